@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/jonathanhope/armaria/cmd/cli/internal/tui"
 	"github.com/jonathanhope/armaria/pkg"
+	"github.com/samber/lo"
 )
 
 // RootCmd is the top level CLI command for Armaria.
@@ -14,12 +21,27 @@ type RootCmd struct {
 	DB        *string   `help:"Location of the bookmarks database."`
 	Formatter Formatter `help:"How to format output: pretty/json." enum:"json,pretty" default:"pretty"`
 
-	Add    AddCmd    `cmd:"" help:"Add a folder, bookmark, or tag."`
-	Remove RemoveCmd `cmd:"" help:"Remove a folder, bookmark, or tag."`
-	Update UpdateCmd `cmd:"" help:"Update a folder or bookmark."`
-	List   ListCmd   `cmd:"" help:"List folders, bookmarks, or tags."`
-	Get    GetCmd    `cmd:"" help:"Get a folder or bookmark."`
-	Query  QueryCmd  `cmd:"" help:"Query folders and bookmarks."`
+	Add     AddCmd     `cmd:"" help:"Add a folder, bookmark, or tag."`
+	Remove  RemoveCmd  `cmd:"" help:"Remove a folder, bookmark, or tag."`
+	Update  UpdateCmd  `cmd:"" help:"Update a folder or bookmark."`
+	Copy    CopyCmd    `cmd:"" help:"Copy a folder or bookmark."`
+	List    ListCmd    `cmd:"" help:"List folders, bookmarks, or tags."`
+	Get     GetCmd     `cmd:"" help:"Get a folder or bookmark."`
+	Query   QueryCmd   `cmd:"" help:"Query folders and bookmarks."`
+	Tree    TreeCmd    `cmd:"" help:"Show the hierarchy of folders and bookmarks."`
+	Dedupe  DedupeCmd  `cmd:"" help:"Find and merge duplicate bookmarks."`
+	Check   CheckCmd   `cmd:"" help:"Check bookmarks for problems."`
+	Refresh RefreshCmd `cmd:"" help:"Refresh bookmarks from the web."`
+	Archive ArchiveCmd `cmd:"" help:"Download offline copies of bookmarked pages."`
+	Open    OpenCmd    `cmd:"" help:"Open a bookmark in the browser."`
+	Undo    UndoCmd    `cmd:"" help:"Undo the most recent change."`
+	Redo    RedoCmd    `cmd:"" help:"Redo the most recently undone change."`
+	History HistoryCmd `cmd:"" help:"List recent changes that can be undone."`
+	Trash   TrashCmd   `cmd:"" help:"Manage removed folders and bookmarks."`
+	Tag     TagCmd     `cmd:"" help:"Rename, merge, or delete tags on every bookmark."`
+	Bulk    BulkCmd    `cmd:"" help:"Change many folders and bookmarks at once."`
+	Import  ImportCmd  `cmd:"" help:"Import bookmarks from another bookmarks manager."`
+	Export  ExportCmd  `cmd:"" help:"Export bookmarks to another bookmarks manager."`
 
 	Config   ConfigCmd   `cmd:"" help:"Manage the configuration."`
 	Manifest ManifestCmd `cmd:"" help:"Manage the app manifest."`
@@ -37,9 +59,10 @@ func RootCmdFactory() RootCmd {
 
 // AddCmd is a CLI command to add a bookmark or folder.
 type AddCmd struct {
-	Book   AddBookCmd   `cmd:"" help:"Add a bookmark."`
-	Folder AddFolderCmd `cmd:"" help:"Add a folder."`
-	Tag    AddTagsCmd   `cmd:"" help:"Add tags to a bookmark."`
+	Book    AddBookCmd    `cmd:"" help:"Add a bookmark."`
+	Folder  AddFolderCmd  `cmd:"" help:"Add a folder."`
+	Tag     AddTagsCmd    `cmd:"" help:"Add tags to a bookmark."`
+	Session AddSessionCmd `cmd:"" help:"Add a folder with a bookmark for each URL."`
 }
 
 // ListCmd is a CLI command to list bookmarks/folders/tags.
@@ -57,6 +80,12 @@ type UpdateCmd struct {
 	Folder UpdateFolderCmd `cmd:"" help:"Update a folder."`
 }
 
+// CopyCmd is a CLI command to copy a folder or bookmark.
+type CopyCmd struct {
+	Book   CopyBookCmd   `cmd:"" help:"Copy a bookmark."`
+	Folder CopyFolderCmd `cmd:"" help:"Copy a folder and everything in it."`
+}
+
 // RemoveCmd is a CLI command to remove a folder or bookmark.
 type RemoveCmd struct {
 	Book   RemoveBookCmd   `cmd:"" help:"Remove a bookmark."`
@@ -69,6 +98,73 @@ type GetCmd struct {
 	All GetAllCmd `cmd:"" help:"Get a bookmark or folder."`
 }
 
+// DedupeCmd is a CLI command to find and merge duplicate bookmarks.
+type DedupeCmd struct {
+	List  DedupeListCmd  `cmd:"" default:"1" help:"List bookmarks that have the same URL."`
+	Merge DedupeMergeCmd `cmd:"" help:"Merge duplicate bookmarks into a surviving bookmark."`
+}
+
+// CheckCmd is a CLI command to check bookmarks for problems.
+type CheckCmd struct {
+	Links  CheckLinksCmd  `cmd:"" help:"Check whether the URLs of bookmarks still resolve."`
+	Broken CheckBrokenCmd `cmd:"" help:"List bookmarks whose URLs were broken when they were last checked."`
+}
+
+// RefreshCmd is a CLI command to refresh bookmarks from the web.
+type RefreshCmd struct {
+	Metadata RefreshMetadataCmd `cmd:"" help:"Fill in the names and descriptions of bookmarks from their pages."`
+	Favicons RefreshFaviconsCmd `cmd:"" help:"Download and store the icons of bookmarks' sites."`
+}
+
+// TrashCmd is a CLI command to manage removed bookmarks/folders.
+type TrashCmd struct {
+	List    TrashListCmd    `cmd:"" default:"1" help:"List removed bookmarks and folders."`
+	Restore TrashRestoreCmd `cmd:"" help:"Restore a removed bookmark or folder."`
+	Empty   TrashEmptyCmd   `cmd:"" help:"Permanently delete removed bookmarks and folders."`
+}
+
+// TagCmd is a CLI command to manage tags across every bookmark.
+type TagCmd struct {
+	Rename TagRenameCmd `cmd:"" help:"Rename a tag on every bookmark."`
+	Merge  TagMergeCmd  `cmd:"" help:"Merge tags into a single tag on every bookmark."`
+	Delete TagDeleteCmd `cmd:"" help:"Remove a tag from every bookmark."`
+	Tree   TagTreeCmd   `cmd:"" help:"Show the hierarchy of tags."`
+}
+
+// BulkCmd is a CLI command to change many bookmarks/folders at once.
+// The bookmarks/folders are selected with the same filters as list all or with IDs read from stdin.
+type BulkCmd struct {
+	Folder    *string         `help:"Folder to select bookmarks/folders in."`
+	NoFolder  bool            `help:"Select top level bookmarks/folders."`
+	Query     *string         `help:"Query to select bookmarks/folders by."`
+	Tag       []string        `help:"Tag to select bookmarks/folders by; lang/* matches every tag underneath lang."`
+	TagMode   armaria.TagMode `help:"Whether bookmarks/folders need any or all of the tags: any/all." enum:"any,all" default:"any"`
+	NotTag    []string        `help:"Tag to exclude bookmarks/folders by."`
+	Recursive bool            `help:"Select bookmarks/folders anywhere under the folder instead of just its direct children."`
+	Stdin     bool            `help:"Select bookmarks/folders by ID; read one per line from stdin."`
+	DryRun    bool            `help:"Show the bookmarks/folders that would be changed without changing them."`
+
+	AddTags     BulkAddTagsCmd     `cmd:"" help:"Add tags to the selected bookmarks."`
+	RemoveTags  BulkRemoveTagsCmd  `cmd:"" help:"Remove tags from the selected bookmarks."`
+	Move        BulkMoveCmd        `cmd:"" help:"Move the selected bookmarks/folders to a folder."`
+	Remove      BulkRemoveCmd      `cmd:"" help:"Move the selected bookmarks/folders to the trash."`
+	Description BulkDescriptionCmd `cmd:"" help:"Update the description of the selected bookmarks."`
+}
+
+// ImportCmd is a CLI command to import bookmarks.
+type ImportCmd struct {
+	HTML    ImportHTMLCmd    `cmd:"" name:"html" help:"Import bookmarks from a Netscape bookmark file."`
+	JSON    ImportJSONCmd    `cmd:"" name:"json" help:"Restore bookmarks from a JSON backup."`
+	Firefox ImportFirefoxCmd `cmd:"" name:"firefox" help:"Import bookmarks from a Firefox profile."`
+	Chrome  ImportChromeCmd  `cmd:"" name:"chrome" help:"Import bookmarks from a Chrome or Chromium profile."`
+}
+
+// ExportCmd is a CLI command to export bookmarks.
+type ExportCmd struct {
+	HTML ExportHTMLCmd `cmd:"" name:"html" help:"Export bookmarks to a Netscape bookmark file."`
+	JSON ExportJSONCmd `cmd:"" name:"json" help:"Backup bookmarks to a JSON file."`
+}
+
 // ConfigCmd is a CLI command to manage config.
 type ConfigCmd struct {
 	DB DBConfigCmd `cmd:"" help:"Manage the bookmarks database location configuration."`
@@ -99,6 +195,10 @@ type AddBookCmd struct {
 	Description *string  `help:"Description of the bookmark."`
 	Tag         []string `help:"Tag to apply to the bookmark."`
 
+	RejectDuplicates bool `help:"Don't add the bookmark if one with the same URL already exists."`
+	FetchMetadata    bool `help:"Download the page to fill in the name and description if they aren't provided."`
+	Canonical        bool `help:"Use the canonical URL of the page when fetching metadata."`
+
 	URL string `arg:"" name:"url" help:"URL of the bookmark."`
 }
 
@@ -122,6 +222,15 @@ func (r *AddBookCmd) Run(ctx *Context) error {
 	if r.Tag != nil {
 		options.WithTags(r.Tag)
 	}
+	if r.RejectDuplicates {
+		options.WithRejectDuplicates(true)
+	}
+	if r.FetchMetadata {
+		options.WithFetchMetadata(true)
+	}
+	if r.Canonical {
+		options.WithUseCanonical(true)
+	}
 
 	book, err := armaria.AddBook(r.URL, options)
 	if err != nil {
@@ -138,6 +247,60 @@ func (r *AddBookCmd) Run(ctx *Context) error {
 	return nil
 }
 
+// AddSessionCmd is a CLI command to add a folder with a bookmark for each URL.
+type AddSessionCmd struct {
+	Folder *string  `help:"Folder to add the session folder to."`
+	Name   string   `help:"Name for the session folder." required:""`
+	URL    []string `name:"url" help:"URL to add to the session. Read one per line from stdin if not provided."`
+	Tag    []string `help:"Tag to apply to every bookmark in the session."`
+}
+
+// Run add a folder with a bookmark for each URL.
+func (r *AddSessionCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	urls := r.URL
+	if len(urls) == 0 && ctx.Reader != nil {
+		scanner := bufio.NewScanner(ctx.Reader)
+		for scanner.Scan() {
+			if url := strings.TrimSpace(scanner.Text()); url != "" {
+				urls = append(urls, url)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+	}
+
+	options := armaria.DefaultAddSessionOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+	if r.Tag != nil {
+		options.WithTags(r.Tag)
+	}
+
+	books, err := armaria.AddSession(r.Name, urls, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, books)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Added in %s", elapsed))
+
+	return nil
+}
+
 // AddFolderCmd is a CLI command to add a folder.
 type AddFolderCmd struct {
 	Folder *string `help:"Folder to add this folder to."`
@@ -205,14 +368,20 @@ func (r *AddTagsCmd) Run(ctx *Context) error {
 
 // ListAllCmd is a CLI command to list bookmarks and folders.
 type ListAllCmd struct {
-	Folder   *string           `help:"Folder to list bookmarks/folders in."`
-	NoFolder bool              `help:"List top level bookmarks/folders."`
-	After    *string           `help:"ID of bookmark/folder to return results after."`
-	Query    *string           `help:"Query to search bookmarks/folders by."`
-	Tag      []string          `help:"Tag to filter bookmarks/folders by."`
-	Order    armaria.Order     `help:"Field results are ordered on: modified/name/manual." enum:"modified,name,manual" default:"manual"`
-	Dir      armaria.Direction `help:"Direction results are ordered by: asc/desc." enum:"asc,desc" default:"asc"`
-	First    *int64            `help:"The max number of bookmarks/folders to return."`
+	Folder        *string           `help:"Folder to list bookmarks/folders in."`
+	NoFolder      bool              `help:"List top level bookmarks/folders."`
+	After         *string           `help:"ID of bookmark/folder to return results after."`
+	Query         *string           `help:"Query to search bookmarks/folders by."`
+	Tag           []string          `help:"Tag to filter bookmarks/folders by; lang/* matches every tag underneath lang."`
+	TagMode       armaria.TagMode   `help:"Whether bookmarks/folders need any or all of the tags: any/all." enum:"any,all" default:"any"`
+	NotTag        []string          `help:"Tag to exclude bookmarks/folders by."`
+	Recursive     bool              `help:"List bookmarks/folders anywhere under the folder instead of just its direct children."`
+	Depth         *int64            `help:"How many levels deep to list bookmarks/folders; implies --recursive."`
+	ModifiedAfter *string           `help:"Only list bookmarks/folders modified after this UTC time: YYYY-MM-DD or YYYY-MM-DD HH:MM:SS."`
+	CreatedBefore *string           `help:"Only list bookmarks/folders created before this UTC time: YYYY-MM-DD or YYYY-MM-DD HH:MM:SS."`
+	Order         armaria.Order     `help:"Field results are ordered on: modified/name/manual/relevance." enum:"modified,name,manual,relevance" default:"manual"`
+	Dir           armaria.Direction `help:"Direction results are ordered by: asc/desc." enum:"asc,desc" default:"asc"`
+	First         *int64            `help:"The max number of bookmarks/folders to return."`
 }
 
 // Run list bookmarks and folders.
@@ -226,8 +395,6 @@ func (r *ListAllCmd) Run(ctx *Context) error {
 	}
 
 	options := armaria.DefaultListBooksOptions()
-	options.WithFolders(true)
-	options.WithBooks(true)
 	if ctx.DB != nil {
 		options.WithDB(*ctx.DB)
 	}
@@ -246,6 +413,36 @@ func (r *ListAllCmd) Run(ctx *Context) error {
 	if r.Tag != nil {
 		options.WithTags(r.Tag)
 	}
+	if r.TagMode != "" {
+		options.WithTagMode(r.TagMode)
+	}
+	if r.NotTag != nil {
+		options.WithExcludeTags(r.NotTag)
+	}
+	if r.Recursive {
+		options.WithRecursive(true)
+	}
+	if r.Depth != nil {
+		options.WithDepth(*r.Depth)
+	}
+	if r.ModifiedAfter != nil {
+		modified, err := parseTimestamp(*r.ModifiedAfter)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+		options.WithModifiedAfter(modified)
+	}
+	if r.CreatedBefore != nil {
+		created, err := parseTimestamp(*r.CreatedBefore)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+		options.WithCreatedBefore(created)
+	}
 	if r.Order != "" {
 		options.WithOrder(r.Order)
 	}
@@ -273,14 +470,20 @@ func (r *ListAllCmd) Run(ctx *Context) error {
 
 // ListBooksCmd is a CLI command to list bookmarks.
 type ListBooksCmd struct {
-	Folder   *string           `help:"Folder to list bookmarks in."`
-	NoFolder bool              `help:"List top level bookmarks."`
-	After    *string           `help:"ID of bookmark to return results after."`
-	Query    *string           `help:"Query to search bookmarks by."`
-	Tag      []string          `help:"Tag to filter bookmarks by."`
-	Order    armaria.Order     `help:"Field results are ordered on: modified/name/manual." enum:"modified,name,manual" default:"manual"`
-	Dir      armaria.Direction `help:"Direction results are ordered by: asc/desc." enum:"asc,desc" default:"asc"`
-	First    *int64            `help:"The max number of bookmarks to return."`
+	Folder        *string           `help:"Folder to list bookmarks in."`
+	NoFolder      bool              `help:"List top level bookmarks."`
+	After         *string           `help:"ID of bookmark to return results after."`
+	Query         *string           `help:"Query to search bookmarks by."`
+	Tag           []string          `help:"Tag to filter bookmarks by; lang/* matches every tag underneath lang."`
+	TagMode       armaria.TagMode   `help:"Whether bookmarks need any or all of the tags: any/all." enum:"any,all" default:"any"`
+	NotTag        []string          `help:"Tag to exclude bookmarks by."`
+	Recursive     bool              `help:"List bookmarks anywhere under the folder instead of just its direct children."`
+	Depth         *int64            `help:"How many levels deep to list bookmarks; implies --recursive."`
+	ModifiedAfter *string           `help:"Only list bookmarks modified after this UTC time: YYYY-MM-DD or YYYY-MM-DD HH:MM:SS."`
+	CreatedBefore *string           `help:"Only list bookmarks created before this UTC time: YYYY-MM-DD or YYYY-MM-DD HH:MM:SS."`
+	Order         armaria.Order     `help:"Field results are ordered on: modified/name/manual/relevance." enum:"modified,name,manual,relevance" default:"manual"`
+	Dir           armaria.Direction `help:"Direction results are ordered by: asc/desc." enum:"asc,desc" default:"asc"`
+	First         *int64            `help:"The max number of bookmarks to return."`
 }
 
 // Run list bookmarks.
@@ -314,6 +517,36 @@ func (r *ListBooksCmd) Run(ctx *Context) error {
 	if r.Tag != nil {
 		options.WithTags(r.Tag)
 	}
+	if r.TagMode != "" {
+		options.WithTagMode(r.TagMode)
+	}
+	if r.NotTag != nil {
+		options.WithExcludeTags(r.NotTag)
+	}
+	if r.Recursive {
+		options.WithRecursive(true)
+	}
+	if r.Depth != nil {
+		options.WithDepth(*r.Depth)
+	}
+	if r.ModifiedAfter != nil {
+		modified, err := parseTimestamp(*r.ModifiedAfter)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+		options.WithModifiedAfter(modified)
+	}
+	if r.CreatedBefore != nil {
+		created, err := parseTimestamp(*r.CreatedBefore)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+		options.WithCreatedBefore(created)
+	}
 	if r.Order != "" {
 		options.WithOrder(r.Order)
 	}
@@ -341,14 +574,20 @@ func (r *ListBooksCmd) Run(ctx *Context) error {
 
 // ListFoldersCmd is a CLI command to list folders.
 type ListFoldersCmd struct {
-	Folder   *string           `help:"Folder to list folders in."`
-	NoFolder bool              `help:"List top level folders."`
-	After    *string           `help:"ID of folder to return results after."`
-	Query    *string           `help:"Query to search folders by."`
-	Tag      []string          `help:"Tag to filter folders by."`
-	Order    armaria.Order     `help:"Field results are ordered on: modified/name/manual." enum:"modified,name,manual" default:"manual"`
-	Dir      armaria.Direction `help:"Direction results are ordered by: asc/desc." enum:"asc,desc" default:"asc"`
-	First    *int64            `help:"The max number of folders to return."`
+	Folder        *string           `help:"Folder to list folders in."`
+	NoFolder      bool              `help:"List top level folders."`
+	After         *string           `help:"ID of folder to return results after."`
+	Query         *string           `help:"Query to search folders by."`
+	Tag           []string          `help:"Tag to filter folders by."`
+	TagMode       armaria.TagMode   `help:"Whether folders need any or all of the tags: any/all." enum:"any,all" default:"any"`
+	NotTag        []string          `help:"Tag to exclude folders by."`
+	Recursive     bool              `help:"List folders anywhere under the folder instead of just its direct children."`
+	Depth         *int64            `help:"How many levels deep to list folders; implies --recursive."`
+	ModifiedAfter *string           `help:"Only list folders modified after this UTC time: YYYY-MM-DD or YYYY-MM-DD HH:MM:SS."`
+	CreatedBefore *string           `help:"Only list folders created before this UTC time: YYYY-MM-DD or YYYY-MM-DD HH:MM:SS."`
+	Order         armaria.Order     `help:"Field results are ordered on: modified/name/manual/relevance." enum:"modified,name,manual,relevance" default:"manual"`
+	Dir           armaria.Direction `help:"Direction results are ordered by: asc/desc." enum:"asc,desc" default:"asc"`
+	First         *int64            `help:"The max number of folders to return."`
 }
 
 // Run list folders.
@@ -382,6 +621,36 @@ func (r *ListFoldersCmd) Run(ctx *Context) error {
 	if r.Tag != nil {
 		options.WithTags(r.Tag)
 	}
+	if r.TagMode != "" {
+		options.WithTagMode(r.TagMode)
+	}
+	if r.NotTag != nil {
+		options.WithExcludeTags(r.NotTag)
+	}
+	if r.Recursive {
+		options.WithRecursive(true)
+	}
+	if r.Depth != nil {
+		options.WithDepth(*r.Depth)
+	}
+	if r.ModifiedAfter != nil {
+		modified, err := parseTimestamp(*r.ModifiedAfter)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+		options.WithModifiedAfter(modified)
+	}
+	if r.CreatedBefore != nil {
+		created, err := parseTimestamp(*r.CreatedBefore)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+		options.WithCreatedBefore(created)
+	}
 	if r.Order != "" {
 		options.WithOrder(r.Order)
 	}
@@ -409,10 +678,11 @@ func (r *ListFoldersCmd) Run(ctx *Context) error {
 
 // ListTagsCmd is a CLI command to list tags.
 type ListTagsCmd struct {
-	Query *string           `help:"Query to search tags by."`
-	After *string           `help:"ID of tags to return results after."`
-	Dir   armaria.Direction `help:"Direction results are ordered by: asc/desc." enum:"asc,desc" default:"asc"`
-	First *int64            `help:"The max number of tags to return."`
+	Query  *string           `help:"Query to search tags by."`
+	After  *string           `help:"ID of tags to return results after."`
+	Dir    armaria.Direction `help:"Direction results are ordered by: asc/desc." enum:"asc,desc" default:"asc"`
+	First  *int64            `help:"The max number of tags to return."`
+	Counts bool              `help:"Include how many bookmarks each tag is applied to."`
 }
 
 // Run list tags.
@@ -436,6 +706,22 @@ func (r *ListTagsCmd) Run(ctx *Context) error {
 		options.WithFirst(*r.First)
 	}
 
+	if r.Counts {
+		counts, err := armaria.ListTagCounts(options)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+
+		elapsed := time.Since(start)
+
+		formatTagCountResults(ctx.Writer, ctx.Formatter, counts)
+		formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Listed in %s", elapsed))
+
+		return nil
+	}
+
 	tags, err := armaria.ListTags(options)
 	if err != nil {
 		formatError(ctx.Writer, ctx.Formatter, err)
@@ -610,6 +896,94 @@ func (r *UpdateFolderCmd) Run(ctx *Context) error {
 	return nil
 }
 
+// CopyBookCmd is a CLI command to copy a bookmark.
+type CopyBookCmd struct {
+	Folder   *string `help:"Folder to copy this bookmark to."`
+	NoFolder bool    `help:"Copy this bookmark to the top level."`
+
+	ID string `arg:"" name:"id" help:"ID of the bookmark to copy."`
+}
+
+// Run copy a bookmark.
+func (r *CopyBookCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	if r.NoFolder && r.Folder != nil {
+		formatError(ctx.Writer, ctx.Formatter, ErrFolderNoFolderMutuallyExclusive)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	options := armaria.DefaultCopyBookOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.NoFolder {
+		options.WithoutParentID()
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+
+	book, err := armaria.CopyBook(r.ID, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, []armaria.Book{book})
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Copied in %s", elapsed))
+
+	return nil
+}
+
+// CopyFolderCmd is a CLI command to copy a folder.
+type CopyFolderCmd struct {
+	Folder   *string `help:"Folder to copy this folder to."`
+	NoFolder bool    `help:"Copy this folder to the top level."`
+
+	ID string `arg:"" name:"id" help:"ID of the folder to copy."`
+}
+
+// Run copy a folder.
+func (r *CopyFolderCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	if r.NoFolder && r.Folder != nil {
+		formatError(ctx.Writer, ctx.Formatter, ErrFolderNoFolderMutuallyExclusive)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	options := armaria.DefaultCopyFolderOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.NoFolder {
+		options.WithoutParentID()
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+
+	book, err := armaria.CopyFolder(r.ID, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, []armaria.Book{book})
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Copied in %s", elapsed))
+
+	return nil
+}
+
 // RemoveBookCmd is a CLI command to remove a bookmark.
 type RemoveBookCmd struct {
 	ID string `arg:"" name:"id" help:"ID of the bookmark to remove."`
@@ -861,7 +1235,7 @@ func (r *VersionCmd) Run(ctx *Context) error {
 type QueryCmd struct {
 	First int64 `help:"The max number of bookmarks/folders to return." default:"5"`
 
-	Query string `arg:"" name:"query" help:"Query to search by."`
+	Query string `arg:"" name:"query" help:"Query to search by. Supports \"phrases\", prefix*, tag:, folder:, url:, name:, description:, modified:, created:, AND, OR, NOT, -, and parentheses."`
 }
 
 // Run query bookmarks.
@@ -869,13 +1243,12 @@ func (r *QueryCmd) Run(ctx *Context) error {
 	start := time.Now()
 
 	options := armaria.DefaultListBooksOptions()
-	options.WithFolders(true)
-	options.WithBooks(true)
 	if ctx.DB != nil {
 		options.WithDB(*ctx.DB)
 	}
 	options.WithFirst(r.First)
 	options.WithQuery(r.Query)
+	options.WithOrder(armaria.OrderRelevance)
 
 	books, err := armaria.ListBooks(options)
 	if err != nil {
@@ -892,18 +1265,1291 @@ func (r *QueryCmd) Run(ctx *Context) error {
 	return nil
 }
 
-// TUICommand is a CLI command to start the TUI.
-type TUICommand struct {
+// TreeCmd is a CLI command to show the hierarchy of folders and bookmarks.
+type TreeCmd struct {
+	Folder *string `help:"Folder to show the hierarchy of."`
+	Depth  *int64  `help:"How many levels deep to show."`
+	URLs   bool    `name:"urls" help:"Show the URLs of bookmarks."`
+	Tags   bool    `help:"Show the tags of bookmarks."`
 }
 
-// Run start the TUI.
-func (r *TUICommand) Run(ctx *Context) error {
-	p := tui.Program()
-	if _, err := p.Run(); err != nil {
+// Run show the hierarchy of folders and bookmarks.
+func (r *TreeCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	var root *armaria.Book
+	if r.Folder != nil {
+		getOptions := armaria.DefaultGetBookOptions()
+		if ctx.DB != nil {
+			getOptions.WithDB(*ctx.DB)
+		}
+
+		folder, err := armaria.GetBook(*r.Folder, getOptions)
+		if err == nil && !folder.IsFolder {
+			err = armaria.ErrFolderNotFound
+		}
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+
+		root = &folder
+	}
+
+	options := armaria.DefaultListBooksOptions()
+	options.WithRecursive(true)
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+	if r.Depth != nil {
+		options.WithDepth(*r.Depth)
+	}
+
+	books, err := armaria.ListBooks(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatTreeResults(ctx.Writer, ctx.Formatter, root, books, r.URLs, r.Tags)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Listed in %s", elapsed))
+
+	return nil
+}
+
+// DedupeListCmd is a CLI command to list bookmarks that have the same URL.
+type DedupeListCmd struct {
+}
+
+// Run list bookmarks that have the same URL.
+func (r *DedupeListCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultFindDuplicatesOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	duplicates, err := armaria.FindDuplicates(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatDuplicateResults(ctx.Writer, ctx.Formatter, duplicates)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Found in %s", elapsed))
+
+	return nil
+}
+
+// DedupeMergeCmd is a CLI command to merge duplicate bookmarks into a surviving bookmark.
+type DedupeMergeCmd struct {
+	ID         string   `arg:"" name:"id" help:"ID of the bookmark to keep."`
+	Duplicates []string `arg:"" name:"duplicates" help:"IDs of the bookmarks to merge into it."`
+}
+
+// Run merge duplicate bookmarks into a surviving bookmark.
+func (r *DedupeMergeCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultMergeBooksOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	book, err := armaria.MergeBooks(r.ID, r.Duplicates, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, []armaria.Book{book})
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Merged in %s", elapsed))
+
+	return nil
+}
+
+// CheckLinksCmd is a CLI command to check whether the URLs of bookmarks still resolve.
+type CheckLinksCmd struct {
+	Folder       *string        `help:"Only check bookmarks anywhere under this folder."`
+	Concurrency  *int           `help:"How many URLs to check at once."`
+	Timeout      *time.Duration `help:"How long to wait for a URL before giving up on it."`
+	HostInterval *time.Duration `help:"How long to wait between requests to the same host."`
+	Broken       bool           `help:"Only show bookmarks whose URLs are broken."`
+	Tag          *string        `help:"Tag to apply to bookmarks whose URLs are broken."`
+	Move         *string        `help:"Folder to move bookmarks whose URLs are broken into."`
+}
+
+// Run check whether the URLs of bookmarks still resolve.
+func (r *CheckLinksCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultCheckLinksOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+	if r.Concurrency != nil {
+		options.WithConcurrency(*r.Concurrency)
+	}
+	if r.Timeout != nil {
+		options.WithTimeout(*r.Timeout)
+	}
+	if r.HostInterval != nil {
+		options.WithHostInterval(*r.HostInterval)
+	}
+
+	checks, err := armaria.CheckLinks(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	checks, err = fileBrokenLinks(ctx, checks, r.Tag, r.Move)
+	if err != nil {
 		formatError(ctx.Writer, ctx.Formatter, err)
 		ctx.ReturnCode(1)
 		return nil
 	}
 
+	if r.Broken {
+		checks = lo.Filter(checks, func(check armaria.LinkCheck, _ int) bool {
+			return check.Broken()
+		})
+	}
+
+	elapsed := time.Since(start)
+
+	formatLinkCheckResults(ctx.Writer, ctx.Formatter, checks)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Checked in %s", elapsed))
+
 	return nil
 }
+
+// CheckBrokenCmd is a CLI command to list bookmarks whose URLs were broken when they were last checked.
+type CheckBrokenCmd struct {
+	Folder *string `help:"Only list bookmarks anywhere under this folder."`
+	Tag    *string `help:"Tag to apply to the broken bookmarks."`
+	Move   *string `help:"Folder to move the broken bookmarks into."`
+}
+
+// Run list bookmarks whose URLs were broken when they were last checked.
+func (r *CheckBrokenCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultListLinkChecksOptions()
+	options.WithBrokenOnly(true)
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+
+	checks, err := armaria.ListLinkChecks(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	checks, err = fileBrokenLinks(ctx, checks, r.Tag, r.Move)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatLinkCheckResults(ctx.Writer, ctx.Formatter, checks)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Listed in %s", elapsed))
+
+	return nil
+}
+
+// fileBrokenLinks tags and/or moves the bookmarks whose URLs are broken.
+// Either every broken bookmark is filed or none of them are.
+func fileBrokenLinks(ctx *Context, checks []armaria.LinkCheck, tag *string, folder *string) ([]armaria.LinkCheck, error) {
+	if tag == nil && folder == nil {
+		return checks, nil
+	}
+
+	filed := make([]armaria.LinkCheck, len(checks))
+	copy(filed, checks)
+
+	batchOptions := armaria.DefaultBatchOptions()
+	if ctx.DB != nil {
+		batchOptions.WithDB(*ctx.DB)
+	}
+
+	err := armaria.RunBatch(func(batch *armaria.Batch) error {
+		for i, check := range filed {
+			if !check.Broken() {
+				continue
+			}
+
+			book := check.Book
+
+			if tag != nil && !lo.Contains(book.Tags, *tag) {
+				tagged, err := batch.AddTags(book.ID, []string{*tag}, armaria.DefaultAddTagsOptions())
+				if err != nil {
+					return err
+				}
+				book = tagged
+			}
+
+			if folder != nil && (book.ParentID == nil || *book.ParentID != *folder) {
+				options := armaria.DefaultUpdateBookOptions()
+				options.WithParentID(*folder)
+
+				moved, err := batch.UpdateBook(book.ID, options)
+				if err != nil {
+					return err
+				}
+				book = moved
+			}
+
+			path, err := batch.GetParentNames(book.ID, armaria.DefaultGetParentNameOptions())
+			if err != nil {
+				return err
+			}
+			book.Path = path
+
+			filed[i].Book = book
+		}
+
+		return nil
+	}, batchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return filed, nil
+}
+
+// RefreshMetadataCmd is a CLI command to fill in the names and descriptions of bookmarks from their pages.
+type RefreshMetadataCmd struct {
+	Folder    *string        `help:"Only refresh bookmarks anywhere under this folder."`
+	Overwrite bool           `help:"Replace names and descriptions that have already been set."`
+	Canonical bool           `help:"Replace URLs with the canonical URL of their page."`
+	Timeout   *time.Duration `help:"How long to wait for a page before giving up on it."`
+}
+
+// Run fill in the names and descriptions of bookmarks from their pages.
+func (r *RefreshMetadataCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultRefreshMetadataOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+	if r.Overwrite {
+		options.WithOverwrite(true)
+	}
+	if r.Canonical {
+		options.WithUseCanonical(true)
+	}
+	if r.Timeout != nil {
+		options.WithHTTPClient(&http.Client{Timeout: *r.Timeout})
+	}
+
+	books, err := armaria.RefreshMetadata(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, books)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Refreshed in %s", elapsed))
+
+	return nil
+}
+
+// RefreshFaviconsCmd is a CLI command to download and store the icons of bookmarks' sites.
+type RefreshFaviconsCmd struct {
+	Folder    *string        `help:"Only refresh bookmarks anywhere under this folder."`
+	Overwrite bool           `help:"Download icons again for bookmarks that already have one."`
+	Timeout   *time.Duration `help:"How long to wait for a page or icon before giving up on it."`
+}
+
+// Run download and store the icons of bookmarks' sites.
+func (r *RefreshFaviconsCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultRefreshFaviconsOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+	if r.Overwrite {
+		options.WithOverwrite(true)
+	}
+	if r.Timeout != nil {
+		options.WithHTTPClient(&http.Client{Timeout: *r.Timeout})
+	}
+
+	favicons, err := armaria.RefreshFavicons(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatFaviconResults(ctx.Writer, ctx.Formatter, favicons)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Refreshed in %s", elapsed))
+
+	return nil
+}
+
+// ArchiveCmd is a CLI command to download offline copies of bookmarked pages.
+type ArchiveCmd struct {
+	AllMissing bool           `help:"Archive every bookmark that hasn't been archived yet."`
+	Inline     bool           `help:"Inline stylesheets and images so each page is a single file."`
+	Timeout    *time.Duration `help:"How long to wait for a page before giving up on it."`
+
+	ID *string `arg:"" optional:"" name:"id" help:"ID of the bookmark to archive."`
+}
+
+// Run download offline copies of bookmarked pages.
+func (r *ArchiveCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	if r.ID != nil && r.AllMissing {
+		formatError(ctx.Writer, ctx.Formatter, ErrIDAllMissingMutuallyExclusive)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	if r.ID == nil && !r.AllMissing {
+		formatError(ctx.Writer, ctx.Formatter, ErrIDOrAllMissingRequired)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	var client *http.Client
+	if r.Timeout != nil {
+		client = &http.Client{Timeout: *r.Timeout}
+	}
+
+	var archives []armaria.Archive
+	if r.AllMissing {
+		options := armaria.DefaultArchiveMissingOptions()
+		if ctx.DB != nil {
+			options.WithDB(*ctx.DB)
+		}
+		if r.Inline {
+			options.WithInline(true)
+		}
+		if client != nil {
+			options.WithHTTPClient(client)
+		}
+
+		var err error
+		archives, err = armaria.ArchiveMissing(options)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+	} else {
+		options := armaria.DefaultArchiveBookOptions()
+		if ctx.DB != nil {
+			options.WithDB(*ctx.DB)
+		}
+		if r.Inline {
+			options.WithInline(true)
+		}
+		if client != nil {
+			options.WithHTTPClient(client)
+		}
+
+		archive, err := armaria.ArchiveBook(*r.ID, options)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+		archives = []armaria.Archive{archive}
+	}
+
+	elapsed := time.Since(start)
+
+	formatArchiveResults(ctx.Writer, ctx.Formatter, archives)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Archived in %s", elapsed))
+
+	return nil
+}
+
+// OpenCmd is a CLI command to open a bookmark in the browser.
+type OpenCmd struct {
+	Archived bool `help:"Open the offline copy of the page instead of the live page."`
+
+	ID string `arg:"" name:"id" help:"ID of the bookmark to open."`
+}
+
+// Run open a bookmark in the browser.
+func (r *OpenCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultGetBookOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	book, err := armaria.GetBook(r.ID, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	if book.IsFolder {
+		formatError(ctx.Writer, ctx.Formatter, armaria.ErrBookNotFound)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	target := *book.URL
+	if r.Archived {
+		target, err = writeArchive(ctx, r.ID)
+		if err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+	}
+
+	if err := ctx.Open(target); err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, []armaria.Book{book})
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Opened in %s", elapsed))
+
+	return nil
+}
+
+// UndoCmd is a CLI command to undo the most recent change.
+type UndoCmd struct{}
+
+// Run undo the most recent change.
+func (r *UndoCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultUndoOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	entry, err := armaria.Undo(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatJournalEntryResult(ctx.Writer, ctx.Formatter, entry)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Undone in %s", elapsed))
+
+	return nil
+}
+
+// RedoCmd is a CLI command to redo the most recently undone change.
+type RedoCmd struct{}
+
+// Run redo the most recently undone change.
+func (r *RedoCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultRedoOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	entry, err := armaria.Redo(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatJournalEntryResult(ctx.Writer, ctx.Formatter, entry)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Redone in %s", elapsed))
+
+	return nil
+}
+
+// HistoryCmd is a CLI command to list recent changes.
+type HistoryCmd struct {
+	First *int64 `help:"The max number of changes to return."`
+}
+
+// Run list recent changes.
+func (r *HistoryCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultListHistoryOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.First != nil {
+		options.WithFirst(*r.First)
+	}
+
+	entries, err := armaria.ListHistory(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatJournalEntryResults(ctx.Writer, ctx.Formatter, entries)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Listed in %s", elapsed))
+
+	return nil
+}
+
+// TrashListCmd is a CLI command to list removed bookmarks/folders.
+type TrashListCmd struct{}
+
+// Run list removed bookmarks/folders.
+func (r *TrashListCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultListTrashOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	books, err := armaria.ListTrash(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, books)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Listed in %s", elapsed))
+
+	return nil
+}
+
+// TrashRestoreCmd is a CLI command to restore a removed bookmark/folder.
+type TrashRestoreCmd struct {
+	ID string `arg:"" name:"id" help:"ID of the bookmark or folder to restore."`
+}
+
+// Run restore a removed bookmark/folder.
+func (r *TrashRestoreCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultRestoreBookOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	book, err := armaria.RestoreBook(r.ID, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, []armaria.Book{book})
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Restored in %s", elapsed))
+
+	return nil
+}
+
+// TrashEmptyCmd is a CLI command to permanently delete removed bookmarks/folders.
+type TrashEmptyCmd struct {
+	OlderThan *time.Duration `help:"Only delete bookmarks and folders that were removed longer ago than this."`
+}
+
+// Run permanently delete removed bookmarks/folders.
+func (r *TrashEmptyCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultEmptyTrashOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.OlderThan != nil {
+		options.WithOlderThan(*r.OlderThan)
+	}
+
+	books, err := armaria.EmptyTrash(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, books)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Emptied in %s", elapsed))
+
+	return nil
+}
+
+// TagRenameCmd is a CLI command to rename a tag.
+type TagRenameCmd struct {
+	From string `arg:"" name:"from" help:"Tag to rename; tags underneath it are renamed too."`
+	To   string `arg:"" name:"to" help:"New name for the tag."`
+}
+
+// Run rename a tag.
+func (r *TagRenameCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultRenameTagOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	count, err := armaria.RenameTag(r.From, r.To, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatTagCountResult(ctx.Writer, ctx.Formatter, count)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Renamed in %s", elapsed))
+
+	return nil
+}
+
+// TagMergeCmd is a CLI command to merge tags into a single tag.
+type TagMergeCmd struct {
+	Tags []string `arg:"" name:"tags" help:"Tags to merge."`
+	Into string   `help:"Tag to merge the tags into." required:""`
+}
+
+// Run merge tags into a single tag.
+func (r *TagMergeCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultMergeTagsOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	count, err := armaria.MergeTags(r.Tags, r.Into, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatTagCountResult(ctx.Writer, ctx.Formatter, count)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Merged in %s", elapsed))
+
+	return nil
+}
+
+// TagDeleteCmd is a CLI command to delete a tag.
+type TagDeleteCmd struct {
+	Tag string `arg:"" name:"tag" help:"Tag to delete."`
+}
+
+// Run delete a tag.
+func (r *TagDeleteCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultDeleteTagOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	err := armaria.DeleteTag(r.Tag, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Deleted in %s", elapsed))
+
+	return nil
+}
+
+// TagTreeCmd is a CLI command to show the hierarchy of tags.
+type TagTreeCmd struct {
+	Query *string `help:"Query to search tags by."`
+}
+
+// Run show the hierarchy of tags.
+func (r *TagTreeCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultListTagsOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Query != nil {
+		options.WithQuery(*r.Query)
+	}
+
+	tree, err := armaria.ListTagTree(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatTagTreeResults(ctx.Writer, ctx.Formatter, tree)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Listed in %s", elapsed))
+
+	return nil
+}
+
+// bulkOperation is which Bulk operation a bulk command runs.
+type bulkOperation int
+
+const (
+	bulkAddTags           bulkOperation = iota // add tags to the selected bookmarks
+	bulkRemoveTags                             // remove tags from the selected bookmarks
+	bulkMove                                   // move the selected bookmarks/folders to a folder
+	bulkRemove                                 // move the selected bookmarks/folders to the trash
+	bulkUpdateDescription                      // update the description of the selected bookmarks
+)
+
+// bulkChange is a change a bulk command makes to the selected bookmarks/folders.
+type bulkChange struct {
+	operation   bulkOperation // which operation to run
+	tags        []string      // tags to add or remove
+	parentID    *string       // folder to move to; nil is the top level
+	description *string       // description to set; nil removes it
+}
+
+// run selects bookmarks/folders and makes a change to them.
+func (r *BulkCmd) run(ctx *Context, change bulkChange, verb string) error {
+	start := time.Now()
+
+	if r.NoFolder && r.Folder != nil {
+		formatError(ctx.Writer, ctx.Formatter, ErrFolderNoFolderMutuallyExclusive)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	options := armaria.DefaultBulkOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.DryRun {
+		options.WithDryRun(true)
+	}
+
+	if r.Stdin && ctx.Reader != nil {
+		var ids []string
+		scanner := bufio.NewScanner(ctx.Reader)
+		for scanner.Scan() {
+			if id := strings.TrimSpace(scanner.Text()); id != "" {
+				ids = append(ids, id)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+			return nil
+		}
+
+		options.WithIDs(ids)
+	}
+
+	// Selecting everything has to be asked for with a filter.
+	if r.Folder != nil || r.NoFolder || r.Query != nil || r.Tag != nil || r.NotTag != nil {
+		filter := armaria.DefaultListBooksOptions()
+		if r.Folder != nil {
+			filter.WithParentID(*r.Folder)
+		}
+		if r.NoFolder {
+			filter.WithoutParentID()
+		}
+		if r.Query != nil {
+			filter.WithQuery(*r.Query)
+		}
+		if r.Tag != nil {
+			filter.WithTags(r.Tag)
+		}
+		if r.TagMode != "" {
+			filter.WithTagMode(r.TagMode)
+		}
+		if r.NotTag != nil {
+			filter.WithExcludeTags(r.NotTag)
+		}
+		if r.Recursive {
+			filter.WithRecursive(true)
+		}
+
+		options.WithFilter(filter)
+	}
+
+	var books []armaria.Book
+	var err error
+	switch change.operation {
+	case bulkAddTags:
+		books, err = armaria.BulkAddTags(change.tags, options)
+	case bulkRemoveTags:
+		books, err = armaria.BulkRemoveTags(change.tags, options)
+	case bulkMove:
+		books, err = armaria.BulkMove(change.parentID, options)
+	case bulkRemove:
+		books, err = armaria.BulkRemove(options)
+	case bulkUpdateDescription:
+		books, err = armaria.BulkUpdateDescription(change.description, options)
+	}
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	if r.DryRun {
+		verb = "Previewed"
+	}
+
+	formatBookResults(ctx.Writer, ctx.Formatter, books)
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("%s in %s", verb, elapsed))
+
+	return nil
+}
+
+// BulkAddTagsCmd is a CLI command to add tags to many bookmarks.
+type BulkAddTagsCmd struct {
+	Tags []string `arg:"" name:"tags" help:"Tags to add."`
+}
+
+// Run add tags to many bookmarks.
+func (r *BulkAddTagsCmd) Run(ctx *Context, bulk *BulkCmd) error {
+	return bulk.run(ctx, bulkChange{operation: bulkAddTags, tags: r.Tags}, "Updated")
+}
+
+// BulkRemoveTagsCmd is a CLI command to remove tags from many bookmarks.
+type BulkRemoveTagsCmd struct {
+	Tags []string `arg:"" name:"tags" help:"Tags to remove."`
+}
+
+// Run remove tags from many bookmarks.
+func (r *BulkRemoveTagsCmd) Run(ctx *Context, bulk *BulkCmd) error {
+	return bulk.run(ctx, bulkChange{operation: bulkRemoveTags, tags: r.Tags}, "Updated")
+}
+
+// BulkMoveCmd is a CLI command to move many bookmarks/folders to a folder.
+type BulkMoveCmd struct {
+	To       *string `help:"Folder to move the bookmarks/folders to."`
+	TopLevel bool    `help:"Move the bookmarks/folders to the top level."`
+}
+
+// Run move many bookmarks/folders to a folder.
+func (r *BulkMoveCmd) Run(ctx *Context, bulk *BulkCmd) error {
+	if r.To != nil && r.TopLevel {
+		formatError(ctx.Writer, ctx.Formatter, ErrToTopLevelMutuallyExclusive)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	if r.To == nil && !r.TopLevel {
+		formatError(ctx.Writer, ctx.Formatter, ErrToOrTopLevelRequired)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	return bulk.run(ctx, bulkChange{operation: bulkMove, parentID: r.To}, "Moved")
+}
+
+// BulkRemoveCmd is a CLI command to move many bookmarks/folders to the trash.
+type BulkRemoveCmd struct{}
+
+// Run move many bookmarks/folders to the trash.
+func (r *BulkRemoveCmd) Run(ctx *Context, bulk *BulkCmd) error {
+	return bulk.run(ctx, bulkChange{operation: bulkRemove}, "Removed")
+}
+
+// BulkDescriptionCmd is a CLI command to update the description of many bookmarks.
+type BulkDescriptionCmd struct {
+	Description   *string `help:"New description for the bookmarks."`
+	NoDescription bool    `help:"Remove the description."`
+}
+
+// Run update the description of many bookmarks.
+func (r *BulkDescriptionCmd) Run(ctx *Context, bulk *BulkCmd) error {
+	if r.NoDescription && r.Description != nil {
+		formatError(ctx.Writer, ctx.Formatter, ErrDescriptionNoDescriptionMutuallyExclusive)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	if !r.NoDescription && r.Description == nil {
+		formatError(ctx.Writer, ctx.Formatter, ErrDescriptionOrNoDescriptionRequired)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	return bulk.run(ctx, bulkChange{operation: bulkUpdateDescription, description: r.Description}, "Updated")
+}
+
+// writeArchive writes the offline copy of a bookmark's page to a temporary file so it can be opened.
+// The path to the file is returned.
+func writeArchive(ctx *Context, id string) (string, error) {
+	options := armaria.DefaultGetArchiveOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	archive, err := armaria.GetArchive(id, options)
+	if err != nil {
+		return "", err
+	}
+
+	extension := ".html"
+	if archive.ContentType != "text/html" {
+		if extensions, err := mime.ExtensionsByType(archive.ContentType); err == nil && len(extensions) > 0 {
+			extension = extensions[0]
+		}
+	}
+
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("armaria-%s%s", id, extension))
+	if err := os.WriteFile(path, archive.Content, 0600); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// TUICommand is a CLI command to start the TUI.
+type TUICommand struct {
+}
+
+// Run start the TUI.
+func (r *TUICommand) Run(ctx *Context) error {
+	p := tui.Program()
+	if _, err := p.Run(); err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	return nil
+}
+
+// ImportHTMLCmd is a CLI command to import bookmarks from a Netscape bookmark file.
+type ImportHTMLCmd struct {
+	Folder *string `help:"Folder to import the bookmarks into."`
+
+	File string `arg:"" name:"file" help:"Netscape bookmark file to import."`
+}
+
+// Run import bookmarks from a Netscape bookmark file.
+func (r *ImportHTMLCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultImportNetscapeHTMLOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+
+	file, err := os.Open(r.File)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+	defer file.Close()
+
+	result, err := armaria.ImportNetscapeHTML(file, options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, result.Books)
+	formatSuccess(ctx.Writer, ctx.Formatter, importedMessage(result, elapsed))
+
+	return nil
+}
+
+// importedMessage describes an import along with any tags that had to be skipped.
+func importedMessage(result armaria.ImportResult, elapsed time.Duration) string {
+	if len(result.SkippedTags) == 0 {
+		return fmt.Sprintf("Imported in %s", elapsed)
+	}
+
+	return fmt.Sprintf("Imported in %s; skipped invalid tags: %s", elapsed, strings.Join(result.SkippedTags, ", "))
+}
+
+// ExportHTMLCmd is a CLI command to export bookmarks to a Netscape bookmark file.
+type ExportHTMLCmd struct {
+	Folder *string `help:"Folder to export the bookmarks from."`
+	Output *string `help:"File to write the bookmarks to. Defaults to stdout."`
+}
+
+// Run export bookmarks to a Netscape bookmark file.
+func (r *ExportHTMLCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultExportNetscapeHTMLOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+
+	if r.Output == nil {
+		if err := armaria.ExportNetscapeHTML(ctx.Writer, options); err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+		}
+
+		return nil
+	}
+
+	file, err := os.Create(*r.Output)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+	defer file.Close()
+
+	if err := armaria.ExportNetscapeHTML(file, options); err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Exported in %s", elapsed))
+
+	return nil
+}
+
+// ImportJSONCmd is a CLI command to restore bookmarks from a JSON backup.
+type ImportJSONCmd struct {
+	Mode armaria.RestoreMode `help:"How the backup is restored: replace/merge." enum:"replace,merge" default:"merge"`
+
+	File string `arg:"" name:"file" help:"JSON backup to restore."`
+}
+
+// Run restore bookmarks from a JSON backup.
+func (r *ImportJSONCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultImportJSONOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Mode != "" {
+		options.WithMode(r.Mode)
+	}
+
+	file, err := os.Open(r.File)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+	defer file.Close()
+
+	if err := armaria.ImportJSON(file, options); err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Imported in %s", elapsed))
+
+	return nil
+}
+
+// ExportJSONCmd is a CLI command to backup bookmarks to a JSON file.
+type ExportJSONCmd struct {
+	Output *string `help:"File to write the backup to. Defaults to stdout."`
+}
+
+// Run backup bookmarks to a JSON file.
+func (r *ExportJSONCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultExportJSONOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+
+	if r.Output == nil {
+		if err := armaria.ExportJSON(ctx.Writer, options); err != nil {
+			formatError(ctx.Writer, ctx.Formatter, err)
+			ctx.ReturnCode(1)
+		}
+
+		return nil
+	}
+
+	file, err := os.Create(*r.Output)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+	defer file.Close()
+
+	if err := armaria.ExportJSON(file, options); err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatSuccess(ctx.Writer, ctx.Formatter, fmt.Sprintf("Exported in %s", elapsed))
+
+	return nil
+}
+
+// ImportFirefoxCmd is a CLI command to import bookmarks from a Firefox profile.
+type ImportFirefoxCmd struct {
+	Folder  *string `help:"Folder to import the bookmarks into."`
+	Profile *string `help:"Firefox profile folder to import from. Defaults to the default profile."`
+}
+
+// Run import bookmarks from a Firefox profile.
+func (r *ImportFirefoxCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultImportFirefoxOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+	if r.Profile != nil {
+		options.WithProfile(*r.Profile)
+	}
+
+	result, err := armaria.ImportFirefox(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, result.Books)
+	formatSuccess(ctx.Writer, ctx.Formatter, importedMessage(result, elapsed))
+
+	return nil
+}
+
+// ImportChromeCmd is a CLI command to import bookmarks from a Chrome profile.
+type ImportChromeCmd struct {
+	Folder  *string `help:"Folder to import the bookmarks into."`
+	Profile *string `help:"Chrome or Chromium profile folder to import from. Defaults to the default Chrome profile."`
+}
+
+// Run import bookmarks from a Chrome profile.
+func (r *ImportChromeCmd) Run(ctx *Context) error {
+	start := time.Now()
+
+	options := armaria.DefaultImportChromeOptions()
+	if ctx.DB != nil {
+		options.WithDB(*ctx.DB)
+	}
+	if r.Folder != nil {
+		options.WithParentID(*r.Folder)
+	}
+	if r.Profile != nil {
+		options.WithProfile(*r.Profile)
+	}
+
+	result, err := armaria.ImportChrome(options)
+	if err != nil {
+		formatError(ctx.Writer, ctx.Formatter, err)
+		ctx.ReturnCode(1)
+		return nil
+	}
+
+	elapsed := time.Since(start)
+
+	formatBookResults(ctx.Writer, ctx.Formatter, result.Books)
+	formatSuccess(ctx.Writer, ctx.Formatter, importedMessage(result, elapsed))
+
+	return nil
+}
+
+// parseTimestamp parses a UTC time passed to the CLI.
+// Either a date or a date and time can be provided.
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range []string{time.DateTime, time.DateOnly} {
+		if timestamp, err := time.Parse(layout, value); err == nil {
+			return timestamp, nil
+		}
+	}
+
+	return time.Time{}, ErrInvalidTimestamp
+}
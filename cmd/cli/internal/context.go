@@ -6,9 +6,11 @@ import (
 
 // Context is the context for an invocation of Armaria.
 type Context struct {
-	DB         *string   // bookmarks database to use
-	Formatter  Formatter // how to format the output
-	Writer     io.Writer // where to write output
-	ReturnCode func(int) // set the return code
-	Version    string    // the current version of Armaria
+	DB         *string            // bookmarks database to use
+	Formatter  Formatter          // how to format the output
+	Reader     io.Reader          // where to read input
+	Writer     io.Writer          // where to write output
+	ReturnCode func(int)          // set the return code
+	Open       func(string) error // open a URL or file in the browser
+	Version    string             // the current version of Armaria
 }
@@ -9,4 +9,16 @@ var (
 	ErrFolderNoFolderMutuallyExclusive = errors.New("folder/no-folder mutually exclusive")
 	// ErrDescriptionNoDescriptionMutuallyExclusive is returned if no-description and description are both provoded.
 	ErrDescriptionNoDescriptionMutuallyExclusive = errors.New("description/no-description mutually exclusive")
+	// ErrIDAllMissingMutuallyExclusive is returned if an ID and all-missing are both provided.
+	ErrIDAllMissingMutuallyExclusive = errors.New("id/all-missing mutually exclusive")
+	// ErrIDOrAllMissingRequired is returned if neither an ID nor all-missing are provided.
+	ErrIDOrAllMissingRequired = errors.New("id or all-missing required")
+	// ErrDescriptionOrNoDescriptionRequired is returned if neither a description nor no-description are provided.
+	ErrDescriptionOrNoDescriptionRequired = errors.New("description or no-description required")
+	// ErrToTopLevelMutuallyExclusive is returned if to and top-level are both provided.
+	ErrToTopLevelMutuallyExclusive = errors.New("to/top-level mutually exclusive")
+	// ErrToOrTopLevelRequired is returned if neither to nor top-level are provided.
+	ErrToOrTopLevelRequired = errors.New("to or top-level required")
+	// ErrInvalidTimestamp is returned if a time can't be parsed.
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
 )
@@ -64,7 +64,18 @@ func formatSuccess(writer io.Writer, formatter Formatter, message string) {
 // formatError formats an error message.
 func formatError(writer io.Writer, formatter Formatter, err error) {
 	var errorString string
-	if errors.Is(err, armaria.ErrURLTooShort) {
+	var queryError *armaria.QueryError
+	if errors.As(err, &queryError) {
+		errorString = fmt.Sprintf("Invalid query: %s", queryError)
+	} else if errors.Is(err, armaria.ErrNoURLs) {
+		errorString = "No URLs"
+	} else if errors.Is(err, armaria.ErrDuplicateURL) {
+		errorString = "Duplicate URL"
+	} else if errors.Is(err, armaria.ErrMergeIntoSelf) {
+		errorString = "Can't merge a bookmark into itself"
+	} else if errors.Is(err, armaria.ErrNotDuplicate) {
+		errorString = "Bookmarks don't have the same URL"
+	} else if errors.Is(err, armaria.ErrURLTooShort) {
 		errorString = "URL too short"
 	} else if errors.Is(err, armaria.ErrURLTooLong) {
 		errorString = "URL too long"
@@ -92,18 +103,76 @@ func formatError(writer io.Writer, formatter Formatter, err error) {
 		errorString = "Too many tags applied to bookmark"
 	} else if errors.Is(err, armaria.ErrTagInvalidChar) {
 		errorString = "Tag has invalid chars"
+	} else if errors.Is(err, armaria.ErrTagEmptyNamespace) {
+		errorString = "Tag has an empty namespace"
 	} else if errors.Is(err, armaria.ErrNoUpdate) {
 		errorString = "At least one update is required"
 	} else if errors.Is(err, ErrFolderNoFolderMutuallyExclusive) {
 		errorString = "Arguments folder and no-folder are mutually exclusive"
 	} else if errors.Is(err, ErrDescriptionNoDescriptionMutuallyExclusive) {
 		errorString = "Arguments description and no-description are mutually exclusive"
+	} else if errors.Is(err, ErrIDAllMissingMutuallyExclusive) {
+		errorString = "Arguments id and all-missing are mutually exclusive"
+	} else if errors.Is(err, ErrIDOrAllMissingRequired) {
+		errorString = "Either an id or all-missing is required"
+	} else if errors.Is(err, ErrDescriptionOrNoDescriptionRequired) {
+		errorString = "Either a description or no-description is required"
+	} else if errors.Is(err, ErrToTopLevelMutuallyExclusive) {
+		errorString = "Arguments to and top-level are mutually exclusive"
+	} else if errors.Is(err, ErrToOrTopLevelRequired) {
+		errorString = "Either to or top-level is required"
+	} else if errors.Is(err, ErrInvalidTimestamp) {
+		errorString = "Times must be formatted as YYYY-MM-DD or YYYY-MM-DD HH:MM:SS"
+	} else if errors.Is(err, armaria.ErrArchiveNotFound) {
+		errorString = "Archive not found"
 	} else if errors.Is(err, armaria.ErrTagNotFound) {
 		errorString = "Tag not found"
 	} else if errors.Is(err, armaria.ErrFirstTooSmall) {
 		errorString = "First too small"
+	} else if errors.Is(err, armaria.ErrDepthTooSmall) {
+		errorString = "Depth too small"
+	} else if errors.Is(err, armaria.ErrConcurrencyTooSmall) {
+		errorString = "Concurrency too small"
+	} else if errors.Is(err, armaria.ErrTimeoutTooSmall) {
+		errorString = "Timeout too small"
+	} else if errors.Is(err, armaria.ErrHostIntervalTooSmall) {
+		errorString = "Host interval too small"
+	} else if errors.Is(err, armaria.ErrInvalidTagMode) {
+		errorString = "Invalid tag mode"
 	} else if errors.Is(err, armaria.ErrQueryTooShort) {
 		errorString = "Query too short"
+	} else if errors.Is(err, armaria.ErrInvalidQuery) {
+		errorString = "Invalid query"
+	} else if errors.Is(err, armaria.ErrInvalidBookmarkFile) {
+		errorString = "Invalid bookmark file"
+	} else if errors.Is(err, armaria.ErrInvalidBackup) {
+		errorString = "Invalid backup"
+	} else if errors.Is(err, armaria.ErrNothingToUndo) {
+		errorString = "Nothing to undo"
+	} else if errors.Is(err, armaria.ErrNothingToRedo) {
+		errorString = "Nothing to redo"
+	} else if errors.Is(err, armaria.ErrNotInTrash) {
+		errorString = "Not in trash"
+	} else if errors.Is(err, armaria.ErrOlderThanTooSmall) {
+		errorString = "Older than too small"
+	} else if errors.Is(err, armaria.ErrTagExists) {
+		errorString = "Tag already exists"
+	} else if errors.Is(err, armaria.ErrNothingToMerge) {
+		errorString = "Nothing to merge"
+	} else if errors.Is(err, armaria.ErrNoSelection) {
+		errorString = "At least one filter or ID is required"
+	} else if errors.Is(err, armaria.ErrMoveIntoSelf) {
+		errorString = "Can't move a folder into itself"
+	} else if errors.Is(err, armaria.ErrFolderHasChildren) {
+		errorString = "Can't turn a folder with children into a bookmark"
+	} else if errors.Is(err, armaria.ErrUnsupportedBackupVersion) {
+		errorString = "Unsupported backup version"
+	} else if errors.Is(err, armaria.ErrInvalidRestoreMode) {
+		errorString = "Invalid restore mode"
+	} else if errors.Is(err, armaria.ErrProfileNotFound) {
+		errorString = "Browser profile not found"
+	} else if errors.Is(err, armaria.ErrProfileLocked) {
+		errorString = "Browser profile is locked; close the browser and try again"
 	} else {
 		errorString = err.Error()
 	}
@@ -145,6 +214,12 @@ func formatBookResults(writer io.Writer, formatter Formatter, books []armaria.Bo
 				IsFolder:    x.IsFolder,
 				ParentName:  null.NullStringFromPtr(x.ParentName),
 				Tags:        x.Tags,
+				Created:     x.Created,
+				Modified:    x.Modified,
+				DeletedAt:   null.NullStringFromPtr(x.DeletedAt),
+				Snippet:     null.NullStringFromPtr(x.Snippet),
+				Depth:       null.NullInt64FromPtr(x.Depth),
+				Path:        x.Path,
 			}
 		})
 
@@ -178,6 +253,75 @@ func formatBookResults(writer io.Writer, formatter Formatter, books []armaria.Bo
 				{"Description", formatNullableString(book.Description)},
 				{"Folder", formatNullableString(book.ParentName)},
 				{"Tags", formatTags(book.Tags)},
+				{"Created", book.Created},
+				{"Modified", book.Modified},
+			}
+			if book.DeletedAt != nil {
+				rows = append(rows, []string{"Deleted", *book.DeletedAt})
+			}
+			if book.Path != nil {
+				rows = append(rows, []string{"Path", strings.Join(book.Path, " / ")})
+			}
+			if book.Snippet != nil {
+				rows = append(rows, []string{"Match", formatSnippet(*book.Snippet)})
+			}
+
+			table := table.New().
+				Border(lipgloss.RoundedBorder()).
+				BorderRow(true).
+				BorderColumn(true).
+				Width(width).
+				StyleFunc(func(row, col int) lipgloss.Style {
+					switch {
+					case col == 0:
+						return headerStyle
+					default:
+						return rowStyle
+					}
+				}).
+				Rows(rows...)
+
+			fmt.Fprintln(writer, table)
+		}
+	}
+}
+
+// formatDuplicateResults formats groups of duplicate bookmarks.
+func formatDuplicateResults(writer io.Writer, formatter Formatter, duplicates []armaria.Duplicates) {
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindDuplicates, messaging.DuplicatesPayload{
+			Duplicates: messaging.DuplicatesMapper(duplicates),
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		width, _ := consolesize.GetConsoleSize()
+
+		headerStyle := lipgloss.
+			NewStyle().
+			Bold(true).
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(16)
+
+		rowStyle := lipgloss.
+			NewStyle().
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(width - 16)
+
+		for _, group := range duplicates {
+			rows := [][]string{
+				{"URL", group.URL},
+			}
+			for _, book := range group.Books {
+				rows = append(rows, []string{
+					formatIsFolder(false),
+					fmt.Sprintf("%s\n%s\n%s", book.ID, book.Name, formatLocation(book.Path)),
+				})
 			}
 
 			table := table.New().
@@ -200,6 +344,383 @@ func formatBookResults(writer io.Writer, formatter Formatter, books []armaria.Bo
 	}
 }
 
+// formatLinkCheckResults formats the outcomes of checking bookmark URLs.
+func formatLinkCheckResults(writer io.Writer, formatter Formatter, checks []armaria.LinkCheck) {
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindLinkChecks, messaging.LinkChecksPayload{
+			LinkChecks: messaging.LinkCheckMapper(checks),
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		if len(checks) == 0 {
+			return
+		}
+
+		width, _ := consolesize.GetConsoleSize()
+
+		headerStyle := lipgloss.
+			NewStyle().
+			Bold(true).
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(16)
+
+		rowStyle := lipgloss.
+			NewStyle().
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(width - 16)
+
+		rows := make([][]string, 0)
+		for _, check := range checks {
+			details := []string{
+				check.Book.ID,
+				check.Book.Name,
+				formatNullableString(check.Book.URL),
+				formatLocation(check.Book.Path),
+			}
+			if check.Redirect != nil {
+				details = append(details, fmt.Sprintf("Redirected to %s", *check.Redirect))
+			}
+			if check.Error != nil {
+				details = append(details, *check.Error)
+			}
+			details = append(details, fmt.Sprintf("Checked %s", check.Checked))
+
+			rows = append(rows, []string{
+				formatLinkStatus(check),
+				strings.Join(details, "\n"),
+			})
+		}
+
+		table := table.New().
+			Border(lipgloss.RoundedBorder()).
+			BorderRow(true).
+			BorderColumn(true).
+			Width(width).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				switch {
+				case col == 0:
+					return headerStyle
+				default:
+					return rowStyle
+				}
+			}).
+			Rows(rows...)
+
+		fmt.Fprintln(writer, table)
+	}
+}
+
+// formatArchiveResults formats offline copies of bookmarked pages.
+func formatArchiveResults(writer io.Writer, formatter Formatter, archives []armaria.Archive) {
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindArchives, messaging.ArchivesPayload{
+			Archives: messaging.ArchiveMapper(archives),
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		if len(archives) == 0 {
+			return
+		}
+
+		width, _ := consolesize.GetConsoleSize()
+
+		headerStyle := lipgloss.
+			NewStyle().
+			Bold(true).
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(16)
+
+		rowStyle := lipgloss.
+			NewStyle().
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(width - 16)
+
+		rows := make([][]string, 0)
+		for _, archive := range archives {
+			inlined := ""
+			if archive.Inlined {
+				inlined = ", inlined"
+			}
+
+			rows = append(rows, []string{
+				formatIsFolder(false),
+				fmt.Sprintf("%s\n%s\n%s (%s%s)\nArchived %s", archive.BookID, archive.URL, archive.ContentType, formatSize(archive.Size), inlined, archive.Archived),
+			})
+		}
+
+		table := table.New().
+			Border(lipgloss.RoundedBorder()).
+			BorderRow(true).
+			BorderColumn(true).
+			Width(width).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				switch {
+				case col == 0:
+					return headerStyle
+				default:
+					return rowStyle
+				}
+			}).
+			Rows(rows...)
+
+		fmt.Fprintln(writer, table)
+	}
+}
+
+// formatFaviconResults formats the icons of bookmarks' sites.
+func formatFaviconResults(writer io.Writer, formatter Formatter, favicons []armaria.Favicon) {
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindFavicons, messaging.FaviconsPayload{
+			Favicons: messaging.FaviconMapper(favicons),
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		if len(favicons) == 0 {
+			return
+		}
+
+		width, _ := consolesize.GetConsoleSize()
+
+		headerStyle := lipgloss.
+			NewStyle().
+			Bold(true).
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(16)
+
+		rowStyle := lipgloss.
+			NewStyle().
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(width - 16)
+
+		rows := make([][]string, 0)
+		for _, favicon := range favicons {
+			rows = append(rows, []string{
+				formatIsFolder(false),
+				fmt.Sprintf("%s\n%s\n%s (%s)\nFetched %s", favicon.BookID, favicon.URL, favicon.ContentType, formatSize(favicon.Size), favicon.Fetched),
+			})
+		}
+
+		table := table.New().
+			Border(lipgloss.RoundedBorder()).
+			BorderRow(true).
+			BorderColumn(true).
+			Width(width).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				switch {
+				case col == 0:
+					return headerStyle
+				default:
+					return rowStyle
+				}
+			}).
+			Rows(rows...)
+
+		fmt.Fprintln(writer, table)
+	}
+}
+
+// formatJournalEntryResult formats a change that was undone or redone.
+func formatJournalEntryResult(writer io.Writer, formatter Formatter, entry armaria.JournalEntry) {
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindJournalEntry, messaging.JournalEntryPayload{
+			Entry: messaging.JournalEntryMapper([]armaria.JournalEntry{entry})[0],
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		formatJournalEntryResults(writer, formatter, []armaria.JournalEntry{entry})
+	}
+}
+
+// formatJournalEntryResults formats recent changes to the bookmarks database.
+func formatJournalEntryResults(writer io.Writer, formatter Formatter, entries []armaria.JournalEntry) {
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindJournalEntries, messaging.JournalEntriesPayload{
+			Entries: messaging.JournalEntryMapper(entries),
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		if len(entries) == 0 {
+			return
+		}
+
+		width, _ := consolesize.GetConsoleSize()
+
+		headerStyle := lipgloss.
+			NewStyle().
+			Bold(true).
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(16)
+
+		rowStyle := lipgloss.
+			NewStyle().
+			PaddingLeft(1).
+			PaddingRight(1).
+			Width(width - 16)
+
+		rows := make([][]string, 0)
+		for _, entry := range entries {
+			status := "Done"
+			if entry.Undone {
+				status = "Undone"
+			}
+
+			rows = append(rows, []string{
+				status,
+				fmt.Sprintf("%s\n%s\n%s", entry.Description, entry.Operation, entry.Created),
+			})
+		}
+
+		table := table.New().
+			Border(lipgloss.RoundedBorder()).
+			BorderRow(true).
+			BorderColumn(true).
+			Width(width).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				switch {
+				case col == 0:
+					return headerStyle
+				default:
+					return rowStyle
+				}
+			}).
+			Rows(rows...)
+
+		fmt.Fprintln(writer, table)
+	}
+}
+
+// formatSize formats a size in bytes so it's easy to read.
+func formatSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+// formatLinkStatus formats whether a bookmark's URL still resolves.
+func formatLinkStatus(check armaria.LinkCheck) string {
+	status := "Failed"
+	if check.Status != nil {
+		status = fmt.Sprintf("%d", *check.Status)
+	}
+
+	if check.Broken() {
+		return fmt.Sprintf("✘ %s", status)
+	}
+
+	return fmt.Sprintf("✔ %s", status)
+}
+
+// formatLocation formats the folders a bookmark is in.
+// The path includes the bookmark itself so the last name is dropped.
+func formatLocation(path []string) string {
+	if len(path) < 2 {
+		return "Top level"
+	}
+
+	return strings.Join(path[:len(path)-1], " / ")
+}
+
+// formatTreeResults formats bookmarks/folders as a hierarchy.
+// If a root folder is provided everything is nested under it.
+func formatTreeResults(writer io.Writer, formatter Formatter, root *armaria.Book, books []armaria.Book, showURLs bool, showTags bool) {
+	tree := messaging.TreeMapper(books)
+	if root != nil {
+		tree = []messaging.TreeDTO{
+			{
+				BookDTO:  messaging.TreeMapper([]armaria.Book{*root})[0].BookDTO,
+				Children: tree,
+			},
+		}
+	}
+
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindTree, messaging.TreePayload{
+			Tree: tree,
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		if root != nil {
+			fmt.Fprintln(writer, formatTreeLine(tree[0], showURLs, showTags))
+			formatTreeLevel(writer, tree[0].Children, "", showURLs, showTags)
+		} else {
+			formatTreeLevel(writer, tree, "", showURLs, showTags)
+		}
+	}
+}
+
+// formatTreeLevel formats a level of a hierarchy (and all of its children).
+// The prefix has the connectors for the levels above.
+func formatTreeLevel(writer io.Writer, nodes []messaging.TreeDTO, prefix string, showURLs bool, showTags bool) {
+	for i, node := range nodes {
+		connector := "├── "
+		childPrefix := prefix + "│   "
+		if i == len(nodes)-1 {
+			connector = "└── "
+			childPrefix = prefix + "    "
+		}
+
+		fmt.Fprintln(writer, prefix+connector+formatTreeLine(node, showURLs, showTags))
+		formatTreeLevel(writer, node.Children, childPrefix, showURLs, showTags)
+	}
+}
+
+// formatTreeLine formats a single bookmark/folder in a hierarchy.
+func formatTreeLine(node messaging.TreeDTO, showURLs bool, showTags bool) string {
+	urlStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	tagStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+
+	line := fmt.Sprintf("%s %s", formatIsFolder(node.IsFolder), node.Name)
+	if node.IsFolder {
+		line = fmt.Sprintf("%s %s", formatIsFolder(node.IsFolder), lipgloss.NewStyle().Bold(true).Render(node.Name))
+	}
+
+	if showURLs && node.URL.Valid {
+		line += " " + urlStyle.Render(node.URL.String)
+	}
+
+	if showTags && len(node.Tags) > 0 {
+		line += " " + tagStyle.Render(fmt.Sprintf("[%s]", formatTags(node.Tags)))
+	}
+
+	return line
+}
+
 // formatTagResults formats a collection of tags.
 func formatTagResults(writer io.Writer, formatter Formatter, tags []string) {
 	switch formatter {
@@ -228,6 +749,95 @@ func formatTagResults(writer io.Writer, formatter Formatter, tags []string) {
 	}
 }
 
+// formatTagCountResult formats a tag and how many bookmarks it's applied to.
+func formatTagCountResult(writer io.Writer, formatter Formatter, count armaria.TagCount) {
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindTagCount, messaging.TagCountPayload{
+			Tag: messaging.TagCountMapper([]armaria.TagCount{count})[0],
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		formatTagCounts(writer, []armaria.TagCount{count})
+	}
+}
+
+// formatTagCountResults formats tags and how many bookmarks they are applied to.
+func formatTagCountResults(writer io.Writer, formatter Formatter, counts []armaria.TagCount) {
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindTagCounts, messaging.TagCountsPayload{
+			Tags: messaging.TagCountMapper(counts),
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		formatTagCounts(writer, counts)
+	}
+}
+
+// formatTagCounts pretty prints tags and how many bookmarks they are applied to.
+func formatTagCounts(writer io.Writer, counts []armaria.TagCount) {
+	width, _ := consolesize.GetConsoleSize()
+
+	style := lipgloss.
+		NewStyle().
+		Bold(true).
+		PaddingLeft(1).
+		PaddingRight(1).
+		BorderStyle(lipgloss.RoundedBorder()).
+		MaxWidth(width - 2)
+
+	for _, count := range counts {
+		fmt.Fprintln(writer, style.Render(fmt.Sprintf("🏷  %s (%d)", count.Tag, count.Count)))
+	}
+}
+
+// formatTagTreeResults formats tags as a hierarchy of namespaces.
+func formatTagTreeResults(writer io.Writer, formatter Formatter, tree []armaria.TagNode) {
+	nodes := messaging.TagTreeMapper(tree)
+
+	switch formatter {
+
+	case FormatterJSON:
+		json := marshalMsg(messaging.MessageKindTagTree, messaging.TagTreePayload{
+			Tree: nodes,
+		})
+
+		fmt.Fprintln(writer, json)
+
+	case FormatterPretty:
+		formatTagTreeLevel(writer, nodes, "")
+	}
+}
+
+// formatTagTreeLevel formats a level of the tag hierarchy (and all of its children).
+// The prefix has the connectors for the levels above.
+// Namespaces that aren't applied to any bookmarks themselves are shown without a count.
+func formatTagTreeLevel(writer io.Writer, nodes []messaging.TagTreeDTO, prefix string) {
+	for i, node := range nodes {
+		connector := "├── "
+		childPrefix := prefix + "│   "
+		if i == len(nodes)-1 {
+			connector = "└── "
+			childPrefix = prefix + "    "
+		}
+
+		line := fmt.Sprintf("🏷  %s", node.Name)
+		if node.Count > 0 {
+			line = fmt.Sprintf("🏷  %s (%d)", node.Name, node.Count)
+		}
+
+		fmt.Fprintln(writer, prefix+connector+line)
+		formatTagTreeLevel(writer, node.Children, childPrefix)
+	}
+}
+
 // formatConfigResult formats a config value.
 func formatConfigResult(writer io.Writer, formatter Formatter, value string) {
 	switch formatter {
@@ -302,6 +912,32 @@ func formatNullableString(str *string) string {
 	return "NULL"
 }
 
+// formatSnippet formats a search snippet so the matched text is bold.
+func formatSnippet(snippet string) string {
+	bold := lipgloss.NewStyle().Bold(true)
+
+	var formatted strings.Builder
+	for {
+		start := strings.Index(snippet, armaria.HighlightStart)
+		if start == -1 {
+			break
+		}
+
+		end := strings.Index(snippet[start:], armaria.HighlightEnd)
+		if end == -1 {
+			break
+		}
+		end += start
+
+		formatted.WriteString(snippet[:start])
+		formatted.WriteString(bold.Render(snippet[start+len(armaria.HighlightStart) : end]))
+		snippet = snippet[end+len(armaria.HighlightEnd):]
+	}
+	formatted.WriteString(snippet)
+
+	return formatted.String()
+}
+
 // formatTags formats a tags value.
 func formatTags(tags []string) string {
 	return strings.Join(tags, ", ")
@@ -0,0 +1,31 @@
+package messaging
+
+import (
+	"github.com/jonathanhope/armaria/pkg"
+	"github.com/samber/lo"
+)
+
+// ArchiveDTO is an offline copy of a bookmark's page that can be marshalled into JSON.
+// The content of the page isn't included.
+type ArchiveDTO struct {
+	BookID      string `json:"bookId"`
+	URL         string `json:"url"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	Inlined     bool   `json:"inlined"`
+	Archived    string `json:"archived"`
+}
+
+// ArchiveMapper maps archives to ArchiveDTOs.
+func ArchiveMapper(archives []armaria.Archive) []ArchiveDTO {
+	return lo.Map(archives, func(archive armaria.Archive, _ int) ArchiveDTO {
+		return ArchiveDTO{
+			BookID:      archive.BookID,
+			URL:         archive.URL,
+			ContentType: archive.ContentType,
+			Size:        archive.Size,
+			Inlined:     archive.Inlined,
+			Archived:    archive.Archived,
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package messaging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// Operations in a batch are regular messages.
+// Before an operation is run any references to earlier results in its payload are replaced.
+// A reference is a string of the form "$N.id" where N is the index of an earlier operation.
+// Every operation runs in the batch's transaction so it can't use a different DB than the batch.
+
+// errBatchDatabase is returned when an operation in a batch uses a different DB than the batch.
+var errBatchDatabase = errors.New("operation uses a different database than the batch")
+
+// batchReference matches a reference to the ID returned by an earlier operation.
+var batchReference = regexp.MustCompile(`^\$(\d+)\.id$`)
+
+// batchOperationHandler gets the handler for a kind of message that can be in a batch.
+func batchOperationHandler(kind MessageKind) (batchHandleFn, bool) {
+	switch kind {
+	case MessageKindAddBook:
+		return addBookHandler, true
+	case MessageKindAddFolder:
+		return addFolderHandler, true
+	case MessageKindAddTags:
+		return addTagsHandler, true
+	case MessageKindAddSession:
+		return addSessionHandler, true
+	case MessageKindGetBook:
+		return getBookHandler, true
+	case MessageKindGetParentNames:
+		return getParentNamesHandler, true
+	case MessageKindListBooks:
+		return listBooksHandler, true
+	case MessageKindListTags:
+		return listTagsHandler, true
+	case MessageKindRemoveBook:
+		return removeBookHandler, true
+	case MessageKindRemoveFolder:
+		return removeFolderHandler, true
+	case MessageKindRemoveTags:
+		return removeTagsHandler, true
+	case MessageKindRenameTag:
+		return renameTagHandler, true
+	case MessageKindMergeTags:
+		return mergeTagsHandler, true
+	case MessageKindDeleteTag:
+		return deleteTagHandler, true
+	case MessageKindUpdateBook:
+		return updateBookHandler, true
+	case MessageKindUpdateFolder:
+		return updateFolderHandler, true
+	default:
+		return nil, false
+	}
+}
+
+// resolveBatchOperation replaces the references in an operation with IDs returned by earlier operations.
+func resolveBatchOperation(operation NativeMessage, db null.NullString, ids []string) (NativeMessage, error) {
+	payload := make(map[string]interface{})
+	if operation.Payload != "" {
+		decoder := json.NewDecoder(bytes.NewBufferString(operation.Payload))
+		decoder.UseNumber()
+		if err := decoder.Decode(&payload); err != nil {
+			return NativeMessage{}, err
+		}
+	}
+
+	if payload["db"] != nil && payload["db"] != db.String {
+		return NativeMessage{}, errBatchDatabase
+	}
+
+	resolved, err := resolveBatchReferences(payload, ids)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	json, err := json.Marshal(resolved)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	operation.Payload = string(json)
+	return operation, nil
+}
+
+// resolveBatchReferences replaces the references in a JSON value (and all of its children).
+func resolveBatchReferences(value interface{}, ids []string) (interface{}, error) {
+	switch value := value.(type) {
+
+	case string:
+		match := batchReference.FindStringSubmatch(value)
+		if match == nil {
+			return value, nil
+		}
+
+		index, err := strconv.Atoi(match[1])
+		if err != nil || index >= len(ids) || ids[index] == "" {
+			return nil, fmt.Errorf("invalid reference %q", value)
+		}
+
+		return ids[index], nil
+
+	case []interface{}:
+		for i, child := range value {
+			resolved, err := resolveBatchReferences(child, ids)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = resolved
+		}
+
+		return value, nil
+
+	case map[string]interface{}:
+		for key, child := range value {
+			resolved, err := resolveBatchReferences(child, ids)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = resolved
+		}
+
+		return value, nil
+
+	default:
+		return value, nil
+	}
+}
+
+// batchResultID gets the ID of the bookmark/folder returned by an operation.
+// Operations that don't return a bookmark/folder have no ID.
+func batchResultID(out NativeMessage) string {
+	if out.Kind != MessageKindBook {
+		return ""
+	}
+
+	payload, err := GetPayload[BookPayload](out)
+	if err != nil {
+		return ""
+	}
+
+	return payload.Book.ID
+}
@@ -0,0 +1,74 @@
+package messaging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+func TestResolveBatchOperation(t *testing.T) {
+	type test struct {
+		payload string
+		db      null.NullString
+		want    string
+	}
+
+	tests := []test{
+		{payload: `{"id":"$0.id"}`, want: `{"id":"a"}`},
+		{payload: `{"id":"$1.id","tags":["$0.id"]}`, want: `{"id":"b","tags":["a"]}`},
+		{payload: `{"name":"$0"}`, want: `{"name":"$0"}`},
+		{payload: `{"first":10}`, want: `{"first":10}`},
+		{payload: `{"id":"a"}`, db: null.NullStringFrom("bookmarks.db"), want: `{"id":"a"}`},
+		{payload: `{"db":"bookmarks.db"}`, db: null.NullStringFrom("bookmarks.db"), want: `{"db":"bookmarks.db"}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.payload, func(t *testing.T) {
+			got, err := resolveBatchOperation(NativeMessage{Payload: tc.payload}, tc.db, []string{"a", "b", ""})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			diff := cmp.Diff(got.Payload, tc.want)
+			if diff != "" {
+				t.Errorf("Expected and actual payload different:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveBatchOperationInvalidReference(t *testing.T) {
+	tests := []string{`{"id":"$2.id"}`, `{"id":"$3.id"}`}
+
+	for _, tc := range tests {
+		t.Run(tc, func(t *testing.T) {
+			_, err := resolveBatchOperation(NativeMessage{Payload: tc}, null.NullString{}, []string{"a", "b", ""})
+			if err == nil {
+				t.Errorf("expected error for %s", tc)
+			}
+		})
+	}
+}
+
+func TestResolveBatchOperationDifferentDatabase(t *testing.T) {
+	type test struct {
+		payload string
+		db      null.NullString
+	}
+
+	tests := []test{
+		{payload: `{"db":"other.db"}`, db: null.NullStringFrom("bookmarks.db")},
+		{payload: `{"db":"other.db"}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.payload, func(t *testing.T) {
+			_, err := resolveBatchOperation(NativeMessage{Payload: tc.payload}, tc.db, []string{})
+			if !errors.Is(err, errBatchDatabase) {
+				t.Errorf("got %+v; want %+v", err, errBatchDatabase)
+			}
+		})
+	}
+}
@@ -15,6 +15,12 @@ type BookDTO struct {
 	IsFolder    bool            `json:"isFolder"`
 	ParentName  null.NullString `json:"parentName"`
 	Tags        []string        `json:"tags"`
+	Created     string          `json:"created"`
+	Modified    string          `json:"modified"`
+	DeletedAt   null.NullString `json:"deletedAt"`
+	Snippet     null.NullString `json:"snippet"`
+	Depth       null.NullInt64  `json:"depth"`
+	Path        []string        `json:"path"`
 }
 
 // bookMapper maps a Book to a BookDTO.
@@ -28,5 +34,11 @@ func bookMapper(book armaria.Book) BookDTO {
 		IsFolder:    book.IsFolder,
 		ParentName:  null.NullStringFromPtr(book.ParentName),
 		Tags:        book.Tags,
+		Created:     book.Created,
+		Modified:    book.Modified,
+		DeletedAt:   null.NullStringFromPtr(book.DeletedAt),
+		Snippet:     null.NullStringFromPtr(book.Snippet),
+		Depth:       null.NullInt64FromPtr(book.Depth),
+		Path:        book.Path,
 	}
 }
@@ -1,74 +1,186 @@
 package messaging
 
 import (
+	"errors"
 	"fmt"
 	"io"
+
+	"github.com/jonathanhope/armaria/pkg"
 )
 
 // The top level handler.
 // Invokes the right handler for a given message kind.
 
-// Dispatch branches the handler invocation based on message kind.
+// errUnknownKind is returned when a message of an unknown kind is received.
+var errUnknownKind = errors.New("Unknown message kind")
+
+// Dispatch receives a single message and responds to it.
 func Dispatch(reader io.Reader, writer io.Writer) error {
 	in, err := ReceiveMessage(reader)
 	if err != nil {
 		return err
 	}
 
+	return dispatch(writer, in)
+}
+
+// Serve receives messages and responds to them until the reader is closed.
+// This is used when a browser keeps a port to the host open (runtime.connectNative).
+// The connection to the bookmarks database is kept open for the whole session.
+func Serve(reader io.Reader, writer io.Writer) error {
+	armaria.StartSession()
+
+	err := serve(reader, writer)
+	if endErr := armaria.EndSession(); err == nil {
+		err = endErr
+	}
+
+	return err
+}
+
+// serve is the message loop for Serve.
+func serve(reader io.Reader, writer io.Writer) error {
+	for {
+		in, err := ReceiveMessage(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// An unknown message kind shouldn't close the port.
+		err = dispatch(writer, in)
+		if errors.Is(err, errUnknownKind) {
+			err = sendError(writer, in, err)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// dispatch branches the handler invocation based on message kind.
+func dispatch(writer io.Writer, in NativeMessage) error {
 	switch in.Kind {
 
 	case MessageKindAddBook:
-		if err := handleKind(writer, in, addBookHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(addBookHandler)); err != nil {
 			return err
 		}
 
 	case MessageKindAddFolder:
-		if err := handleKind(writer, in, addFolderHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(addFolderHandler)); err != nil {
 			return err
 		}
 
 	case MessageKindAddTags:
-		if err := handleKind(writer, in, addTagsHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(addTagsHandler)); err != nil {
+			return err
+		}
+
+	case MessageKindAddSession:
+		if err := handleKind(writer, in, withoutBatch(addSessionHandler)); err != nil {
+			return err
+		}
+
+	case MessageKindGetBook:
+		if err := handleKind(writer, in, withoutBatch(getBookHandler)); err != nil {
+			return err
+		}
+
+	case MessageKindGetParentNames:
+		if err := handleKind(writer, in, withoutBatch(getParentNamesHandler)); err != nil {
+			return err
+		}
+
+	case MessageKindGetFavicons:
+		if err := handleKind(writer, in, getFaviconsHandler); err != nil {
+			return err
+		}
+
+	case MessageKindGetDBConfig:
+		if err := handleKind(writer, in, getDBConfigHandler); err != nil {
+			return err
+		}
+
+	case MessageKindSetDBConfig:
+		if err := handleKind(writer, in, setDBConfigHandler); err != nil {
 			return err
 		}
 
 	case MessageKindListBooks:
-		if err := handleKind(writer, in, listBooksHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(listBooksHandler)); err != nil {
 			return err
 		}
 
 	case MessageKindListTags:
-		if err := handleKind(writer, in, listTagsHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(listTagsHandler)); err != nil {
 			return err
 		}
 
 	case MessageKindRemoveBook:
-		if err := handleKind(writer, in, removeBookHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(removeBookHandler)); err != nil {
 			return err
 		}
 
 	case MessageKindRemoveFolder:
-		if err := handleKind(writer, in, removeFolderHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(removeFolderHandler)); err != nil {
 			return err
 		}
 
 	case MessageKindRemoveTags:
-		if err := handleKind(writer, in, removeTagsHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(removeTagsHandler)); err != nil {
+			return err
+		}
+
+	case MessageKindRenameTag:
+		if err := handleKind(writer, in, withoutBatch(renameTagHandler)); err != nil {
+			return err
+		}
+
+	case MessageKindMergeTags:
+		if err := handleKind(writer, in, withoutBatch(mergeTagsHandler)); err != nil {
+			return err
+		}
+
+	case MessageKindDeleteTag:
+		if err := handleKind(writer, in, withoutBatch(deleteTagHandler)); err != nil {
 			return err
 		}
 
 	case MessageKindUpdateBook:
-		if err := handleKind(writer, in, updateBookHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(updateBookHandler)); err != nil {
 			return err
 		}
 
 	case MessageKindUpdateFolder:
-		if err := handleKind(writer, in, updateFolderHandler); err != nil {
+		if err := handleKind(writer, in, withoutBatch(updateFolderHandler)); err != nil {
+			return err
+		}
+
+	case MessageKindUndo:
+		if err := handleKind(writer, in, undoHandler); err != nil {
+			return err
+		}
+
+	case MessageKindRedo:
+		if err := handleKind(writer, in, redoHandler); err != nil {
+			return err
+		}
+
+	case MessageKindListHistory:
+		if err := handleKind(writer, in, listHistoryHandler); err != nil {
+			return err
+		}
+
+	case MessageKindBatch:
+		if err := handleKind(writer, in, batchHandler); err != nil {
 			return err
 		}
 
 	default:
-		return fmt.Errorf("Unknown message kind: %s", in.Kind)
+		return fmt.Errorf("%w: %s", errUnknownKind, in.Kind)
 	}
 
 	return nil
@@ -78,14 +190,10 @@ func Dispatch(reader io.Reader, writer io.Writer) error {
 func handleKind(writer io.Writer, in NativeMessage, handler handleFn) error {
 	out, err := handler(in)
 	if err != nil {
-		out, err = PayloadToMessage(MessageKindError, ErrorPayload{
-			Error: fmt.Sprintf("%s", err),
-		})
-		if err != nil {
-			return err
-		}
+		return sendError(writer, in, err)
 	}
 
+	out.RequestID = in.RequestID
 	err = out.SendMessage(writer)
 	if err != nil {
 		return err
@@ -93,3 +201,16 @@ func handleKind(writer io.Writer, in NativeMessage, handler handleFn) error {
 
 	return nil
 }
+
+// sendError responds to a message with an error.
+func sendError(writer io.Writer, in NativeMessage, handlerErr error) error {
+	out, err := PayloadToMessage(MessageKindError, ErrorPayload{
+		Error: fmt.Sprintf("%s", handlerErr),
+	})
+	if err != nil {
+		return err
+	}
+
+	out.RequestID = in.RequestID
+	return out.SendMessage(writer)
+}
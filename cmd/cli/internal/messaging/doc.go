@@ -6,4 +6,6 @@
 // This is also the format the JSON formatter uses.
 // The messages require calls to unmarshal the JSON.
 // First to get the kind of the message; second to unmarshal the payload once the type is known.
+// The host keeps answering messages until stdin is closed, so a port can be kept open.
+// Messages can have a request ID which is echoed back on the response to correlate them.
 package messaging
@@ -0,0 +1,24 @@
+package messaging
+
+import (
+	"github.com/jonathanhope/armaria/pkg"
+	"github.com/samber/lo"
+)
+
+// DuplicatesDTO is a group of bookmarks with the same URL that can be marshalled into JSON.
+type DuplicatesDTO struct {
+	URL   string    `json:"url"`
+	Books []BookDTO `json:"books"`
+}
+
+// DuplicatesMapper maps groups of duplicate bookmarks to DuplicatesDTOs.
+func DuplicatesMapper(duplicates []armaria.Duplicates) []DuplicatesDTO {
+	return lo.Map(duplicates, func(group armaria.Duplicates, _ int) DuplicatesDTO {
+		return DuplicatesDTO{
+			URL: group.URL,
+			Books: lo.Map(group.Books, func(book armaria.Book, _ int) BookDTO {
+				return bookMapper(book)
+			}),
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package messaging
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/pkg"
+	"github.com/samber/lo"
+)
+
+// FaviconDTO is the icon of a bookmark's site that can be marshalled into JSON.
+// The icon itself is a data URL so it can be used as the src of an image as is.
+type FaviconDTO struct {
+	BookID      string `json:"bookId"`
+	Hash        string `json:"hash"`
+	URL         string `json:"url"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	Fetched     string `json:"fetched"`
+	Data        string `json:"data,omitempty"`
+}
+
+// FaviconMapper maps favicons to FaviconDTOs.
+// Data is only set for favicons that have their content.
+func FaviconMapper(favicons []armaria.Favicon) []FaviconDTO {
+	return lo.Map(favicons, func(favicon armaria.Favicon, _ int) FaviconDTO {
+		data := ""
+		if favicon.Content != nil {
+			data = fmt.Sprintf("data:%s;base64,%s", favicon.ContentType, base64.StdEncoding.EncodeToString(favicon.Content))
+		}
+
+		return FaviconDTO{
+			BookID:      favicon.BookID,
+			Hash:        favicon.Hash,
+			URL:         favicon.URL,
+			ContentType: favicon.ContentType,
+			Size:        favicon.Size,
+			Fetched:     favicon.Fetched,
+			Data:        data,
+		}
+	})
+}
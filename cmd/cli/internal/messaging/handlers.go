@@ -1,6 +1,9 @@
 package messaging
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/jonathanhope/armaria/pkg"
 	"github.com/samber/lo"
 )
@@ -8,8 +11,19 @@ import (
 // handleFn is a function that can handle a particular kind of message.
 type handleFn func(in NativeMessage) (NativeMessage, error)
 
+// batchHandleFn is a function that can handle a kind of message that can be part of a batch.
+// The batch is nil if the message isn't part of one.
+type batchHandleFn func(batch *armaria.Batch, in NativeMessage) (NativeMessage, error)
+
+// withoutBatch handles a kind of message that can be part of a batch on its own.
+func withoutBatch(handler batchHandleFn) handleFn {
+	return func(in NativeMessage) (NativeMessage, error) {
+		return handler(nil, in)
+	}
+}
+
 // addBookHandler handles an add-book message.
-func addBookHandler(in NativeMessage) (NativeMessage, error) {
+func addBookHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[AddBookPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -31,8 +45,18 @@ func addBookHandler(in NativeMessage) (NativeMessage, error) {
 	if payload.Tags != nil {
 		options.WithTags(payload.Tags)
 	}
+	if payload.RejectDuplicates {
+		options.WithRejectDuplicates(true)
+	}
+	if payload.FetchMetadata {
+		options.WithFetchMetadata(true)
+	}
 
-	book, err := armaria.AddBook(payload.URL, options)
+	addBook := armaria.AddBook
+	if batch != nil {
+		addBook = batch.AddBook
+	}
+	book, err := addBook(payload.URL, options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -48,7 +72,7 @@ func addBookHandler(in NativeMessage) (NativeMessage, error) {
 }
 
 // addFolderHandler handles an add-folder message.
-func addFolderHandler(in NativeMessage) (NativeMessage, error) {
+func addFolderHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[AddFolderPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -62,7 +86,11 @@ func addFolderHandler(in NativeMessage) (NativeMessage, error) {
 		options.WithParentID(payload.ParentID.String)
 	}
 
-	book, err := armaria.AddFolder(payload.Name, options)
+	addFolder := armaria.AddFolder
+	if batch != nil {
+		addFolder = batch.AddFolder
+	}
+	book, err := addFolder(payload.Name, options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -78,7 +106,7 @@ func addFolderHandler(in NativeMessage) (NativeMessage, error) {
 }
 
 // addTagsHandler handles an add-tags message.
-func addTagsHandler(in NativeMessage) (NativeMessage, error) {
+func addTagsHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[AddTagsPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -88,7 +116,81 @@ func addTagsHandler(in NativeMessage) (NativeMessage, error) {
 	if payload.DB.Valid {
 		options.WithDB(payload.DB.String)
 	}
-	book, err := armaria.AddTags(payload.ID, payload.Tags, options)
+	addTags := armaria.AddTags
+	if batch != nil {
+		addTags = batch.AddTags
+	}
+	book, err := addTags(payload.ID, payload.Tags, options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindBook, BookPayload{
+		Book: bookMapper(book),
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// addSessionHandler handles an add-session message.
+func addSessionHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[AddSessionPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultAddSessionOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+	if payload.ParentID.Valid {
+		options.WithParentID(payload.ParentID.String)
+	}
+	if payload.Tags != nil {
+		options.WithTags(payload.Tags)
+	}
+
+	addSession := armaria.AddSession
+	if batch != nil {
+		addSession = batch.AddSession
+	}
+	books, err := addSession(payload.Name, payload.URLs, options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindBooks, BooksPayload{
+		Books: lo.Map(books, func(book armaria.Book, _ int) BookDTO {
+			return bookMapper(book)
+		}),
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// getBookHandler handles a get-book message.
+func getBookHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[GetBookPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultGetBookOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+
+	getBook := armaria.GetBook
+	if batch != nil {
+		getBook = batch.GetBook
+	}
+	book, err := getBook(payload.ID, options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -103,8 +205,105 @@ func addTagsHandler(in NativeMessage) (NativeMessage, error) {
 	return out, nil
 }
 
+// getParentNamesHandler handles a get-parent-names message.
+func getParentNamesHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[GetParentNamesPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultGetParentNameOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+
+	getParentNames := armaria.GetParentNames
+	if batch != nil {
+		getParentNames = batch.GetParentNames
+	}
+	names, err := getParentNames(payload.ID, options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindParentNames, ParentNamesPayload{
+		ParentNames: names,
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// getFaviconsHandler handles a get-favicons message.
+func getFaviconsHandler(in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[GetFaviconsPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultGetFaviconsOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+
+	favicons, err := armaria.GetFavicons(payload.IDs, options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindFavicons, FaviconsPayload{
+		Favicons: FaviconMapper(favicons),
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// getDBConfigHandler handles a get-db-config message.
+func getDBConfigHandler(in NativeMessage) (NativeMessage, error) {
+	config, err := armaria.GetConfig()
+	if err != nil && !errors.Is(err, armaria.ErrConfigMissing) {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindConfigValue, ConfigValuePayload{
+		Value: config.DB,
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// setDBConfigHandler handles a set-db-config message.
+func setDBConfigHandler(in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[SetDBConfigPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	err = armaria.UpdateConfig(func(config *armaria.Config) {
+		config.DB = payload.DB
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindVoid, VoidPayload{})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
 // listBooksHandler handles a list-books message.
-func listBooksHandler(in NativeMessage) (NativeMessage, error) {
+func listBooksHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[ListBooksPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -131,6 +330,18 @@ func listBooksHandler(in NativeMessage) (NativeMessage, error) {
 	if payload.Tags != nil {
 		options.WithTags(payload.Tags)
 	}
+	if payload.TagMode != "" {
+		options.WithTagMode(armaria.TagMode(payload.TagMode))
+	}
+	if payload.ExcludeTags != nil {
+		options.WithExcludeTags(payload.ExcludeTags)
+	}
+	if payload.Recursive {
+		options.WithRecursive(true)
+	}
+	if payload.Depth.Valid {
+		options.WithDepth(payload.Depth.Int64)
+	}
 	if payload.Order != "" {
 		options.WithOrder(armaria.Order(payload.Order))
 	}
@@ -141,7 +352,11 @@ func listBooksHandler(in NativeMessage) (NativeMessage, error) {
 		options.WithFirst(payload.First.Int64)
 	}
 
-	books, err := armaria.ListBooks(options)
+	listBooks := armaria.ListBooks
+	if batch != nil {
+		listBooks = batch.ListBooks
+	}
+	books, err := listBooks(options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -159,7 +374,7 @@ func listBooksHandler(in NativeMessage) (NativeMessage, error) {
 }
 
 // listTagsHandler handles a list-tags message.
-func listTagsHandler(in NativeMessage) (NativeMessage, error) {
+func listTagsHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[ListTagsPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -182,7 +397,41 @@ func listTagsHandler(in NativeMessage) (NativeMessage, error) {
 		options.WithFirst(payload.First.Int64)
 	}
 
-	tags, err := armaria.ListTags(options)
+	if payload.Tree {
+		listTagTree := armaria.ListTagTree
+		if batch != nil {
+			listTagTree = batch.ListTagTree
+		}
+		tree, err := listTagTree(options)
+		if err != nil {
+			return NativeMessage{}, err
+		}
+
+		return PayloadToMessage(MessageKindTagTree, TagTreePayload{
+			Tree: TagTreeMapper(tree),
+		})
+	}
+
+	if payload.Counts {
+		listTagCounts := armaria.ListTagCounts
+		if batch != nil {
+			listTagCounts = batch.ListTagCounts
+		}
+		counts, err := listTagCounts(options)
+		if err != nil {
+			return NativeMessage{}, err
+		}
+
+		return PayloadToMessage(MessageKindTagCounts, TagCountsPayload{
+			Tags: TagCountMapper(counts),
+		})
+	}
+
+	listTags := armaria.ListTags
+	if batch != nil {
+		listTags = batch.ListTags
+	}
+	tags, err := listTags(options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -197,8 +446,99 @@ func listTagsHandler(in NativeMessage) (NativeMessage, error) {
 	return out, nil
 }
 
+// renameTagHandler handles a rename-tag message.
+func renameTagHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[RenameTagPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultRenameTagOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+
+	renameTag := armaria.RenameTag
+	if batch != nil {
+		renameTag = batch.RenameTag
+	}
+	count, err := renameTag(payload.From, payload.To, options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindTagCount, TagCountPayload{
+		Tag: tagCountMapper(count),
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// mergeTagsHandler handles a merge-tags message.
+func mergeTagsHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[MergeTagsPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultMergeTagsOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+
+	mergeTags := armaria.MergeTags
+	if batch != nil {
+		mergeTags = batch.MergeTags
+	}
+	count, err := mergeTags(payload.Tags, payload.Into, options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindTagCount, TagCountPayload{
+		Tag: tagCountMapper(count),
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// deleteTagHandler handles a delete-tag message.
+func deleteTagHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[DeleteTagPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultDeleteTagOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+
+	deleteTag := armaria.DeleteTag
+	if batch != nil {
+		deleteTag = batch.DeleteTag
+	}
+	err = deleteTag(payload.Tag, options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindVoid, VoidPayload{})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
 // removeBookHandler handles a remove-book message.
-func removeBookHandler(in NativeMessage) (NativeMessage, error) {
+func removeBookHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[RemoveBookPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -209,7 +549,11 @@ func removeBookHandler(in NativeMessage) (NativeMessage, error) {
 		options.WithDB(payload.DB.String)
 	}
 
-	err = armaria.RemoveBook(payload.ID, options)
+	removeBook := armaria.RemoveBook
+	if batch != nil {
+		removeBook = batch.RemoveBook
+	}
+	err = removeBook(payload.ID, options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -223,7 +567,7 @@ func removeBookHandler(in NativeMessage) (NativeMessage, error) {
 }
 
 // removeFolderHandler handles a remove-book message.
-func removeFolderHandler(in NativeMessage) (NativeMessage, error) {
+func removeFolderHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[RemoveFolderPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -234,7 +578,11 @@ func removeFolderHandler(in NativeMessage) (NativeMessage, error) {
 		options.WithDB(payload.DB.String)
 	}
 
-	err = armaria.RemoveFolder(payload.ID, options)
+	removeFolder := armaria.RemoveFolder
+	if batch != nil {
+		removeFolder = batch.RemoveFolder
+	}
+	err = removeFolder(payload.ID, options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -248,7 +596,7 @@ func removeFolderHandler(in NativeMessage) (NativeMessage, error) {
 }
 
 // removeTagsHandler handles a remove-tags message.
-func removeTagsHandler(in NativeMessage) (NativeMessage, error) {
+func removeTagsHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[RemoveTagsPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -259,7 +607,11 @@ func removeTagsHandler(in NativeMessage) (NativeMessage, error) {
 		options.WithDB(payload.DB.String)
 	}
 
-	book, err := armaria.RemoveTags(payload.ID, payload.Tags, options)
+	removeTags := armaria.RemoveTags
+	if batch != nil {
+		removeTags = batch.RemoveTags
+	}
+	book, err := removeTags(payload.ID, payload.Tags, options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -275,7 +627,7 @@ func removeTagsHandler(in NativeMessage) (NativeMessage, error) {
 }
 
 // updateBookHandler handles an update-book message.
-func updateBookHandler(in NativeMessage) (NativeMessage, error) {
+func updateBookHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[UpdateBookPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -310,7 +662,11 @@ func updateBookHandler(in NativeMessage) (NativeMessage, error) {
 		options.WithOrderAfter(payload.PreviousBook.String)
 	}
 
-	book, err := armaria.UpdateBook(payload.ID, options)
+	updateBook := armaria.UpdateBook
+	if batch != nil {
+		updateBook = batch.UpdateBook
+	}
+	book, err := updateBook(payload.ID, options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -326,7 +682,7 @@ func updateBookHandler(in NativeMessage) (NativeMessage, error) {
 }
 
 // updateFolderHandler handles an update-folder message.
-func updateFolderHandler(in NativeMessage) (NativeMessage, error) {
+func updateFolderHandler(batch *armaria.Batch, in NativeMessage) (NativeMessage, error) {
 	payload, err := GetPayload[UpdateFolderPayload](in)
 	if err != nil {
 		return NativeMessage{}, err
@@ -352,7 +708,11 @@ func updateFolderHandler(in NativeMessage) (NativeMessage, error) {
 		options.WithOrderAfter(payload.PreviousBook.String)
 	}
 
-	book, err := armaria.UpdateFolder(payload.ID, options)
+	updateFolder := armaria.UpdateFolder
+	if batch != nil {
+		updateFolder = batch.UpdateFolder
+	}
+	book, err := updateFolder(payload.ID, options)
 	if err != nil {
 		return NativeMessage{}, err
 	}
@@ -366,3 +726,139 @@ func updateFolderHandler(in NativeMessage) (NativeMessage, error) {
 
 	return out, nil
 }
+
+// undoHandler handles an undo message.
+func undoHandler(in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[UndoPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultUndoOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+
+	entry, err := armaria.Undo(options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindJournalEntry, JournalEntryPayload{
+		Entry: journalEntryMapper(entry),
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// redoHandler handles a redo message.
+func redoHandler(in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[RedoPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultRedoOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+
+	entry, err := armaria.Redo(options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindJournalEntry, JournalEntryPayload{
+		Entry: journalEntryMapper(entry),
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// listHistoryHandler handles a list-history message.
+func listHistoryHandler(in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[ListHistoryPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultListHistoryOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+	if payload.First.Valid {
+		options.WithFirst(payload.First.Int64)
+	}
+
+	entries, err := armaria.ListHistory(options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindJournalEntries, JournalEntriesPayload{
+		Entries: JournalEntryMapper(entries),
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
+
+// batchHandler handles a batch message.
+func batchHandler(in NativeMessage) (NativeMessage, error) {
+	payload, err := GetPayload[BatchPayload](in)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	options := armaria.DefaultBatchOptions()
+	if payload.DB.Valid {
+		options.WithDB(payload.DB.String)
+	}
+
+	results := make([]NativeMessage, 0, len(payload.Operations))
+	ids := make([]string, 0, len(payload.Operations))
+
+	err = armaria.RunBatch(func(batch *armaria.Batch) error {
+		for i, operation := range payload.Operations {
+			handler, ok := batchOperationHandler(operation.Kind)
+			if !ok {
+				return fmt.Errorf("error in batch operation %d: %w: %s", i, errUnknownKind, operation.Kind)
+			}
+
+			operation, err := resolveBatchOperation(operation, payload.DB, ids)
+			if err != nil {
+				return fmt.Errorf("error in batch operation %d: %w", i, err)
+			}
+
+			out, err := handler(batch, operation)
+			if err != nil {
+				return fmt.Errorf("error in batch operation %d: %w", i, err)
+			}
+
+			results = append(results, out)
+			ids = append(ids, batchResultID(out))
+		}
+
+		return nil
+	}, options)
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	out, err := PayloadToMessage(MessageKindBatchResults, BatchResultsPayload{
+		Results: results,
+	})
+	if err != nil {
+		return NativeMessage{}, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,33 @@
+package messaging
+
+import (
+	"github.com/jonathanhope/armaria/pkg"
+	"github.com/samber/lo"
+)
+
+// JournalEntryDTO is an operation recorded in the journal that can be marshalled into JSON.
+type JournalEntryDTO struct {
+	ID          int64  `json:"id"`
+	Operation   string `json:"operation"`
+	Description string `json:"description"`
+	Undone      bool   `json:"undone"`
+	Created     string `json:"created"`
+}
+
+// JournalEntryMapper maps journal entries to JournalEntryDTOs.
+func JournalEntryMapper(entries []armaria.JournalEntry) []JournalEntryDTO {
+	return lo.Map(entries, func(entry armaria.JournalEntry, _ int) JournalEntryDTO {
+		return journalEntryMapper(entry)
+	})
+}
+
+// journalEntryMapper maps a journal entry to a JournalEntryDTO.
+func journalEntryMapper(entry armaria.JournalEntry) JournalEntryDTO {
+	return JournalEntryDTO{
+		ID:          entry.ID,
+		Operation:   string(entry.Operation),
+		Description: entry.Description,
+		Undone:      entry.Undone,
+		Created:     entry.Created,
+	}
+}
@@ -0,0 +1,31 @@
+package messaging
+
+import (
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/pkg"
+	"github.com/samber/lo"
+)
+
+// LinkCheckDTO is the outcome of checking a bookmark's URL that can be marshalled into JSON.
+type LinkCheckDTO struct {
+	Book     BookDTO         `json:"book"`
+	Status   null.NullInt64  `json:"status"`
+	Redirect null.NullString `json:"redirect"`
+	Error    null.NullString `json:"error"`
+	Checked  string          `json:"checked"`
+	Broken   bool            `json:"broken"`
+}
+
+// LinkCheckMapper maps link checks to LinkCheckDTOs.
+func LinkCheckMapper(checks []armaria.LinkCheck) []LinkCheckDTO {
+	return lo.Map(checks, func(check armaria.LinkCheck, _ int) LinkCheckDTO {
+		return LinkCheckDTO{
+			Book:     bookMapper(check.Book),
+			Status:   null.NullInt64FromPtr(check.Status),
+			Redirect: null.NullStringFromPtr(check.Redirect),
+			Error:    null.NullStringFromPtr(check.Error),
+			Checked:  check.Checked,
+			Broken:   check.Broken(),
+		}
+	})
+}
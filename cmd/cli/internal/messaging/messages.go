@@ -10,29 +10,54 @@ import (
 type MessageKind string
 
 const (
-	MessageKindError        MessageKind = "error"         // message contains an error that occurred
-	MessageKindBooks        MessageKind = "books"         // message contains zero or more books
-	MessageKindBook         MessageKind = "book"          // message contains a single book
-	MessageKindVoid         MessageKind = "void"          // message contains nothing
-	MessageKindTags         MessageKind = "tags"          // message contains zero or more tags
-	MessageKindConfigValue  MessageKind = "config-value"  // message contains a config value
-	MessageKindParentNames  MessageKind = "parent-names"  // message contains zero or more parent names
-	MessageKindAddBook      MessageKind = "add-book"      // message is a request to add a bookmark
-	MessageKindAddFolder    MessageKind = "add-folder"    // message is a request to add a bookmark
-	MessageKindAddTags      MessageKind = "add-tags"      // message is a request to add tags
-	MessageKindListBooks    MessageKind = "list-books"    // message is a request to list books
-	MessageKindListTags     MessageKind = "list-tags"     // message is a request to list tags
-	MessageKindRemoveBook   MessageKind = "remove-book"   // message is a request to remove a bookmark
-	MessageKindRemoveFolder MessageKind = "remove-folder" // message is a request to remove a folder
-	MessageKindRemoveTags   MessageKind = "remove-tags"   // message is a request to remove tags from a bookmark
-	MessageKindUpdateBook   MessageKind = "update-book"   // message is a request to update a bookmark
-	MessageKindUpdateFolder MessageKind = "update-folder" // message is a request to update a folder
+	MessageKindError          MessageKind = "error"            // message contains an error that occurred
+	MessageKindBooks          MessageKind = "books"            // message contains zero or more books
+	MessageKindBook           MessageKind = "book"             // message contains a single book
+	MessageKindTree           MessageKind = "tree"             // message contains nested books
+	MessageKindVoid           MessageKind = "void"             // message contains nothing
+	MessageKindTags           MessageKind = "tags"             // message contains zero or more tags
+	MessageKindTagCount       MessageKind = "tag-count"        // message contains a tag and how many bookmarks it's applied to
+	MessageKindTagCounts      MessageKind = "tag-counts"       // message contains zero or more tags and how many bookmarks they are applied to
+	MessageKindTagTree        MessageKind = "tag-tree"         // message contains nested tags and how many bookmarks they are applied to
+	MessageKindConfigValue    MessageKind = "config-value"     // message contains a config value
+	MessageKindParentNames    MessageKind = "parent-names"     // message contains zero or more parent names
+	MessageKindBatchResults   MessageKind = "batch-results"    // message contains the results of each operation in a batch
+	MessageKindDuplicates     MessageKind = "duplicates"       // message contains zero or more groups of duplicate bookmarks
+	MessageKindLinkChecks     MessageKind = "link-checks"      // message contains the outcomes of checking zero or more bookmark URLs
+	MessageKindArchives       MessageKind = "archives"         // message contains zero or more offline copies of bookmarked pages
+	MessageKindFavicons       MessageKind = "favicons"         // message contains zero or more icons of bookmarks' sites
+	MessageKindJournalEntry   MessageKind = "journal-entry"    // message contains an operation that was undone or redone
+	MessageKindJournalEntries MessageKind = "journal-entries"  // message contains zero or more operations from the journal
+	MessageKindAddBook        MessageKind = "add-book"         // message is a request to add a bookmark
+	MessageKindAddFolder      MessageKind = "add-folder"       // message is a request to add a bookmark
+	MessageKindAddTags        MessageKind = "add-tags"         // message is a request to add tags
+	MessageKindAddSession     MessageKind = "add-session"      // message is a request to add a folder with a bookmark for each URL
+	MessageKindGetBook        MessageKind = "get-book"         // message is a request to get a bookmark or folder
+	MessageKindGetParentNames MessageKind = "get-parent-names" // message is a request to get the parent names of a bookmark or folder
+	MessageKindGetFavicons    MessageKind = "get-favicons"     // message is a request to get the stored icons of bookmarks
+	MessageKindGetDBConfig    MessageKind = "get-db-config"    // message is a request to get the location of the bookmarks database from the config
+	MessageKindSetDBConfig    MessageKind = "set-db-config"    // message is a request to set the location of the bookmarks database in the config
+	MessageKindListBooks      MessageKind = "list-books"       // message is a request to list books
+	MessageKindListTags       MessageKind = "list-tags"        // message is a request to list tags
+	MessageKindRemoveBook     MessageKind = "remove-book"      // message is a request to remove a bookmark
+	MessageKindRemoveFolder   MessageKind = "remove-folder"    // message is a request to remove a folder
+	MessageKindRemoveTags     MessageKind = "remove-tags"      // message is a request to remove tags from a bookmark
+	MessageKindRenameTag      MessageKind = "rename-tag"       // message is a request to rename a tag on every bookmark
+	MessageKindMergeTags      MessageKind = "merge-tags"       // message is a request to merge tags into a single tag on every bookmark
+	MessageKindDeleteTag      MessageKind = "delete-tag"       // message is a request to remove a tag from every bookmark
+	MessageKindUpdateBook     MessageKind = "update-book"      // message is a request to update a bookmark
+	MessageKindUpdateFolder   MessageKind = "update-folder"    // message is a request to update a folder
+	MessageKindUndo           MessageKind = "undo"             // message is a request to undo the most recent operation
+	MessageKindRedo           MessageKind = "redo"             // message is a request to redo the most recently undone operation
+	MessageKindListHistory    MessageKind = "list-history"     // message is a request to list the operations in the journal
+	MessageKindBatch          MessageKind = "batch"            // message is a request to run several operations in a single transaction
 )
 
 // NativeMessage is a message sent to or received from a browser extension.
 type NativeMessage struct {
-	Kind    MessageKind `json:"kind"`    // denotes what kind of message this is
-	Payload string      `json:"payload"` // a JSON payload that is different depending on the MessageKind
+	Kind      MessageKind `json:"kind"`                // denotes what kind of message this is
+	Payload   string      `json:"payload"`             // a JSON payload that is different depending on the MessageKind
+	RequestID string      `json:"requestId,omitempty"` // correlates a response with its request; echoed back as is
 }
 
 // SendMessage sends a message to a browser extension.
@@ -64,7 +89,7 @@ func ReceiveMessage(reader io.Reader) (NativeMessage, error) {
 	}
 
 	messageBytes := make([]byte, messageLength)
-	_, err = reader.Read(messageBytes)
+	_, err = io.ReadFull(reader, messageBytes)
 	if err != nil {
 		return NativeMessage{}, err
 	}
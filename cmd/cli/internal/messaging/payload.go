@@ -12,17 +12,19 @@ import (
 
 // Payload are the payloads that a message can have.
 type Payload interface {
-	AddBookPayload | AddFolderPayload | AddTagsPayload | ListBooksPayload | ListTagsPayload | RemoveBookPayload | RemoveFolderPayload | RemoveTagsPayload | UpdateBookPayload | UpdateFolderPayload | ErrorPayload | BooksPayload | BookPayload | TagsPayload | VoidPayload | ConfigValuePayload | ParentNamesPayload
+	AddBookPayload | AddFolderPayload | AddTagsPayload | AddSessionPayload | GetBookPayload | GetParentNamesPayload | GetFaviconsPayload | GetDBConfigPayload | SetDBConfigPayload | ListBooksPayload | ListTagsPayload | RemoveBookPayload | RemoveFolderPayload | RemoveTagsPayload | RenameTagPayload | MergeTagsPayload | DeleteTagPayload | UpdateBookPayload | UpdateFolderPayload | UndoPayload | RedoPayload | ListHistoryPayload | BatchPayload | ErrorPayload | BooksPayload | TreePayload | BookPayload | TagsPayload | TagCountPayload | TagCountsPayload | TagTreePayload | VoidPayload | ConfigValuePayload | ParentNamesPayload | BatchResultsPayload | DuplicatesPayload | LinkChecksPayload | ArchivesPayload | FaviconsPayload | JournalEntryPayload | JournalEntriesPayload
 }
 
 // AddBookPayload is a payload for a request to add a bookmark.
 type AddBookPayload struct {
-	DB          null.NullString `json:"db"`
-	URL         string          `json:"url"`
-	Name        null.NullString `json:"name"`
-	Description null.NullString `json:"description"`
-	ParentID    null.NullString `json:"parentId"`
-	Tags        []string        `json:"tags"`
+	DB               null.NullString `json:"db"`
+	URL              string          `json:"url"`
+	Name             null.NullString `json:"name"`
+	Description      null.NullString `json:"description"`
+	ParentID         null.NullString `json:"parentId"`
+	Tags             []string        `json:"tags"`
+	RejectDuplicates bool            `json:"rejectDuplicates"`
+	FetchMetadata    bool            `json:"fetchMetadata"`
 }
 
 // AddFolderPayload is a payload for a request to add a folder.
@@ -39,6 +41,42 @@ type AddTagsPayload struct {
 	Tags []string        `json:"tags"`
 }
 
+// AddSessionPayload is a payload for a request to add a folder with a bookmark for each URL.
+type AddSessionPayload struct {
+	DB       null.NullString `json:"db"`
+	Name     string          `json:"name"`
+	ParentID null.NullString `json:"parentId"`
+	URLs     []string        `json:"urls"`
+	Tags     []string        `json:"tags"`
+}
+
+// GetBookPayload is a payload for a request to get a bookmark or folder.
+type GetBookPayload struct {
+	DB null.NullString `json:"db"`
+	ID string          `json:"id"`
+}
+
+// GetParentNamesPayload is a payload for a request to get the parent names of a bookmark or folder.
+type GetParentNamesPayload struct {
+	DB null.NullString `json:"db"`
+	ID string          `json:"id"`
+}
+
+// GetFaviconsPayload is a payload for a request to get the stored icons of bookmarks.
+// If no IDs are provided every stored icon is returned.
+type GetFaviconsPayload struct {
+	DB  null.NullString `json:"db"`
+	IDs []string        `json:"ids"`
+}
+
+// GetDBConfigPayload is a payload for a request to get the location of the bookmarks database from the config.
+type GetDBConfigPayload struct{}
+
+// SetDBConfigPayload is a payload for a request to set the location of the bookmarks database in the config.
+type SetDBConfigPayload struct {
+	DB string `json:"db"`
+}
+
 // ListBooksPayload is a payload for a request to list bookmarks.
 type ListBooksPayload struct {
 	DB               null.NullString `json:"db"`
@@ -48,6 +86,10 @@ type ListBooksPayload struct {
 	WithoutParentID  bool            `json:"withoutParentID"`
 	Query            null.NullString `json:"query"`
 	Tags             []string        `json:"tags"`
+	TagMode          string          `json:"tagMode"`
+	ExcludeTags      []string        `json:"excludeTags"`
+	Recursive        bool            `json:"recursive"`
+	Depth            null.NullInt64  `json:"depth"`
 	After            null.NullString `json:"after"`
 	Order            string          `json:"order"`
 	Direction        string          `json:"direction"`
@@ -61,6 +103,8 @@ type ListTagsPayload struct {
 	After     null.NullString `json:"after"`
 	Direction string          `json:"direction"`
 	First     null.NullInt64  `json:"first"`
+	Counts    bool            `json:"counts"`
+	Tree      bool            `json:"tree"`
 }
 
 // RemoveBookPayload is a payload for a request to delete a bookmark.
@@ -82,6 +126,26 @@ type RemoveTagsPayload struct {
 	Tags []string        `json:"tags"`
 }
 
+// RenameTagPayload is a payload for a request to rename a tag.
+type RenameTagPayload struct {
+	DB   null.NullString `json:"db"`
+	From string          `json:"from"`
+	To   string          `json:"to"`
+}
+
+// MergeTagsPayload is a payload for a request to merge tags into a single tag.
+type MergeTagsPayload struct {
+	DB   null.NullString `json:"db"`
+	Tags []string        `json:"tags"`
+	Into string          `json:"into"`
+}
+
+// DeleteTagPayload is a payload for a request to delete a tag.
+type DeleteTagPayload struct {
+	DB  null.NullString `json:"db"`
+	Tag string          `json:"tag"`
+}
+
 // UpdateBookPayload is a payload for a request to update a bookmark.
 type UpdateBookPayload struct {
 	DB                null.NullString `json:"db"`
@@ -107,6 +171,29 @@ type UpdateFolderPayload struct {
 	NextBook       null.NullString `json:"nextBook"`
 }
 
+// UndoPayload is a payload for a request to undo the most recent operation.
+type UndoPayload struct {
+	DB null.NullString `json:"db"`
+}
+
+// RedoPayload is a payload for a request to redo the most recently undone operation.
+type RedoPayload struct {
+	DB null.NullString `json:"db"`
+}
+
+// ListHistoryPayload is a payload for a request to list the operations in the journal.
+type ListHistoryPayload struct {
+	DB    null.NullString `json:"db"`
+	First null.NullInt64  `json:"first"`
+}
+
+// BatchPayload is a payload for a request to run several operations in a single transaction.
+// An operation can use the ID of a bookmark/folder returned by an earlier operation with "$N.id".
+type BatchPayload struct {
+	DB         null.NullString `json:"db"`
+	Operations []NativeMessage `json:"operations"`
+}
+
 // ErrorPayload is a payload for a response with an error in it.
 type ErrorPayload struct {
 	Error string `json:"error"`
@@ -117,6 +204,11 @@ type BooksPayload struct {
 	Books []BookDTO `json:"books"`
 }
 
+// TreePayload is a payload for a response with nested bookmarks/folders in it.
+type TreePayload struct {
+	Tree []TreeDTO `json:"tree"`
+}
+
 // BookPayload is a payload for a response with a bookmark/folder in it.
 type BookPayload struct {
 	Book BookDTO `json:"book"`
@@ -127,6 +219,21 @@ type TagsPayload struct {
 	Tags []string `json:"tags"`
 }
 
+// TagCountPayload is a payload for a response with a tag and how many bookmarks it's applied to in it.
+type TagCountPayload struct {
+	Tag TagCountDTO `json:"tag"`
+}
+
+// TagCountsPayload is a payload for a response with tags and how many bookmarks they are applied to in it.
+type TagCountsPayload struct {
+	Tags []TagCountDTO `json:"tags"`
+}
+
+// TagTreePayload is a payload for a response with nested tags and how many bookmarks they are applied to in it.
+type TagTreePayload struct {
+	Tree []TagTreeDTO `json:"tree"`
+}
+
 // ConfigValuePayload is a payload for a response with a config value in it.
 type ConfigValuePayload struct {
 	Value string `json:"value"`
@@ -137,6 +244,41 @@ type ParentNamesPayload struct {
 	ParentNames []string
 }
 
+// BatchResultsPayload is a payload for a response with the result of each operation in a batch.
+type BatchResultsPayload struct {
+	Results []NativeMessage `json:"results"`
+}
+
+// DuplicatesPayload is a payload for a response with groups of duplicate bookmarks in it.
+type DuplicatesPayload struct {
+	Duplicates []DuplicatesDTO `json:"duplicates"`
+}
+
+// LinkChecksPayload is a payload for a response with the outcomes of checking bookmark URLs in it.
+type LinkChecksPayload struct {
+	LinkChecks []LinkCheckDTO `json:"linkChecks"`
+}
+
+// ArchivesPayload is a payload for a response with offline copies of bookmarked pages in it.
+type ArchivesPayload struct {
+	Archives []ArchiveDTO `json:"archives"`
+}
+
+// FaviconsPayload is a payload for a response with the icons of bookmarks' sites in it.
+type FaviconsPayload struct {
+	Favicons []FaviconDTO `json:"favicons"`
+}
+
+// JournalEntryPayload is a payload for a response with an operation that was undone or redone in it.
+type JournalEntryPayload struct {
+	Entry JournalEntryDTO `json:"entry"`
+}
+
+// JournalEntriesPayload is a payload for a response with the operations in the journal in it.
+type JournalEntriesPayload struct {
+	Entries []JournalEntryDTO `json:"entries"`
+}
+
 // VoidPayload is a payload for a response with nothing in it.
 type VoidPayload struct{}
 
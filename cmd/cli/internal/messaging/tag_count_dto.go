@@ -0,0 +1,27 @@
+package messaging
+
+import (
+	"github.com/jonathanhope/armaria/pkg"
+	"github.com/samber/lo"
+)
+
+// TagCountDTO is a tag and how many bookmarks it's applied to that can be marshalled into JSON.
+type TagCountDTO struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// TagCountMapper maps tag counts to TagCountDTOs.
+func TagCountMapper(counts []armaria.TagCount) []TagCountDTO {
+	return lo.Map(counts, func(count armaria.TagCount, _ int) TagCountDTO {
+		return tagCountMapper(count)
+	})
+}
+
+// tagCountMapper maps a tag count to a TagCountDTO.
+func tagCountMapper(count armaria.TagCount) TagCountDTO {
+	return TagCountDTO{
+		Tag:   count.Tag,
+		Count: count.Count,
+	}
+}
@@ -0,0 +1,31 @@
+package messaging
+
+import (
+	"github.com/jonathanhope/armaria/pkg"
+	"github.com/samber/lo"
+)
+
+// TagTreeDTO is a level in the tree of hierarchical tags, along with everything underneath it, that can be marshalled into JSON.
+type TagTreeDTO struct {
+	Name     string       `json:"name"`
+	Tag      string       `json:"tag"`
+	Count    int64        `json:"count"`
+	Children []TagTreeDTO `json:"children"`
+}
+
+// TagTreeMapper maps a tree of tags to TagTreeDTOs.
+func TagTreeMapper(nodes []armaria.TagNode) []TagTreeDTO {
+	return lo.Map(nodes, func(node armaria.TagNode, _ int) TagTreeDTO {
+		return tagTreeMapper(node)
+	})
+}
+
+// tagTreeMapper maps a level of the tag tree (and all of its children) to a TagTreeDTO.
+func tagTreeMapper(node armaria.TagNode) TagTreeDTO {
+	return TagTreeDTO{
+		Name:     node.Name,
+		Tag:      node.Tag,
+		Count:    node.Count,
+		Children: TagTreeMapper(node.Children),
+	}
+}
@@ -0,0 +1,46 @@
+package messaging
+
+import (
+	"github.com/jonathanhope/armaria/pkg"
+)
+
+// TreeDTO is a bookmark or folder, along with everything inside of it, that can be marshalled into JSON.
+type TreeDTO struct {
+	BookDTO
+	Children []TreeDTO `json:"children"`
+}
+
+// TreeMapper nests a list of bookmarks/folders under their parents.
+// Anything whose parent isn't in the list is at the top level.
+// The order of the list is preserved within each folder.
+func TreeMapper(books []armaria.Book) []TreeDTO {
+	children := make(map[string][]armaria.Book)
+	ids := make(map[string]bool)
+	for _, book := range books {
+		ids[book.ID] = true
+	}
+
+	roots := make([]armaria.Book, 0)
+	for _, book := range books {
+		if book.ParentID != nil && ids[*book.ParentID] {
+			children[*book.ParentID] = append(children[*book.ParentID], book)
+		} else {
+			roots = append(roots, book)
+		}
+	}
+
+	return treeMapper(roots, children)
+}
+
+// treeMapper maps a level of the tree (and all of its children) to TreeDTOs.
+func treeMapper(books []armaria.Book, children map[string][]armaria.Book) []TreeDTO {
+	nodes := make([]TreeDTO, 0, len(books))
+	for _, book := range books {
+		nodes = append(nodes, TreeDTO{
+			BookDTO:  bookMapper(book),
+			Children: treeMapper(children[book.ID], children),
+		})
+	}
+
+	return nodes
+}
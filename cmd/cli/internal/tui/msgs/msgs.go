@@ -25,6 +25,10 @@ type ErrorMsg struct{ Err error }
 // Error stringifies ErrorMsg.
 func (e ErrorMsg) Error() string { return e.Err.Error() }
 
+// QueryErrorMsg is a message that contains a search query that couldn't be parsed.
+// Unlike ErrorMsg it doesn't switch to the error view since the query is usually still being typed.
+type QueryErrorMsg struct{ Err error }
+
 // ViewMsg is a message that contains which view to show.
 type ViewMsg View
 
@@ -1,9 +1,8 @@
 package booksview
 
 import (
+	"errors"
 	"fmt"
-	"os/exec"
-	"runtime"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,6 +13,7 @@ import (
 	"github.com/jonathanhope/armaria/cmd/cli/internal/tui/controls/scrolltable"
 	"github.com/jonathanhope/armaria/cmd/cli/internal/tui/controls/typeahead"
 	"github.com/jonathanhope/armaria/cmd/cli/internal/tui/msgs"
+	"github.com/jonathanhope/armaria/internal/browser"
 	"github.com/jonathanhope/armaria/pkg"
 	"github.com/samber/lo"
 )
@@ -47,6 +47,8 @@ const (
 	addTagOperation                        // using a typeahead to add a tag
 	removeTagOperation                     // using a typeahead to remove a tag
 	changeParentOperation                  // using a typeahead to change a books parent
+	browseTagsOperation                    // using a typeahead to filter by a tag
+	copyOperation                          // using a typeahead to copy a book to a folder
 )
 
 // model is the model for the book listing.
@@ -58,6 +60,8 @@ type model struct {
 	height    int                                        // the current height of the screen
 	folder    string                                     // the current folder
 	query     string                                     // current search query
+	queryErr  string                                     // why the current search query couldn't be parsed (if it couldn't)
+	tag       string                                     // current tag filter; includes every tag underneath it
 	header    header.HeaderModel                         // header for app
 	footer    footer.FooterModel                         // footer for app
 	table     scrolltable.ScrolltableModel[armaria.Book] // table of books
@@ -111,6 +115,7 @@ func InitialModel() tea.Model {
 				{Context: "Listing", Key: "right", Help: "Move to folder children"},
 				{Context: "Listing", Key: "enter", Help: "Open bookmark or folder"},
 				{Context: "Listing", Key: "s", Help: "Search bookmarks/folders"},
+				{Context: "Listing", Key: "g", Help: "Browse tags"},
 				{Context: "Listing", Key: "c", Help: "Clear filters"},
 				{Context: "Listing", Key: "r", Help: "Reload books"},
 				{Context: "Listing", Key: "u", Help: "Edit URL"},
@@ -121,6 +126,9 @@ func InitialModel() tea.Model {
 				{Context: "Listing", Key: "T", Help: "Remove tag"},
 				{Context: "Listing", Key: "p", Help: "Change parent"},
 				{Context: "Listing", Key: "P", Help: "Remove parent"},
+				{Context: "Listing", Key: "y", Help: "Copy to folder"},
+				{Context: "Listing", Key: "z", Help: "Undo last change"},
+				{Context: "Listing", Key: "Z", Help: "Redo last undone change"},
 				{Context: "Listing", Key: "q", Help: "Quit"},
 				{Context: "Input", Key: "left", Help: "Move to previous char"},
 				{Context: "Input", Key: "right", Help: "Move to next char"},
@@ -188,6 +196,24 @@ func formatTags(book armaria.Book) string {
 	return strings.Join(book.Tags, ", ")
 }
 
+// tagTreeItems flattens a level of the tag tree (and all of its children) into typeahead items.
+// Each level is indented underneath its namespace.
+func tagTreeItems(nodes []armaria.TagNode, depth int) []typeahead.TypeaheadItem {
+	items := make([]typeahead.TypeaheadItem, 0)
+
+	for _, node := range nodes {
+		label := strings.Repeat("  ", depth) + node.Name
+		if node.Count > 0 {
+			label = fmt.Sprintf("%s (%d)", label, node.Count)
+		}
+
+		items = append(items, typeahead.TypeaheadItem{Label: label, Value: node.Tag})
+		items = append(items, tagTreeItems(node.Children, depth+1)...)
+	}
+
+	return items
+}
+
 // resize changes the size of the books view.
 func (m *model) resize() {
 	tableHeight := m.height -
@@ -207,11 +233,21 @@ func (m *model) resize() {
 
 // updateFilters will upate the filters display in the header based on the current filters.
 func (m *model) updateFilters() {
+	filters := make([]string, 0)
+
 	if len(m.query) > 0 {
-		m.footer.SetFilters([]string{fmt.Sprintf("Query: %s", m.query)})
-	} else {
-		m.footer.SetFilters([]string{})
+		filters = append(filters, fmt.Sprintf("Query: %s", m.query))
 	}
+
+	if len(m.tag) > 0 {
+		filters = append(filters, fmt.Sprintf("Tag: %s", m.tag))
+	}
+
+	if len(m.queryErr) > 0 {
+		filters = append(filters, fmt.Sprintf("Invalid query: %s", m.queryErr))
+	}
+
+	m.footer.SetFilters(filters)
 }
 
 // Update handles a message.
@@ -226,9 +262,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case msgs.DataMsg[armaria.Book]:
 		if msg.Name == TableName {
 			m.header.SetFree()
+			m.queryErr = ""
+			m.updateFilters()
 			return m, m.table.Reload(msg.Data, msg.Move)
 		}
 
+	case msgs.QueryErrorMsg:
+		// The current listing is kept until the query can be parsed again.
+		m.header.SetFree()
+		m.queryErr = msg.Err.Error()
+		m.updateFilters()
+
 	case msgs.DataMsg[typeahead.TypeaheadItem]:
 		if msg.Name == m.typeahead.TableName() {
 			return m, m.typeahead.Reload(msg.Data, msg.Move)
@@ -346,6 +390,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, m.removeTagCmd(value.Value)
 				case changeParentOperation:
 					return m, m.changeParentCmd(value.Value)
+				case copyOperation:
+					return m, m.copyCmd(value.Value)
+				case browseTagsOperation:
+					m.header.SetFree()
+					m.tag = value.Value
+					m.updateFilters()
+					return m, m.getBooksCmd(msgs.DirectionStart)
 				}
 
 			default:
@@ -415,8 +466,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 			case "c":
-				if m.query != "" && !m.header.Busy() {
+				if (m.query != "" || m.tag != "") && !m.header.Busy() {
 					m.query = ""
+					m.tag = ""
 					m.updateFilters()
 					return m, m.getBooksCmd(msgs.DirectionNone)
 				}
@@ -450,7 +502,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 			case "ctrl+up":
-				if m.query == "" && !m.table.Empty() && m.table.Index() > 0 && !m.header.Busy() {
+				if m.query == "" && m.tag == "" && !m.table.Empty() && m.table.Index() > 0 && !m.header.Busy() {
 					m.header.SetBusy()
 					if m.table.Index() == 1 {
 						next := m.table.Data()[0].ID
@@ -463,7 +515,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 			case "ctrl+down":
-				if m.query == "" && !m.table.Empty() && m.table.Index() < len(m.table.Data())-1 && !m.header.Busy() {
+				if m.query == "" && m.tag == "" && !m.table.Empty() && m.table.Index() < len(m.table.Data())-1 && !m.header.Busy() {
 					m.header.SetBusy()
 					if m.table.Index() == len(m.table.Data())-2 {
 						previous := m.table.Data()[len(m.table.Data())-1].ID
@@ -579,6 +631,92 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 								WithQuery(query)
 							books, err := armaria.ListBooks(options)
 
+							// Nothing matches a query that's still being typed.
+							if errors.Is(err, armaria.ErrInvalidQuery) {
+								return make([]typeahead.TypeaheadItem, 0), nil
+							}
+							if err != nil {
+								return nil, err
+							}
+
+							items := lo.Map(books, func(book armaria.Book, index int) typeahead.TypeaheadItem {
+								return typeahead.TypeaheadItem{Label: book.Name, Value: book.ID}
+							})
+
+							return items, nil
+						},
+					})
+				}
+
+			case "g":
+				if !m.header.Busy() {
+					m.operation = browseTagsOperation
+					m.header.SetBusy()
+					return m, m.typeahead.StartTypeahead(typeahead.StartTypeaheadPayload{
+						Prompt:         "Browse Tags: ",
+						Text:           "",
+						MaxChars:       128,
+						IncludeInput:   false,
+						MinFilterChars: 3,
+						UnfilteredQuery: func() ([]typeahead.TypeaheadItem, error) {
+							options := armaria.DefaultListTagsOptions()
+							tree, err := armaria.ListTagTree(options)
+
+							if err != nil {
+								return nil, err
+							}
+
+							return tagTreeItems(tree, 0), nil
+						},
+						FilteredQuery: func(query string) ([]typeahead.TypeaheadItem, error) {
+							options := armaria.DefaultListTagsOptions().WithQuery(query)
+							tree, err := armaria.ListTagTree(options)
+
+							if err != nil {
+								return nil, err
+							}
+
+							return tagTreeItems(tree, 0), nil
+						},
+					})
+				}
+
+			case "y":
+				if !m.header.Busy() && !m.table.Empty() {
+					m.operation = copyOperation
+					m.header.SetBusy()
+					return m, m.typeahead.StartTypeahead(typeahead.StartTypeaheadPayload{
+						Prompt:         "Copy To: ",
+						Text:           "",
+						MaxChars:       2048,
+						IncludeInput:   false,
+						MinFilterChars: 3,
+						UnfilteredQuery: func() ([]typeahead.TypeaheadItem, error) {
+							options := armaria.DefaultListBooksOptions().WithFolders(true).WithBooks(false)
+							books, err := armaria.ListBooks(options)
+
+							if err != nil {
+								return nil, err
+							}
+
+							items := lo.Map(books, func(book armaria.Book, index int) typeahead.TypeaheadItem {
+								return typeahead.TypeaheadItem{Label: book.Name, Value: book.ID}
+							})
+
+							return items, nil
+						},
+						FilteredQuery: func(query string) ([]typeahead.TypeaheadItem, error) {
+							options := armaria.
+								DefaultListBooksOptions().
+								WithFolders(true).
+								WithBooks(false).
+								WithQuery(query)
+							books, err := armaria.ListBooks(options)
+
+							// Nothing matches a query that's still being typed.
+							if errors.Is(err, armaria.ErrInvalidQuery) {
+								return make([]typeahead.TypeaheadItem, 0), nil
+							}
 							if err != nil {
 								return nil, err
 							}
@@ -596,6 +734,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if !m.header.Busy() && !m.table.Empty() && m.table.Selection().ParentID != nil {
 					return m, m.removeParentCmd()
 				}
+
+			case "z":
+				if !m.header.Busy() {
+					m.header.SetBusy()
+					return m, m.undoCmd()
+				}
+
+			case "Z":
+				if !m.header.Busy() {
+					m.header.SetBusy()
+					return m, m.redoCmd()
+				}
 			}
 		}
 	}
@@ -653,12 +803,24 @@ func (m *model) getBooksCmd(move msgs.Direction) tea.Cmd {
 			options.WithParentID(m.folder)
 		}
 
-		// The query must be at least 3 chars.
-		if len(m.query) > 2 {
+		// Tagged bookmarks anywhere inside of the current folder are listed.
+		if m.tag != "" {
+			options.
+				WithTags([]string{m.tag, m.tag + armaria.TagDescendants}).
+				WithFolders(false).
+				WithRecursive(true)
+		}
+
+		// Search results are ranked by how well they match.
+		if m.query != "" {
 			options.WithQuery(m.query)
+			options.WithOrder(armaria.OrderRelevance)
 		}
 
 		books, err := armaria.ListBooks(options)
+		if errors.Is(err, armaria.ErrInvalidQuery) {
+			return msgs.QueryErrorMsg{Err: err}
+		}
 		if err != nil {
 			return msgs.ErrorMsg{Err: err}
 		}
@@ -670,22 +832,7 @@ func (m *model) getBooksCmd(move msgs.Direction) tea.Cmd {
 // openURLCmd opens a bookmarks URL in the browser.
 func (m model) openURLCmd() tea.Cmd {
 	return func() tea.Msg {
-		var cmd string
-		var args []string
-
-		switch runtime.GOOS {
-		case "windows":
-			cmd = "cmd"
-			args = []string{"/c", "start"}
-		case "darwin":
-			cmd = "open"
-		default: // "linux", "freebsd", "openbsd", "netbsd"
-			cmd = "xdg-open"
-		}
-
-		args = append(args, *m.table.Selection().URL)
-		err := exec.Command(cmd, args...).Start()
-		if err != nil {
+		if err := browser.Open(*m.table.Selection().URL); err != nil {
 			return msgs.ErrorMsg{Err: err}
 		}
 
@@ -946,6 +1093,32 @@ func (m model) removeTagCmd(tag string) tea.Cmd {
 	}
 }
 
+// undoCmd undoes the most recent change to the bookmarks database.
+func (m model) undoCmd() tea.Cmd {
+	return func() tea.Msg {
+		// Having nothing to undo isn't an error worth leaving the listing for.
+		_, err := armaria.Undo(armaria.DefaultUndoOptions())
+		if err != nil && !errors.Is(err, armaria.ErrNothingToUndo) {
+			return msgs.ErrorMsg{Err: err}
+		}
+
+		return m.getBooksCmd(msgs.DirectionNone)()
+	}
+}
+
+// redoCmd redoes the most recently undone change to the bookmarks database.
+func (m model) redoCmd() tea.Cmd {
+	return func() tea.Msg {
+		// Having nothing to redo isn't an error worth leaving the listing for.
+		_, err := armaria.Redo(armaria.DefaultRedoOptions())
+		if err != nil && !errors.Is(err, armaria.ErrNothingToRedo) {
+			return msgs.ErrorMsg{Err: err}
+		}
+
+		return m.getBooksCmd(msgs.DirectionNone)()
+	}
+}
+
 // changeParentCmd changes the parent of a bookmark or folder.
 func (m model) changeParentCmd(parentID string) tea.Cmd {
 	return func() tea.Msg {
@@ -966,3 +1139,24 @@ func (m model) changeParentCmd(parentID string) tea.Cmd {
 		return m.getBooksCmd(msgs.DirectionNone)()
 	}
 }
+
+// copyCmd copies a bookmark or folder to a folder.
+func (m model) copyCmd(parentID string) tea.Cmd {
+	return func() tea.Msg {
+		if m.table.Selection().IsFolder {
+			options := armaria.DefaultCopyFolderOptions().WithParentID(parentID)
+			_, err := armaria.CopyFolder(m.table.Selection().ID, options)
+			if err != nil {
+				return msgs.ErrorMsg{Err: err}
+			}
+		} else {
+			options := armaria.DefaultCopyBookOptions().WithParentID(parentID)
+			_, err := armaria.CopyBook(m.table.Selection().ID, options)
+			if err != nil {
+				return msgs.ErrorMsg{Err: err}
+			}
+		}
+
+		return m.getBooksCmd(msgs.DirectionNone)()
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/alecthomas/kong"
 	"github.com/jonathanhope/armaria/cmd/cli/internal"
 	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+	"github.com/jonathanhope/armaria/internal/browser"
 	"github.com/jonathanhope/armaria/internal/manifest"
 )
 
@@ -24,8 +25,10 @@ func main() {
 		}
 	}
 
+	// The host answers messages until the browser closes stdin.
+	// This works for both one off messages and ports that are kept open.
 	if hostMode {
-		if err := messaging.Dispatch(os.Stdin, os.Stdout); err != nil {
+		if err := messaging.Serve(os.Stdin, os.Stdout); err != nil {
 			fmt.Printf("Unexpected error: %s", err)
 			os.Exit(1)
 		}
@@ -38,8 +41,10 @@ func main() {
 	err := ctx.Run(&cmd.Context{
 		DB:         rootCmd.DB,
 		Formatter:  rootCmd.Formatter,
+		Reader:     os.Stdin,
 		Writer:     os.Stdout,
 		ReturnCode: os.Exit,
+		Open:       browser.Open,
 		Version:    version})
 
 	ctx.FatalIfErrorf(err)
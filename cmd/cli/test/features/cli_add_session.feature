@@ -0,0 +1,74 @@
+Feature: Add Session with CLI
+
+  @cli @add_session
+  Scenario: Can add a session
+    When I run it with the following args:
+      """
+      add session --name Tabs --url https://jho.pe --url https://go.dev --tag tabs
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | {tabs} | NULL      | true      | Tabs           | NULL           | NULL        |      |
+      | {id_1} | [tabs]    | false     | https://jho.pe | https://jho.pe | NULL        | tabs |
+      | {id_2} | [tabs]    | false     | https://go.dev | https://go.dev | NULL        | tabs |
+    And the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [tabs] | NULL      | true      | Tabs           | NULL           | NULL        |      |
+      | [id_1] | [tabs]    | false     | https://jho.pe | https://jho.pe | NULL        | tabs |
+      | [id_2] | [tabs]    | false     | https://go.dev | https://go.dev | NULL        | tabs |
+
+  @cli @add_session
+  Scenario: Can add a session in a folder
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name     | url  | description | tags |
+      | {work} | NULL      | true      | Work     | NULL | NULL        |      |
+    When I run it with the following args:
+      """
+      add session --name Tabs --folder [work] --url https://jho.pe
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | {tabs} | [work]    | true      | Tabs           | NULL           | NULL        |      |
+      | {id_1} | [tabs]    | false     | https://jho.pe | https://jho.pe | NULL        |      |
+
+  @cli @add_session
+  Scenario: Can add a session from stdin
+    Given the following input is piped in:
+      """
+      https://jho.pe
+
+      https://go.dev
+      """
+    When I run it with the following args:
+      """
+      add session --name Tabs
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | {tabs} | NULL      | true      | Tabs           | NULL           | NULL        |      |
+      | {id_1} | [tabs]    | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | {id_2} | [tabs]    | false     | https://go.dev | https://go.dev | NULL        |      |
+
+  @cli @add_session
+  Scenario: A session needs URLs
+    When I run it with the following args:
+      """
+      add session --name Tabs
+      """
+    Then the following error is returned:
+      """
+      No URLs
+      """
+
+  @cli @add_session
+  Scenario: Nothing is added if a bookmark is invalid
+    When I run it with the following args:
+      """
+      add session --name Tabs --url https://jho.pe --tag "bad tag"
+      """
+    Then the following error is returned:
+      """
+      Tag has invalid chars
+      """
+    And the following bookmarks/folders exist:
+      | id | parent_id | is_folder | name | url | description | tags |
@@ -0,0 +1,121 @@
+Feature: Archive with CLI
+
+  @cli @archive
+  Scenario: Can archive a bookmark
+    Given a web server is running with the following pages:
+      | url    | path  | status | location | body                                                |
+      | {blog} | /blog | 200    |          | <title>Blog</title><p>Notes on quokka habitats.</p> |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | {id_1} | NULL      | false     | Blog | [blog] | NULL        |      |
+    When I run it with the following args:
+      """
+      archive [id_1]
+      """
+    Then the folllowing archives are returned:
+      | id     | url    | content_type | inlined |
+      | [id_1] | [blog] | text/html    | false   |
+
+  @cli @archive
+  Scenario: Can inline assets when archiving a bookmark
+    Given a web server is running with the following pages:
+      | url    | path  | status | location | body                                                |
+      | {blog} | /blog | 200    |          | <title>Blog</title><p>Notes on quokka habitats.</p> |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | {id_1} | NULL      | false     | Blog | [blog] | NULL        |      |
+    When I run it with the following args:
+      """
+      archive [id_1] --inline
+      """
+    Then the folllowing archives are returned:
+      | id     | url    | content_type | inlined |
+      | [id_1] | [blog] | text/html    | true    |
+
+  @cli @archive
+  Scenario: Can archive all bookmarks missing an archive
+    Given a web server is running with the following pages:
+      | url    | path  | status | location | body                                                |
+      | {blog} | /blog | 200    |          | <title>Blog</title><p>Notes on quokka habitats.</p> |
+      | {news} | /news | 200    |          | <title>News</title><p>Nothing happened today.</p>   |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | {id_1} | NULL      | false     | Blog | [blog] | NULL        |      |
+      | {id_2} | NULL      | false     | News | [news] | NULL        |      |
+    When I run it with the following args:
+      """
+      archive [id_1]
+      """
+    And I run it with the following args:
+      """
+      archive --all-missing
+      """
+    Then the folllowing archives are returned:
+      | id     | url    | content_type | inlined |
+      | [id_2] | [news] | text/html    | false   |
+
+  @cli @archive
+  Scenario: Archived text is searchable
+    Given a web server is running with the following pages:
+      | url    | path  | status | location | body                                                |
+      | {blog} | /blog | 200    |          | <title>Blog</title><p>Notes on quokka habitats.</p> |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url                      | description | tags |
+      | {id_1} | NULL      | false     | Blog | [blog]                   | NULL        |      |
+      | {id_2} | NULL      | false     | News | https://example.com/news | NULL        |      |
+    When I run it with the following args:
+      """
+      archive [id_1]
+      """
+    And I run it with the following args:
+      """
+      query quokka
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | [id_1] | NULL      | false     | Blog | [blog] | NULL        |      |
+
+  @cli @archive
+  Scenario: Either an id or all missing is required
+    When I run it with the following args:
+      """
+      archive
+      """
+    Then the following error is returned:
+      """
+      Either an id or all-missing is required
+      """
+
+  @cli @archive
+  Scenario: Can't open an archive that doesn't exist
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url                 | description | tags |
+      | {id_1} | NULL      | false     | Blog | https://example.com | NULL        |      |
+    When I run it with the following args:
+      """
+      open [id_1] --archived
+      """
+    Then the following error is returned:
+      """
+      Archive not found
+      """
+
+  @cli @archive
+  Scenario: Can open an archived copy of a bookmark
+    Given a web server is running with the following pages:
+      | url    | path  | status | location | body                                                |
+      | {blog} | /blog | 200    |          | <title>Blog</title><p>Notes on quokka habitats.</p> |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | {id_1} | NULL      | false     | Blog | [blog] | NULL        |      |
+    When I run it with the following args:
+      """
+      archive [id_1]
+      """
+    And I run it with the following args:
+      """
+      open [id_1] --archived
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | [id_1] | NULL      | false     | Blog | [blog] | NULL        |      |
@@ -0,0 +1,376 @@
+Feature: Backup and Restore JSON with CLI
+
+  The Armaria CLI can be used to backup bookmarks to JSON and restore them again.
+
+  @cli @backup_json
+  Scenario: Can backup and restore bookmarks and folders
+    Given the DB already has the following entries:
+      | id          | parent_id   | is_folder | name           | url                 | description                | tags              |
+      | {parent_id} | NULL        | true      | blogs          | NULL                | NULL                       |                   |
+      | {id_1}      | [parent_id] | false     | The Flat Field | https://jho.pe      | The blog of Jonathan Hope. | blog, programming |
+      | {id_2}      | NULL        | false     | Armaria        | https://armaria.net | NULL                       |                   |
+    And the file "backup.json" has the following contents:
+      """
+      """
+    When I run it with the following args:
+      """
+      export json --output [backup.json]
+      """
+    And I run it with the following args:
+      """
+      remove folder [parent_id]
+      """
+    And I run it with the following args:
+      """
+      import json [backup.json] --mode replace
+      """
+    Then the following bookmarks/folders exist:
+      | id          | parent_id   | is_folder | name           | url                 | description                | tags              |
+      | [parent_id] | NULL        | true      | blogs          | NULL                | NULL                       |                   |
+      | [id_1]      | [parent_id] | false     | The Flat Field | https://jho.pe      | The blog of Jonathan Hope. | blog, programming |
+      | [id_2]      | NULL        | false     | Armaria        | https://armaria.net | NULL                       |                   |
+    And the folllowing tags exist:
+      | tag         |
+      | blog        |
+      | programming |
+
+  @cli @backup_json
+  Scenario: Restoring in replace mode removes everything else
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name    | url                 | description | tags |
+      | {id} | NULL      | false     | Armaria | https://armaria.net | NULL        | docs |
+    And the file "backup.json" has the following contents:
+      """
+      {
+        "version": 1,
+        "bookmarks": [
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "parentId": null,
+            "isFolder": false,
+            "name": "The Flat Field",
+            "url": "https://jho.pe",
+            "description": null,
+            "modified": "2024-01-01 00:00:00",
+            "order": "a"
+          }
+        ],
+        "tags": [
+          {
+            "tag": "blog",
+            "modified": "2024-01-01 00:00:00"
+          }
+        ],
+        "bookmarksTags": [
+          {
+            "bookmarkId": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "tag": "blog",
+            "modified": "2024-01-01 00:00:00"
+          }
+        ]
+      }
+      """
+    When I run it with the following args:
+      """
+      import json [backup.json] --mode replace
+      """
+    Then the following bookmarks/folders exist:
+      | id                                   | parent_id | is_folder | name           | url            | description | tags |
+      | 1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01 | NULL      | false     | The Flat Field | https://jho.pe | NULL        | blog |
+    And the folllowing tags exist:
+      | tag  |
+      | blog |
+
+  @cli @backup_json
+  Scenario: Restoring in merge mode reconciles by ID
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name    | url                 | description | tags |
+      | {id} | NULL      | false     | Armaria | https://armaria.net | NULL        | docs |
+    And the file "first.json" has the following contents:
+      """
+      {
+        "version": 1,
+        "bookmarks": [
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "parentId": null,
+            "isFolder": false,
+            "name": "jho.pe",
+            "url": "https://jho.pe",
+            "description": null,
+            "modified": "2024-01-01 00:00:00",
+            "order": "c"
+          }
+        ],
+        "tags": [
+          {
+            "tag": "programming",
+            "modified": "2024-01-01 00:00:00"
+          }
+        ],
+        "bookmarksTags": [
+          {
+            "bookmarkId": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "tag": "programming",
+            "modified": "2024-01-01 00:00:00"
+          }
+        ]
+      }
+      """
+    And the file "second.json" has the following contents:
+      """
+      {
+        "version": 1,
+        "bookmarks": [
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "parentId": null,
+            "isFolder": false,
+            "name": "The Flat Field",
+            "url": "https://jho.pe",
+            "description": "The blog of Jonathan Hope.",
+            "modified": "2024-01-02 00:00:00",
+            "order": "c"
+          }
+        ],
+        "tags": [
+          {
+            "tag": "blog",
+            "modified": "2024-01-02 00:00:00"
+          }
+        ],
+        "bookmarksTags": [
+          {
+            "bookmarkId": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "tag": "blog",
+            "modified": "2024-01-02 00:00:00"
+          }
+        ]
+      }
+      """
+    When I run it with the following args:
+      """
+      import json [first.json]
+      """
+    And I run it with the following args:
+      """
+      import json [second.json] --mode merge
+      """
+    Then the following bookmarks/folders exist:
+      | id                                   | parent_id | is_folder | name           | url                 | description                | tags |
+      | [id]                                 | NULL      | false     | Armaria        | https://armaria.net | NULL                       | docs |
+      | 1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01 | NULL      | false     | The Flat Field | https://jho.pe      | The blog of Jonathan Hope. | blog |
+    And the folllowing tags exist:
+      | tag  |
+      | blog |
+      | docs |
+
+  @cli @backup_json
+  Scenario: Restoring in merge mode can't move a folder into its descendants
+    Given the file "first.json" has the following contents:
+      """
+      {
+        "version": 1,
+        "bookmarks": [
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "parentId": null,
+            "isFolder": true,
+            "name": "tech",
+            "url": null,
+            "description": null,
+            "modified": "2024-01-01 00:00:00",
+            "order": "c"
+          },
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a02",
+            "parentId": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "isFolder": true,
+            "name": "blogs",
+            "url": null,
+            "description": null,
+            "modified": "2024-01-01 00:00:00",
+            "order": "c"
+          }
+        ],
+        "tags": [],
+        "bookmarksTags": []
+      }
+      """
+    And the file "second.json" has the following contents:
+      """
+      {
+        "version": 1,
+        "bookmarks": [
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "parentId": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a02",
+            "isFolder": true,
+            "name": "tech",
+            "url": null,
+            "description": null,
+            "modified": "2024-01-02 00:00:00",
+            "order": "c"
+          }
+        ],
+        "tags": [],
+        "bookmarksTags": []
+      }
+      """
+    When I run it with the following args:
+      """
+      import json [first.json]
+      """
+    And I run it with the following args:
+      """
+      import json [second.json] --mode merge
+      """
+    Then the following error is returned:
+      """
+      Can't move a folder into itself
+      """
+    And the following bookmarks/folders exist:
+      | id                                   | parent_id                            | is_folder | name  | url  | description | tags |
+      | 1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01 | NULL                                 | true      | tech  | NULL | NULL        |      |
+      | 1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a02 | 1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01 | true      | blogs | NULL | NULL        |      |
+
+  @cli @backup_json
+  Scenario: Restoring in merge mode can't turn a folder with children into a bookmark
+    Given the file "first.json" has the following contents:
+      """
+      {
+        "version": 1,
+        "bookmarks": [
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "parentId": null,
+            "isFolder": true,
+            "name": "tech",
+            "url": null,
+            "description": null,
+            "modified": "2024-01-01 00:00:00",
+            "order": "c"
+          },
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a02",
+            "parentId": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "isFolder": true,
+            "name": "blogs",
+            "url": null,
+            "description": null,
+            "modified": "2024-01-01 00:00:00",
+            "order": "c"
+          }
+        ],
+        "tags": [],
+        "bookmarksTags": []
+      }
+      """
+    And the file "second.json" has the following contents:
+      """
+      {
+        "version": 1,
+        "bookmarks": [
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "parentId": null,
+            "isFolder": false,
+            "name": "tech",
+            "url": "https://jho.pe",
+            "description": null,
+            "modified": "2024-01-02 00:00:00",
+            "order": "c"
+          }
+        ],
+        "tags": [],
+        "bookmarksTags": []
+      }
+      """
+    When I run it with the following args:
+      """
+      import json [first.json]
+      """
+    And I run it with the following args:
+      """
+      import json [second.json] --mode merge
+      """
+    Then the following error is returned:
+      """
+      Can't turn a folder with children into a bookmark
+      """
+    And the following bookmarks/folders exist:
+      | id                                   | parent_id                            | is_folder | name  | url  | description | tags |
+      | 1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01 | NULL                                 | true      | tech  | NULL | NULL        |      |
+      | 1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a02 | 1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01 | true      | blogs | NULL | NULL        |      |
+
+  @cli @backup_json
+  Scenario: Nothing is restored if a parent folder is missing
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name    | url                 | description | tags |
+      | {id} | NULL      | false     | Armaria | https://armaria.net | NULL        |      |
+    And the file "backup.json" has the following contents:
+      """
+      {
+        "version": 1,
+        "bookmarks": [
+          {
+            "id": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a01",
+            "parentId": "1f0b5a4e-4a8e-4c2f-9a83-7c0b1e0d2a02",
+            "isFolder": false,
+            "name": "The Flat Field",
+            "url": "https://jho.pe",
+            "description": null,
+            "modified": "2024-01-01 00:00:00",
+            "order": "a"
+          }
+        ],
+        "tags": [],
+        "bookmarksTags": []
+      }
+      """
+    When I run it with the following args:
+      """
+      import json [backup.json] --mode replace
+      """
+    Then the following error is returned:
+      """
+      Folder not found
+      """
+    And the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name    | url                 | description | tags |
+      | [id] | NULL      | false     | Armaria | https://armaria.net | NULL        |      |
+
+  @cli @backup_json
+  Scenario: Backup must be a supported version
+    Given the file "backup.json" has the following contents:
+      """
+      {
+        "version": 2,
+        "bookmarks": [],
+        "tags": [],
+        "bookmarksTags": []
+      }
+      """
+    When I run it with the following args:
+      """
+      import json [backup.json]
+      """
+    Then the following error is returned:
+      """
+      Unsupported backup version
+      """
+
+  @cli @backup_json
+  Scenario: Backup must be valid JSON
+    Given the file "backup.json" has the following contents:
+      """
+      <DL><p></DL><p>
+      """
+    When I run it with the following args:
+      """
+      import json [backup.json]
+      """
+    Then the following error is returned:
+      """
+      Invalid backup
+      """
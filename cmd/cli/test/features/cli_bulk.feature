@@ -0,0 +1,236 @@
+Feature: Bulk Operations with CLI
+
+  The Armaria CLI can be used to change many bookmarks and folders at once.
+
+  @cli @bulk
+  Scenario: Can add tags to the bookmarks matching a query
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url                   | description | tags |
+      | {id_1} | NULL      | false     | Go      | https://go.dev        | NULL        | blog |
+      | {id_2} | NULL      | false     | Go Blog | https://go.dev/blog   | NULL        |      |
+      | {id_3} | NULL      | false     | Rust    | https://rust-lang.org | NULL        |      |
+    When I run it with the following args:
+      """
+      bulk --query go.dev add-tags blog lang/go
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name    | url                 | description | tags          |
+      | [id_1] | NULL      | false     | Go      | https://go.dev      | NULL        | blog, lang/go |
+      | [id_2] | NULL      | false     | Go Blog | https://go.dev/blog | NULL        | blog, lang/go |
+    And the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name    | url                   | description | tags          |
+      | [id_1] | NULL      | false     | Go      | https://go.dev        | NULL        | blog, lang/go |
+      | [id_2] | NULL      | false     | Go Blog | https://go.dev/blog   | NULL        | blog, lang/go |
+      | [id_3] | NULL      | false     | Rust    | https://rust-lang.org | NULL        |               |
+
+  @cli @bulk
+  Scenario: Folders are skipped when adding tags
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id}          | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      bulk --folder [parent_1_id] add-tags blog
+      """
+    And I run it with the following args:
+      """
+      bulk --no-folder add-tags blog
+      """
+    Then the following bookmarks/folders exist:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | [parent_1_id] | NULL          | true      | blogs          | NULL           | NULL        |      |
+      | [id]          | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+
+  @cli @bulk
+  Scenario: Can remove tags from the bookmarks with a tag
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags             |
+      | {id_1} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog, old        |
+      | {id_2} | NULL      | false     | https://go.dev | https://go.dev | NULL        | old, programming |
+    When I run it with the following args:
+      """
+      bulk --tag old remove-tags old
+      """
+    Then the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags        |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog        |
+      | [id_2] | NULL      | false     | https://go.dev | https://go.dev | NULL        | programming |
+    And the folllowing tags exist:
+      | tag         |
+      | blog        |
+      | programming |
+
+  @cli @bulk
+  Scenario: Can move bookmarks selected by IDs from stdin
+    Given the DB already has the following entries:
+      | id            | parent_id | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL      | true      | blogs          |                | NULL        |      |
+      | {id_1}        | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | {id_2}        | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+      | {id_3}        | NULL      | false     | https://bun.sh | https://bun.sh | NULL        |      |
+    And the following input is piped in:
+      """
+      [id_1]
+      [id_3]
+      """
+    When I run it with the following args:
+      """
+      bulk --stdin move --to [parent_1_id]
+      """
+    Then the following bookmarks/folders exist:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | [parent_1_id] | NULL          | true      | blogs          | NULL           | NULL        |      |
+      | [id_1]        | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | [id_3]        | [parent_1_id] | false     | https://bun.sh | https://bun.sh | NULL        |      |
+      | [id_2]        | NULL          | false     | https://go.dev | https://go.dev | NULL        |      |
+
+  @cli @bulk
+  Scenario: Can move bookmarks to the top level
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id}          | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      bulk --folder [parent_1_id] move --top-level
+      """
+    Then the following bookmarks/folders exist:
+      | id            | parent_id | is_folder | name           | url            | description | tags |
+      | [parent_1_id] | NULL      | true      | blogs          | NULL           | NULL        |      |
+      | [id]          | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+
+  @cli @bulk
+  Scenario: Can't move a folder into itself
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name  | url | description | tags |
+      | {parent_1_id} | NULL          | true      | tech  |     | NULL        |      |
+      | {parent_2_id} | [parent_1_id] | true      | blogs |     | NULL        |      |
+    When I run it with the following args:
+      """
+      bulk --no-folder move --to [parent_2_id]
+      """
+    Then the following error is returned:
+      """
+      Can't move a folder into itself
+      """
+
+  @cli @bulk
+  Scenario: Move requires a destination
+    When I run it with the following args:
+      """
+      bulk --no-folder move
+      """
+    Then the following error is returned:
+      """
+      Either to or top-level is required
+      """
+
+  @cli @bulk
+  Scenario: Can remove bookmarks and folders
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id_1}        | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | {id_2}        | NULL          | false     | https://go.dev | https://go.dev | NULL        | go   |
+      | {id_3}        | NULL          | false     | https://bun.sh | https://bun.sh | NULL        |      |
+    When I run it with the following args:
+      """
+      bulk --no-folder --not-tag go remove
+      """
+    And I run it with the following args:
+      """
+      list all --recursive
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_2] | NULL      | false     | https://go.dev | https://go.dev | NULL        | go   |
+
+  @cli @bulk
+  Scenario: Removing counts everything moved to the trash
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id_1}        | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | {id_2}        | NULL          | false     | https://go.dev | https://go.dev | NULL        | go   |
+      | {id_3}        | NULL          | false     | https://bun.sh | https://bun.sh | NULL        |      |
+    When I run it with the following args:
+      """
+      bulk --no-folder --not-tag go remove
+      """
+    And I run it with the following args:
+      """
+      history --first 1
+      """
+    Then the folllowing history is returned:
+      | operation   | description                 | undone |
+      | bulk-remove | Removed 3 bookmarks/folders | false  |
+
+  @cli @bulk
+  Scenario: Can update the description of bookmarks
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | {id_1} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | {id_2} | NULL      | false     | https://go.dev | https://go.dev | Go          |      |
+    When I run it with the following args:
+      """
+      bulk --tag blog description --description "A blog"
+      """
+    And I run it with the following args:
+      """
+      bulk --query go.dev description --no-description
+      """
+    Then the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | A blog      | blog |
+      | [id_2] | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+
+  @cli @bulk
+  Scenario: A dry run doesn't change anything
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | {id_1} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | {id_2} | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      bulk --tag blog --dry-run remove
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+    And the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | [id_2] | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+
+  @cli @bulk
+  Scenario: Can undo a bulk change in one step
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | {id_1} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | {id_2} | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      bulk --no-folder add-tags reading
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | [id_2] | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+    And the folllowing tags exist:
+      | tag |
+
+  @cli @bulk
+  Scenario: A selection is required
+    When I run it with the following args:
+      """
+      bulk add-tags blog
+      """
+    Then the following error is returned:
+      """
+      At least one filter or ID is required
+      """
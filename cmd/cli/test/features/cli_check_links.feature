@@ -0,0 +1,120 @@
+Feature: Check Links with CLI
+
+  @cli @check-links
+  Scenario: Can check links
+    Given a web server is running with the following pages:
+      | url       | path     | status | location |
+      | {ok}      | /ok      | 200    |          |
+      | {missing} | /missing | 404    |          |
+      | {moved}   | /moved   | 301    | /ok      |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url       | description | tags |
+      | {id_1} | NULL      | false     | OK      | [ok]      | NULL        |      |
+      | {id_2} | NULL      | false     | Missing | [missing] | NULL        |      |
+      | {id_3} | NULL      | false     | Moved   | [moved]   | NULL        |      |
+    When I run it with the following args:
+      """
+      check links --host-interval 0s
+      """
+    Then the folllowing link checks are returned:
+      | id     | status | redirect | broken |
+      | [id_1] | 200    | NULL     | false  |
+      | [id_2] | 404    | NULL     | true   |
+      | [id_3] | 200    | [ok]     | false  |
+
+  @cli @check-links
+  Scenario: Can check links in a folder
+    Given a web server is running with the following pages:
+      | url       | path     | status | location |
+      | {ok}      | /ok      | 200    |          |
+      | {missing} | /missing | 404    |          |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url       | description | tags |
+      | {work} | NULL      | true      | Work    | NULL      | NULL        |      |
+      | {id_1} | [work]    | false     | OK      | [ok]      | NULL        |      |
+      | {id_2} | NULL      | false     | Missing | [missing] | NULL        |      |
+    When I run it with the following args:
+      """
+      check links --folder [work] --host-interval 0s
+      """
+    Then the folllowing link checks are returned:
+      | id     | status | redirect | broken |
+      | [id_1] | 200    | NULL     | false  |
+
+  @cli @check-links
+  Scenario: Can show only broken links
+    Given a web server is running with the following pages:
+      | url       | path     | status | location |
+      | {ok}      | /ok      | 200    |          |
+      | {missing} | /missing | 404    |          |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url                   | description | tags |
+      | {id_1} | NULL      | false     | OK      | [ok]                  | NULL        |      |
+      | {id_2} | NULL      | false     | Missing | [missing]             | NULL        |      |
+      | {id_3} | NULL      | false     | Refused | http://127.0.0.1:1/   | NULL        |      |
+    When I run it with the following args:
+      """
+      check links --broken --host-interval 0s
+      """
+    Then the folllowing link checks are returned:
+      | id     | status | redirect | broken |
+      | [id_2] | 404    | NULL     | true   |
+      | [id_3] | NULL   | NULL     | true   |
+
+  @cli @check-links
+  Scenario: Can tag and move broken links
+    Given a web server is running with the following pages:
+      | url       | path     | status | location |
+      | {ok}      | /ok      | 200    |          |
+      | {missing} | /missing | 404    |          |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url       | description | tags |
+      | {dead} | NULL      | true      | Dead    | NULL      | NULL        |      |
+      | {id_1} | NULL      | false     | OK      | [ok]      | NULL        |      |
+      | {id_2} | NULL      | false     | Missing | [missing] | NULL        |      |
+    When I run it with the following args:
+      """
+      check links --tag broken --move [dead] --host-interval 0s
+      """
+    Then the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name    | url       | description | tags   |
+      | [dead] | NULL      | true      | Dead    | NULL      | NULL        |        |
+      | [id_2] | [dead]    | false     | Missing | [missing] | NULL        | broken |
+      | [id_1] | NULL      | false     | OK      | [ok]      | NULL        |        |
+
+  @cli @check-links
+  Scenario: Can list broken links from the last check
+    Given a web server is running with the following pages:
+      | url       | path     | status | location |
+      | {ok}      | /ok      | 200    |          |
+      | {missing} | /missing | 404    |          |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url       | description | tags |
+      | {id_1} | NULL      | false     | OK      | [ok]      | NULL        |      |
+      | {id_2} | NULL      | false     | Missing | [missing] | NULL        |      |
+    When I run it with the following args:
+      """
+      check links --host-interval 0s
+      """
+    And I run it with the following args:
+      """
+      check broken --tag broken
+      """
+    Then the folllowing link checks are returned:
+      | id     | status | redirect | broken |
+      | [id_2] | 404    | NULL     | true   |
+    And the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name    | url       | description | tags   |
+      | [id_1] | NULL      | false     | OK      | [ok]      | NULL        |        |
+      | [id_2] | NULL      | false     | Missing | [missing] | NULL        | broken |
+
+  @cli @check-links
+  Scenario: Concurrency must be positive
+    When I run it with the following args:
+      """
+      check links --concurrency 0
+      """
+    Then the following error is returned:
+      """
+      Concurrency too small
+      """
@@ -0,0 +1,174 @@
+Feature: Copy with CLI
+
+  The Armaria CLI can be used to copy bookmarks and folders.
+
+  @cli @copy
+  Scenario: Can copy a bookmark
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags       |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | blog        | blog, tech |
+    When I run it with the following args:
+      """
+      copy book [id]
+      """
+    Then the folllowing books are returned:
+      | id        | parent_id | is_folder | name           | url            | description | tags       |
+      | {copy_id} | NULL      | false     | https://jho.pe | https://jho.pe | blog        | blog, tech |
+    And the following bookmarks/folders exist:
+      | id        | parent_id | is_folder | name           | url            | description | tags       |
+      | [id]      | NULL      | false     | https://jho.pe | https://jho.pe | blog        | blog, tech |
+      | [copy_id] | NULL      | false     | https://jho.pe | https://jho.pe | blog        | blog, tech |
+
+  @cli @copy
+  Scenario: Can copy a bookmark to a folder
+    Given the DB already has the following entries:
+      | id          | parent_id | is_folder | name           | url            | description | tags |
+      | {parent_id} | NULL      | true      | blogs          | NULL           | NULL        |      |
+      | {id}        | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+    When I run it with the following args:
+      """
+      copy book [id] --folder [parent_id]
+      """
+    Then the following bookmarks/folders exist:
+      | id          | parent_id   | is_folder | name           | url            | description | tags |
+      | [parent_id] | NULL        | true      | blogs          | NULL           | NULL        |      |
+      | {copy_id}   | [parent_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | [id]        | NULL        | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+
+  @cli @copy
+  Scenario: Can copy a bookmark to the top level
+    Given the DB already has the following entries:
+      | id          | parent_id   | is_folder | name           | url            | description | tags |
+      | {parent_id} | NULL        | true      | blogs          | NULL           | NULL        |      |
+      | {id}        | [parent_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      copy book [id] --no-folder
+      """
+    Then the following bookmarks/folders exist:
+      | id          | parent_id   | is_folder | name           | url            | description | tags |
+      | [parent_id] | NULL        | true      | blogs          | NULL           | NULL        |      |
+      | [id]        | [parent_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | {copy_id}   | NULL        | false     | https://jho.pe | https://jho.pe | NULL        |      |
+
+  @cli @copy
+  Scenario: Can copy a folder and everything in it
+    Given the DB already has the following entries:
+      | id          | parent_id   | is_folder | name           | url            | description | tags |
+      | {id}        | NULL        | true      | tech           | NULL           | NULL        |      |
+      | {child_id}  | [id]        | true      | blogs          | NULL           | NULL        |      |
+      | {book_1_id} | [child_id]  | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | {book_2_id} | [id]        | false     | https://go.dev | https://go.dev | Go          |      |
+    When I run it with the following args:
+      """
+      copy folder [id]
+      """
+    Then the folllowing books are returned:
+      | id        | parent_id | is_folder | name | url  | description | tags |
+      | {copy_id} | NULL      | true      | tech | NULL | NULL        |      |
+    And the following bookmarks/folders exist:
+      | id              | parent_id       | is_folder | name           | url            | description | tags |
+      | [id]            | NULL            | true      | tech           | NULL           | NULL        |      |
+      | [child_id]      | [id]            | true      | blogs          | NULL           | NULL        |      |
+      | [book_1_id]     | [child_id]      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | {copy_child_id} | [copy_id]       | true      | blogs          | NULL           | NULL        |      |
+      | {copy_book_1}   | [copy_child_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | [book_2_id]     | [id]            | false     | https://go.dev | https://go.dev | Go          |      |
+      | [copy_id]       | NULL            | true      | tech           | NULL           | NULL        |      |
+      | {copy_book_2}   | [copy_id]       | false     | https://go.dev | https://go.dev | Go          |      |
+
+  @cli @copy
+  Scenario: Can copy a folder into itself
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | true      | blogs          | NULL           | NULL        |      |
+      | {bk} | [id]      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      copy folder [id] --folder [id]
+      """
+    Then the folllowing books are returned:
+      | id        | parent_id | is_folder | name  | url  | description | tags |
+      | {copy_id} | [id]      | true      | blogs | NULL | NULL        |      |
+    When I run it with the following args:
+      """
+      list all --folder [copy_id]
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | {copy} | [copy_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    And the following bookmarks/folders exist:
+      | id        | parent_id | is_folder | name           | url            | description | tags |
+      | [id]      | NULL      | true      | blogs          | NULL           | NULL        |      |
+      | [bk]      | [id]      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | [copy]    | [copy_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | [copy_id] | [id]      | true      | blogs          | NULL           | NULL        |      |
+
+  @cli @copy
+  Scenario: Can undo a copy in one step
+    Given the DB already has the following entries:
+      | id        | parent_id | is_folder | name           | url            | description | tags |
+      | {id}      | NULL      | true      | blogs          | NULL           | NULL        |      |
+      | {book_id} | [id]      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+    When I run it with the following args:
+      """
+      copy folder [id]
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id        | parent_id | is_folder | name           | url            | description | tags |
+      | [id]      | NULL      | true      | blogs          | NULL           | NULL        |      |
+      | [book_id] | [id]      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+
+  @cli @copy
+  Scenario: Bookmark must exist to be copied
+    When I run it with the following args:
+      """
+      copy book test
+      """
+    Then the following error is returned:
+      """
+      Bookmark not found
+      """
+
+  @cli @copy
+  Scenario: Folder must exist to be copied
+    When I run it with the following args:
+      """
+      copy folder test
+      """
+    Then the following error is returned:
+      """
+      Folder not found
+      """
+
+  @cli @copy
+  Scenario: Destination folder must exist
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      copy book [id] --folder test
+      """
+    Then the following error is returned:
+      """
+      Folder not found
+      """
+
+  @cli @copy
+  Scenario: Cannot copy to a folder and the top level at the same time
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name  | url  | description | tags |
+      | {id} | NULL      | true      | blogs | NULL | NULL        |      |
+    When I run it with the following args:
+      """
+      copy folder [id] --folder [id] --no-folder
+      """
+    Then the following error is returned:
+      """
+      Arguments folder and no-folder are mutually exclusive
+      """
@@ -0,0 +1,99 @@
+Feature: Dedupe with CLI
+
+  @cli @dedupe
+  Scenario: Can list duplicate bookmarks
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name  | url                                 | description | tags |
+      | {work} | NULL      | true      | Work  | NULL                                | NULL        |      |
+      | {id_1} | NULL      | false     | Blog  | https://jho.pe                      | NULL        |      |
+      | {id_2} | [work]    | false     | Blog  | HTTPS://JHO.PE/?utm_source=rss      | NULL        |      |
+      | {id_3} | NULL      | false     | Go    | https://go.dev                      | NULL        |      |
+      | {id_4} | [work]    | false     | Posts | https://jho.pe/posts                | NULL        |      |
+    When I run it with the following args:
+      """
+      dedupe
+      """
+    Then the folllowing duplicates are returned:
+      | url            | id     | path        |
+      | https://jho.pe | [id_1] | Blog        |
+      | https://jho.pe | [id_2] | Work / Blog |
+
+  @cli @dedupe
+  Scenario: Can merge duplicate bookmarks
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url             | description | tags     |
+      | {id_1} | NULL      | false     | Blog | https://jho.pe  | A blog      | blog     |
+      | {id_2} | NULL      | false     | Blog | https://jho.pe/ | NULL        | personal |
+      | {id_3} | NULL      | false     | Blog | https://jho.pe  | About code  | blog     |
+    When I run it with the following args:
+      """
+      dedupe merge [id_1] [id_2] [id_3]
+      """
+    Then the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name | url            | description            | tags           |
+      | [id_1] | NULL      | false     | Blog | https://jho.pe | A blog\n\nAbout code  | blog, personal |
+
+  @cli @dedupe
+  Scenario: Merged duplicates are moved to the trash
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url             | description | tags     |
+      | {id_1} | NULL      | false     | Blog | https://jho.pe  | NULL        | blog     |
+      | {id_2} | NULL      | false     | Blog | https://jho.pe/ | NULL        | personal |
+    When I run it with the following args:
+      """
+      dedupe merge [id_1] [id_2]
+      """
+    And I run it with the following args:
+      """
+      trash list
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url             | description | tags     |
+      | [id_2] | NULL      | false     | Blog | https://jho.pe/ | NULL        | personal |
+
+  @cli @dedupe
+  Scenario: Can't merge a bookmark into itself
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url            | description | tags |
+      | {id_1} | NULL      | false     | Blog | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      dedupe merge [id_1] [id_1]
+      """
+    Then the following error is returned:
+      """
+      Can't merge a bookmark into itself
+      """
+
+  @cli @dedupe
+  Scenario: Can't merge bookmarks with different URLs
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url                 | description | tags |
+      | {id_1} | NULL      | false     | Blog    | https://jho.pe      | NULL        |      |
+      | {id_2} | NULL      | false     | Armaria | https://armaria.net | NULL        |      |
+    When I run it with the following args:
+      """
+      dedupe merge [id_1] [id_2]
+      """
+    Then the following error is returned:
+      """
+      Bookmarks don't have the same URL
+      """
+    And the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name    | url                 | description | tags |
+      | [id_1] | NULL      | false     | Blog    | https://jho.pe      | NULL        |      |
+      | [id_2] | NULL      | false     | Armaria | https://armaria.net | NULL        |      |
+
+  @cli @dedupe
+  Scenario: Can reject duplicate bookmarks
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url            | description | tags |
+      | {id_1} | NULL      | false     | Blog | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      add book https://jho.pe/?utm_source=rss --reject-duplicates
+      """
+    Then the following error is returned:
+      """
+      Duplicate URL
+      """
@@ -0,0 +1,69 @@
+Feature: Export Netscape Bookmark Files with CLI
+
+  The Armaria CLI can be used to export bookmarks to a Netscape bookmark file.
+
+  @cli @export_html
+  Scenario: Can export bookmarks and folders
+    Given the DB already has the following entries:
+      | id          | parent_id   | is_folder | name           | url                 | description                | tags              |
+      | {parent_id} | NULL        | true      | blogs          | NULL                | NULL                       |                   |
+      | {id_1}      | [parent_id] | false     | The Flat Field | https://jho.pe      | The blog of Jonathan Hope. | blog, programming |
+      | {id_2}      | NULL        | false     | Armaria        | https://armaria.net | NULL                       |                   |
+    When I run it with the following args:
+      """
+      export html
+      """
+    Then the following output is returned:
+      """
+      <!DOCTYPE NETSCAPE-Bookmark-file-1>
+      <!-- This is an automatically generated file.
+           It will be read and overwritten.
+           DO NOT EDIT! -->
+      <META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+      <TITLE>Bookmarks</TITLE>
+      <H1>Bookmarks</H1>
+      <DL><p>
+          <DT><H3>blogs</H3>
+          <DL><p>
+              <DT><A HREF="https://jho.pe" TAGS="blog,programming">The Flat Field</A>
+              <DD>The blog of Jonathan Hope.
+          </DL><p>
+          <DT><A HREF="https://armaria.net">Armaria</A>
+      </DL><p>
+      """
+
+  @cli @export_html
+  Scenario: Can export bookmarks from a folder
+    Given the DB already has the following entries:
+      | id          | parent_id   | is_folder | name           | url                 | description | tags |
+      | {parent_id} | NULL        | true      | blogs          | NULL                | NULL        |      |
+      | {id_1}      | [parent_id] | false     | The Flat Field | https://jho.pe      | NULL        |      |
+      | {id_2}      | NULL        | false     | Armaria        | https://armaria.net | NULL        |      |
+    When I run it with the following args:
+      """
+      export html --folder [parent_id]
+      """
+    Then the following output is returned:
+      """
+      <!DOCTYPE NETSCAPE-Bookmark-file-1>
+      <!-- This is an automatically generated file.
+           It will be read and overwritten.
+           DO NOT EDIT! -->
+      <META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+      <TITLE>Bookmarks</TITLE>
+      <H1>Bookmarks</H1>
+      <DL><p>
+          <DT><A HREF="https://jho.pe">The Flat Field</A>
+      </DL><p>
+      """
+
+  @cli @export_html
+  Scenario: Folder must exist
+    When I run it with the following args:
+      """
+      export html --folder [uuid]
+      """
+    Then the following error is returned:
+      """
+      Folder not found
+      """
@@ -0,0 +1,152 @@
+Feature: Hierarchical Tags with CLI
+
+  The Armaria CLI can be used to organize tags into namespaces such as lang/go.
+
+  @cli @hierarchical_tags
+  Scenario: Can add a tag with a namespace
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      add tag [id] --tag lang/go
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags    |
+      | [id] | NULL      | false     | https://go.dev | https://go.dev | NULL        | lang/go |
+
+  @cli @hierarchical_tags
+  Scenario: Namespaces can't be empty
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      add tag [id] --tag lang//go
+      """
+    Then the following error is returned:
+      """
+      Tag has an empty namespace
+      """
+
+  @cli @hierarchical_tags
+  Scenario: Tags can't start with a separator
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      add tag [id] --tag /go
+      """
+    Then the following error is returned:
+      """
+      Tag has an empty namespace
+      """
+
+  @cli @hierarchical_tags
+  Scenario: Can filter bookmarks by every tag underneath a namespace
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name                  | url                   | description | tags      |
+      | {id_1} | NULL      | false     | https://go.dev        | https://go.dev        | NULL        | lang/go   |
+      | {id_2} | NULL      | false     | https://rust-lang.org | https://rust-lang.org | NULL        | lang/rust |
+      | {id_3} | NULL      | false     | https://jho.pe        | https://jho.pe        | NULL        | lang      |
+    When I run it with the following args:
+      """
+      list books --tag lang/*
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name                  | url                   | description | tags      |
+      | [id_1] | NULL      | false     | https://go.dev        | https://go.dev        | NULL        | lang/go   |
+      | [id_2] | NULL      | false     | https://rust-lang.org | https://rust-lang.org | NULL        | lang/rust |
+
+  @cli @hierarchical_tags
+  Scenario: Can search by every tag underneath a namespace
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name                  | url                   | description | tags         |
+      | {id_1} | NULL      | false     | https://go.dev        | https://go.dev        | NULL        | lang/go      |
+      | {id_2} | NULL      | false     | https://armaria.net   | https://armaria.net   | NULL        | proj/armaria |
+    When I run it with the following args:
+      """
+      list books --query "tag:lang/*"
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url            | description | tags    |
+      | [id_1] | NULL      | false     | https://go.dev | https://go.dev | NULL        | lang/go |
+
+  @cli @hierarchical_tags
+  Scenario: Can show the hierarchy of tags
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name                  | url                   | description | tags                  |
+      | {id_1} | NULL      | false     | https://go.dev        | https://go.dev        | NULL        | lang/go, proj/armaria |
+      | {id_2} | NULL      | false     | https://rust-lang.org | https://rust-lang.org | NULL        | lang/rust             |
+      | {id_3} | NULL      | false     | https://jho.pe        | https://jho.pe        | NULL        | blog                  |
+    When I run it with the following args:
+      """
+      tag tree
+      """
+    Then the folllowing tag tree is returned:
+      """
+      blog (1)
+      lang
+        go (1)
+        rust (1)
+      proj
+        armaria (1)
+      """
+
+  @cli @hierarchical_tags
+  Scenario: Renaming a namespace renames every tag underneath it
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name                  | url                   | description | tags          |
+      | {id_1} | NULL      | false     | https://go.dev        | https://go.dev        | NULL        | lang, lang/go |
+      | {id_2} | NULL      | false     | https://rust-lang.org | https://rust-lang.org | NULL        | lang/rust     |
+    When I run it with the following args:
+      """
+      tag rename lang language
+      """
+    Then the folllowing tag counts are returned:
+      | tag      | count |
+      | language | 1     |
+    And the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name                  | url                   | description | tags                  |
+      | [id_1] | NULL      | false     | https://go.dev        | https://go.dev        | NULL        | language, language/go |
+      | [id_2] | NULL      | false     | https://rust-lang.org | https://rust-lang.org | NULL        | language/rust         |
+    And the folllowing tags exist:
+      | tag           |
+      | language      |
+      | language/go   |
+      | language/rust |
+
+  @cli @hierarchical_tags
+  Scenario: Can't rename a namespace onto existing tags
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags                 |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        | lang/go, language/go |
+    When I run it with the following args:
+      """
+      tag rename lang language
+      """
+    Then the following error is returned:
+      """
+      Tag already exists
+      """
+
+  @cli @hierarchical_tags
+  Scenario: Can undo renaming a namespace
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags    |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        | lang/go |
+    When I run it with the following args:
+      """
+      tag rename lang language
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags    |
+      | [id] | NULL      | false     | https://go.dev | https://go.dev | NULL        | lang/go |
+    And the folllowing tags exist:
+      | tag     |
+      | lang/go |
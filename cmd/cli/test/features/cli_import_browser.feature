@@ -0,0 +1,102 @@
+Feature: Import Browser Profiles with CLI
+
+  The Armaria CLI can be used to import bookmarks directly from a browser profile.
+
+  @cli @import_browser
+  Scenario: Can import bookmarks and folders from Chrome
+    Given the folder "profile" has the file "Bookmarks" with the following contents:
+      """
+      {
+        "roots": {
+          "bookmark_bar": {
+            "children": [
+              {
+                "children": [
+                  { "name": "The Flat Field", "type": "url", "url": "https://jho.pe" }
+                ],
+                "name": "blogs",
+                "type": "folder"
+              }
+            ],
+            "name": "Bookmarks bar",
+            "type": "folder"
+          },
+          "other": {
+            "children": [
+              { "name": "Armaria", "type": "url", "url": "https://armaria.net" }
+            ],
+            "name": "Other bookmarks",
+            "type": "folder"
+          },
+          "synced": {
+            "children": [],
+            "name": "Mobile bookmarks",
+            "type": "folder"
+          }
+        },
+        "version": 1
+      }
+      """
+    When I run it with the following args:
+      """
+      import chrome --profile [profile]
+      """
+    Then the folllowing books are returned:
+      | id        | parent_id | is_folder | name            | url                 | description | tags |
+      | {bar_id}  | NULL      | true      | Bookmarks bar   | NULL                | NULL        |      |
+      | {blog_id} | [bar_id]  | true      | blogs           | NULL                | NULL        |      |
+      | {id_1}    | [blog_id] | false     | The Flat Field  | https://jho.pe      | NULL        |      |
+      | {other}   | NULL      | true      | Other bookmarks | NULL                | NULL        |      |
+      | {id_2}    | [other]   | false     | Armaria         | https://armaria.net | NULL        |      |
+
+  @cli @import_browser
+  Scenario: Can import bookmarks from Chrome into a folder
+    Given the DB already has the following entries:
+      | id          | parent_id | is_folder | name   | url  | description | tags |
+      | {parent_id} | NULL      | true      | chrome | NULL | NULL        |      |
+    And the folder "profile" has the file "Bookmarks" with the following contents:
+      """
+      {
+        "roots": {
+          "bookmark_bar": {
+            "children": [
+              { "name": "The Flat Field", "type": "url", "url": "https://jho.pe" }
+            ],
+            "name": "Bookmarks bar",
+            "type": "folder"
+          }
+        },
+        "version": 1
+      }
+      """
+    When I run it with the following args:
+      """
+      import chrome --profile [profile] --folder [parent_id]
+      """
+    Then the following bookmarks/folders exist:
+      | id          | parent_id   | is_folder | name           | url            | description | tags |
+      | [parent_id] | NULL        | true      | chrome         | NULL           | NULL        |      |
+      | {bar_id}    | [parent_id] | true      | Bookmarks bar  | NULL           | NULL        |      |
+      | {id}        | [bar_id]    | false     | The Flat Field | https://jho.pe | NULL        |      |
+
+  @cli @import_browser
+  Scenario: Chrome profile must exist
+    When I run it with the following args:
+      """
+      import chrome --profile [uuid]
+      """
+    Then the following error is returned:
+      """
+      Browser profile not found
+      """
+
+  @cli @import_browser
+  Scenario: Firefox profile must exist
+    When I run it with the following args:
+      """
+      import firefox --profile [uuid]
+      """
+    Then the following error is returned:
+      """
+      Browser profile not found
+      """
@@ -0,0 +1,136 @@
+Feature: Import Netscape Bookmark Files with CLI
+
+  The Armaria CLI can be used to import bookmarks from a Netscape bookmark file.
+
+  @cli @import_html
+  Scenario: Can import bookmarks and folders
+    Given the file "bookmarks.html" has the following contents:
+      """
+      <!DOCTYPE NETSCAPE-Bookmark-file-1>
+      <META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+      <TITLE>Bookmarks</TITLE>
+      <H1>Bookmarks</H1>
+      <DL><p>
+          <DT><H3>blogs</H3>
+          <DL><p>
+              <DT><A HREF="https://jho.pe" TAGS="blog,programming">The Flat Field</A>
+              <DD>The blog of Jonathan Hope.
+          </DL><p>
+          <DT><A HREF="https://armaria.net">Armaria</A>
+      </DL><p>
+      """
+    When I run it with the following args:
+      """
+      import html [bookmarks.html]
+      """
+    Then the following bookmarks/folders exist:
+      | id          | parent_id   | is_folder | name           | url                 | description                | tags              |
+      | {parent_id} | NULL        | true      | blogs          | NULL                | NULL                       |                   |
+      | {id_1}      | [parent_id] | false     | The Flat Field | https://jho.pe      | The blog of Jonathan Hope. | blog, programming |
+      | {id_2}      | NULL        | false     | Armaria        | https://armaria.net | NULL                       |                   |
+    And the folllowing tags exist:
+      | tag         |
+      | blog        |
+      | programming |
+
+  @cli @import_html
+  Scenario: Invalid tags from other bookmark managers are skipped and folder names are made valid
+    Given the file "bookmarks.html" has the following contents:
+      """
+      <DL><p>
+          <DT><H3></H3>
+          <DL><p>
+              <DT><A HREF="https://nodejs.org" TAGS="node.js,web">Node.js</A>
+          </DL><p>
+          <DT><A HREF="https://jho.pe" TAGS="c++">The Flat Field</A>
+      </DL><p>
+      """
+    When I run it with the following args:
+      """
+      import html [bookmarks.html]
+      """
+    Then the following bookmarks/folders exist:
+      | id          | parent_id   | is_folder | name            | url                | description | tags |
+      | {parent_id} | NULL        | true      | Untitled folder | NULL               | NULL        |      |
+      | {id_1}      | [parent_id] | false     | Node.js         | https://nodejs.org | NULL        | web  |
+      | {id_2}      | NULL        | false     | The Flat Field  | https://jho.pe     | NULL        |      |
+
+  @cli @import_html
+  Scenario: Can import bookmarks into a folder
+    Given the DB already has the following entries:
+      | id          | parent_id | is_folder | name     | url  | description | tags |
+      | {parent_id} | NULL      | true      | imported | NULL | NULL        |      |
+    And the file "bookmarks.html" has the following contents:
+      """
+      <DL><p>
+          <DT><A HREF="https://jho.pe">https://jho.pe</A>
+      </DL><p>
+      """
+    When I run it with the following args:
+      """
+      import html [bookmarks.html] --folder [parent_id]
+      """
+    Then the following bookmarks/folders exist:
+      | id          | parent_id   | is_folder | name           | url            | description | tags |
+      | [parent_id] | NULL        | true      | imported       | NULL           | NULL        |      |
+      | {id}        | [parent_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+
+  @cli @import_html
+  Scenario: Nothing is imported if the file has an invalid bookmark
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    And the file "bookmarks.html" has the following contents:
+      """
+      <DL><p>
+          <DT><H3>blogs</H3>
+          <DL><p>
+              <DT><A HREF="https://armaria.net">Armaria</A>
+              <DT><A HREF="https://jho.pe">The Flat Field</A>
+              <DD>aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+          </DL><p>
+      </DL><p>
+      """
+    When I run it with the following args:
+      """
+      import html [bookmarks.html]
+      """
+    Then the following error is returned:
+      """
+      Description too long
+      """
+    And the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | [id] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+
+  @cli @import_html
+  Scenario: File must be a Netscape bookmark file
+    Given the file "bookmarks.html" has the following contents:
+      """
+      <html><body>Not bookmarks</body></html>
+      """
+    When I run it with the following args:
+      """
+      import html [bookmarks.html]
+      """
+    Then the following error is returned:
+      """
+      Invalid bookmark file
+      """
+
+  @cli @import_html
+  Scenario: Folder must exist
+    Given the file "bookmarks.html" has the following contents:
+      """
+      <DL><p>
+          <DT><A HREF="https://jho.pe">https://jho.pe</A>
+      </DL><p>
+      """
+    When I run it with the following args:
+      """
+      import html [bookmarks.html] --folder [uuid]
+      """
+    Then the following error is returned:
+      """
+      Folder not found
+      """
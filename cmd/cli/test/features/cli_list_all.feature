@@ -173,23 +173,84 @@ Feature: List All with CLI
       """
 
   @cli @list_all
-  Scenario: Query must be at leat three chars
+  Scenario: Cannot filter by folder and top level at same time
     When I run it with the following args:
       """
-      list all --query a
+      list all --folder [parent_id] --no-folder
       """
     Then the following error is returned:
       """
-    Query too short
+      Arguments folder and no-folder are mutually exclusive
       """
 
   @cli @list_all
-  Scenario: Cannot filter by folder and top level at same time
+  Scenario: Can list bookmarks/folders recursively
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url                 | description | tags |
+      | {work} | NULL      | true      | Work    | NULL                | NULL        |      |
+      | {docs} | [work]    | true      | Docs    | NULL                | NULL        |      |
+      | {id_1} | [docs]    | false     | Go Docs | https://go.dev/doc  | NULL        |      |
+      | {id_2} | [work]    | false     | Blog    | https://jho.pe      | NULL        |      |
+      | {id_3} | NULL      | false     | Armaria | https://armaria.net | NULL        |      |
     When I run it with the following args:
       """
-      list all --folder [parent_id] --no-folder
+      list all --folder [work] --recursive
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name    | url                | description | tags |
+      | [docs] | [work]    | true      | Docs    | NULL               | NULL        |      |
+      | [id_1] | [docs]    | false     | Go Docs | https://go.dev/doc | NULL        |      |
+      | [id_2] | [work]    | false     | Blog    | https://jho.pe     | NULL        |      |
+    And the folllowing paths are returned:
+      | depth | path                  |
+      | 1     | Work / Docs           |
+      | 2     | Work / Docs / Go Docs |
+      | 1     | Work / Blog           |
+
+  @cli @list_all
+  Scenario: Can limit the depth of a recursive listing
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url                | description | tags |
+      | {work} | NULL      | true      | Work    | NULL               | NULL        |      |
+      | {docs} | [work]    | true      | Docs    | NULL               | NULL        |      |
+      | {id_1} | [docs]    | false     | Go Docs | https://go.dev/doc | NULL        |      |
+    When I run it with the following args:
+      """
+      list all --depth 2
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url  | description | tags |
+      | [work] | NULL      | true      | Work | NULL | NULL        |      |
+      | [docs] | [work]    | true      | Docs | NULL | NULL        |      |
+    And the folllowing paths are returned:
+      | depth | path        |
+      | 1     | Work        |
+      | 2     | Work / Docs |
+
+  @cli @list_all
+  Scenario: Can search and paginate a recursive listing
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url                 | description | tags |
+      | {work} | NULL      | true      | Work    | NULL                | NULL        |      |
+      | {docs} | [work]    | true      | Docs    | NULL                | NULL        |      |
+      | {id_1} | [docs]    | false     | Go Docs | https://go.dev/doc  | NULL        | go   |
+      | {id_2} | [docs]    | false     | Go Blog | https://go.dev/blog | NULL        | go   |
+      | {id_3} | [work]    | false     | Go Tour | https://go.dev/tour | NULL        | go   |
+    When I run it with the following args:
+      """
+      list all --folder [work] --recursive --tag go --after [id_1] --first 1
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name    | url                 | description | tags |
+      | [id_2] | [docs]    | false     | Go Blog | https://go.dev/blog | NULL        | go   |
+
+  @cli @list_all
+  Scenario: Depth must be greater than zero
+    When I run it with the following args:
+      """
+      list all --depth 0
       """
     Then the following error is returned:
       """
-      Arguments folder and no-folder are mutually exclusive
+      Depth too small
       """
@@ -173,25 +173,60 @@ Feature: List Books with CLI
       | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
 
   @cli @list_books
-  Scenario: First must be greater than zero
+  Scenario: Can filter bookmarks by all tags
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name                | url                 | description | tags              |
+      | {id_1} | NULL      | false     | https://jho.pe      | https://jho.pe      | NULL        | blog, programming |
+      | {id_2} | NULL      | false     | https://armaria.net | https://armaria.net | NULL        | blog              |
     When I run it with the following args:
       """
-      list books --first 0
+      list books --tag blog --tag programming --tag-mode all
       """
-    Then the following error is returned:
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url            | description | tags              |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog, programming |
+
+  @cli @list_books
+  Scenario: Can filter bookmarks by any tag
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name                | url                 | description | tags |
+      | {id_1} | NULL      | false     | https://jho.pe      | https://jho.pe      | NULL        | blog |
+      | {id_2} | NULL      | false     | https://armaria.net | https://armaria.net | NULL        | tool |
+      | {id_3} | NULL      | false     | https://go.dev      | https://go.dev      | NULL        |      |
+    When I run it with the following args:
       """
-      First too small
+      list books --tag blog --tag tool --tag-mode any
       """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name                | url                 | description | tags |
+      | [id_1] | NULL      | false     | https://jho.pe      | https://jho.pe      | NULL        | blog |
+      | [id_2] | NULL      | false     | https://armaria.net | https://armaria.net | NULL        | tool |
 
   @cli @list_books
-  Scenario: Query must be at leat three chars
+  Scenario: Can exclude bookmarks by tag
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name                | url                 | description | tags           |
+      | {id_1} | NULL      | false     | https://jho.pe      | https://jho.pe      | NULL        | blog           |
+      | {id_2} | NULL      | false     | https://armaria.net | https://armaria.net | NULL        | blog, archived |
+      | {id_3} | NULL      | false     | https://go.dev      | https://go.dev      | NULL        |                |
+    When I run it with the following args:
+      """
+      list books --not-tag archived
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | [id_3] | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+
+  @cli @list_books
+  Scenario: First must be greater than zero
     When I run it with the following args:
       """
-      list books --query a
+      list books --first 0
       """
     Then the following error is returned:
       """
-      Query too short
+      First too small
       """
 
   @cli @list_books
@@ -168,17 +168,6 @@ Feature: List Folder with CLI
       First too small
       """
 
-  @cli @list_folders
-  Scenario: Query must be at leat three chars
-    When I run it with the following args:
-      """
-      list folders --query a
-      """
-    Then the following error is returned:
-      """
-      Query too short
-      """
-
   @cli @list_folders
   Scenario: Cannot filter by folder and top level at same time
     When I run it with the following args:
@@ -0,0 +1,211 @@
+Feature: Manage Tags with CLI
+
+  The Armaria CLI can be used to rename, merge, and delete tags on every bookmark.
+
+  @cli @manage_tags
+  Scenario: Can list tag counts
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags              |
+      | {id_1} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog, programming |
+      | {id_2} | NULL      | false     | https://go.dev | https://go.dev | NULL        | programming       |
+    When I run it with the following args:
+      """
+      list tags --counts
+      """
+    Then the folllowing tag counts are returned:
+      | tag         | count |
+      | blog        | 1     |
+      | programming | 2     |
+
+  @cli @manage_tags
+  Scenario: Tag counts don't include bookmarks in the trash
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags              |
+      | {id_1} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog, programming |
+      | {id_2} | NULL      | false     | https://go.dev | https://go.dev | NULL        | programming       |
+    When I run it with the following args:
+      """
+      remove book [id_2]
+      """
+    And I run it with the following args:
+      """
+      list tags --counts
+      """
+    Then the folllowing tag counts are returned:
+      | tag         | count |
+      | blog        | 1     |
+      | programming | 1     |
+
+  @cli @manage_tags
+  Scenario: Can rename a tag
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags              |
+      | {id_1} | NULL      | false     | https://go.dev | https://go.dev | NULL        | golang            |
+      | {id_2} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog, golang      |
+    When I run it with the following args:
+      """
+      tag rename golang go
+      """
+    Then the folllowing tag counts are returned:
+      | tag | count |
+      | go  | 2     |
+    And the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags     |
+      | [id_1] | NULL      | false     | https://go.dev | https://go.dev | NULL        | go       |
+      | [id_2] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog, go |
+    And the folllowing tags exist:
+      | tag  |
+      | blog |
+      | go   |
+
+  @cli @manage_tags
+  Scenario: Can't rename a tag to an existing tag
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags       |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        | go, golang |
+    When I run it with the following args:
+      """
+      tag rename golang go
+      """
+    Then the following error is returned:
+      """
+      Tag already exists
+      """
+
+  @cli @manage_tags
+  Scenario: Tag to rename must exist
+    When I run it with the following args:
+      """
+      tag rename golang go
+      """
+    Then the following error is returned:
+      """
+      Tag not found
+      """
+
+  @cli @manage_tags
+  Scenario: Tag to rename can't have wildcards
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags       |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        | go, golang |
+    When I run it with the following args:
+      """
+      tag rename go* lang
+      """
+    Then the following error is returned:
+      """
+      Tag has invalid chars
+      """
+    And the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags       |
+      | [id] | NULL      | false     | https://go.dev | https://go.dev | NULL        | go, golang |
+
+  @cli @manage_tags
+  Scenario: Can merge tags
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name               | url                | description | tags        |
+      | {id_1} | NULL      | false     | https://go.dev     | https://go.dev     | NULL        | go, golang  |
+      | {id_2} | NULL      | false     | https://pkg.go.dev | https://pkg.go.dev | NULL        | go-lang     |
+      | {id_3} | NULL      | false     | https://jho.pe     | https://jho.pe     | NULL        | blog        |
+    When I run it with the following args:
+      """
+      tag merge golang go-lang --into go
+      """
+    Then the folllowing tag counts are returned:
+      | tag | count |
+      | go  | 2     |
+    And the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name               | url                | description | tags |
+      | [id_1] | NULL      | false     | https://go.dev     | https://go.dev     | NULL        | go   |
+      | [id_2] | NULL      | false     | https://pkg.go.dev | https://pkg.go.dev | NULL        | go   |
+      | [id_3] | NULL      | false     | https://jho.pe     | https://jho.pe     | NULL        | blog |
+    And the folllowing tags exist:
+      | tag  |
+      | blog |
+      | go   |
+
+  @cli @manage_tags
+  Scenario: Tags to merge must exist
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        | go   |
+    When I run it with the following args:
+      """
+      tag merge golang --into go
+      """
+    Then the following error is returned:
+      """
+      Tag not found
+      """
+
+  @cli @manage_tags
+  Scenario: Tags to merge can't have wildcards
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags       |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        | go, golang |
+    When I run it with the following args:
+      """
+      tag merge go? --into go
+      """
+    Then the following error is returned:
+      """
+      Tag has invalid chars
+      """
+    And the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags       |
+      | [id] | NULL      | false     | https://go.dev | https://go.dev | NULL        | go, golang |
+
+  @cli @manage_tags
+  Scenario: Can delete a tag
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags              |
+      | {id_1} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog, programming |
+      | {id_2} | NULL      | false     | https://go.dev | https://go.dev | NULL        | programming       |
+    When I run it with the following args:
+      """
+      tag delete programming
+      """
+    Then the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | [id_2] | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+    And the folllowing tags exist:
+      | tag  |
+      | blog |
+
+  @cli @manage_tags
+  Scenario: Tag to delete can't have wildcards
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags       |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        | go, golang |
+    When I run it with the following args:
+      """
+      tag delete go[a-z]*
+      """
+    Then the following error is returned:
+      """
+      Tag has invalid chars
+      """
+    And the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags       |
+      | [id] | NULL      | false     | https://go.dev | https://go.dev | NULL        | go, golang |
+
+  @cli @manage_tags
+  Scenario: Can undo renaming a tag
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags   |
+      | {id} | NULL      | false     | https://go.dev | https://go.dev | NULL        | golang |
+    When I run it with the following args:
+      """
+      tag rename golang go
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags   |
+      | [id] | NULL      | false     | https://go.dev | https://go.dev | NULL        | golang |
+    And the folllowing tags exist:
+      | tag    |
+      | golang |
@@ -29,3 +29,127 @@ Feature: Query with CLI
     Then the folllowing books are returned:
       | id            | parent_id | is_folder | name           | url            | description | tags |
       | [parent_1_id] | NULL      | true      | blogs          | NULL           | NULL        |      |
+
+  @cli @query
+  Scenario: Query results are ranked by relevance
+    Given the DB already has the following entries:
+      | id            | parent_id | is_folder | name           | url                 | description       | tags |
+      | {id_1}        | NULL      | false     | Armaria        | https://armaria.net | Not a flat field. |      |
+      | {id_2}        | NULL      | false     | The Flat Field | https://jho.pe      | NULL              |      |
+    When I run it with the following args:
+      """
+      query flat
+      """
+    Then the folllowing books are returned:
+      | id            | parent_id | is_folder | name           | url                 | description       | tags |
+      | [id_2]        | NULL      | false     | The Flat Field | https://jho.pe      | NULL              |      |
+      | [id_1]        | NULL      | false     | Armaria        | https://armaria.net | Not a flat field. |      |
+
+  @cli @query
+  Scenario: Can query with terms shorter than 3 chars
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name       | url                   | description | tags |
+      | {id_1} | NULL      | false     | Go tools   | https://go.dev/tools  | NULL        |      |
+      | {id_2} | NULL      | false     | Rust tools | https://rust-lang.org | NULL        |      |
+    When I run it with the following args:
+      """
+      query "go tools"
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name       | url                   | description | tags |
+      | [id_1] | NULL      | false     | Go tools   | https://go.dev/tools  | NULL        |      |
+
+  @cli @query
+  Scenario: Can query with a single term shorter than 3 chars
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name       | url                   | description | tags |
+      | {id_1} | NULL      | false     | Go tools   | https://go.dev/tools  | NULL        |      |
+      | {id_2} | NULL      | false     | Rust tools | https://rust-lang.org | NULL        |      |
+    When I run it with the following args:
+      """
+      query go
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name       | url                   | description | tags |
+      | [id_1] | NULL      | false     | Go tools   | https://go.dev/tools  | NULL        |      |
+
+  @cli @query
+  Scenario: Can list with terms shorter than 3 chars
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name       | url                   | description | tags |
+      | {id_1} | NULL      | false     | Go tools   | https://go.dev/tools  | NULL        |      |
+      | {id_2} | NULL      | false     | Rust tools | https://rust-lang.org | NULL        |      |
+    When I run it with the following args:
+      """
+      list all --query "go tools"
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name       | url                   | description | tags |
+      | [id_1] | NULL      | false     | Go tools   | https://go.dev/tools  | NULL        |      |
+
+  @cli @query
+  Scenario: Can query with phrases and exclusions
+    Given the DB already has the following entries:
+      | id            | parent_id | is_folder | name           | url                 | description       | tags |
+      | {id_1}        | NULL      | false     | Armaria        | https://armaria.net | Not a flat field. |      |
+      | {id_2}        | NULL      | false     | The Flat Field | https://jho.pe      | NULL              |      |
+    When I run it with the following args:
+      """
+      query '"flat field" -armaria'
+      """
+    Then the folllowing books are returned:
+      | id            | parent_id | is_folder | name           | url                 | description       | tags |
+      | [id_2]        | NULL      | false     | The Flat Field | https://jho.pe      | NULL              |      |
+
+  @cli @query
+  Scenario: Invalid queries are rejected
+    When I run it with the following args:
+      """
+      query 'flat OR'
+      """
+    Then the following error is returned:
+      """
+      Invalid query: unexpected end of query at position 8
+      """
+
+  @cli @query
+  Scenario: Can query with tags and folders
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url                 | description | tags          |
+      | {work} | NULL      | true      | Work    | NULL                | NULL        |               |
+      | {docs} | [work]    | true      | Docs    | NULL                | NULL        |               |
+      | {id_1} | [docs]    | false     | Go Docs | https://go.dev/doc  | NULL        | go, docs      |
+      | {id_2} | [docs]    | false     | Go Blog | https://go.dev/blog | NULL        | go, archived  |
+      | {id_3} | NULL      | false     | Go Tour | https://go.dev/tour | NULL        | go            |
+    When I run it with the following args:
+      """
+      query 'tag:go tag:-archived folder:"work/docs"'
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name    | url                 | description | tags          |
+      | [id_1] | [docs]    | false     | Go Docs | https://go.dev/doc  | NULL        | docs, go      |
+
+  @cli @query
+  Scenario: Can query by field
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url                | description        | tags |
+      | {id_1} | NULL      | false     | Go Docs | https://go.dev/doc | NULL               |      |
+      | {id_2} | NULL      | false     | Blog    | https://jho.pe     | All about Go docs. |      |
+    When I run it with the following args:
+      """
+      query '(name:docs OR url:jho.pe) modified:>2000-01-01 -description:about'
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name    | url                | description | tags |
+      | [id_1] | NULL      | false     | Go Docs | https://go.dev/doc | NULL        |      |
+
+  @cli @query
+  Scenario: Query parse errors include the position
+    When I run it with the following args:
+      """
+      query 'tag:go (docs'
+      """
+    Then the following error is returned:
+      """
+      Invalid query: missing ")" at position 8
+      """
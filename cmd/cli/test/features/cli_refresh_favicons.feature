@@ -0,0 +1,110 @@
+Feature: Refresh Favicons with CLI
+
+  @cli @favicons
+  Scenario: Can fetch the icon a page declares
+    Given a web server is running with the following pages:
+      | url    | path      | status | location | body                               |
+      | {blog} | /blog     | 200    |          | <link rel="icon" href="/icon.gif"> |
+      | {icon} | /icon.gif | 200    |          | GIF89a                             |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | {id_1} | NULL      | false     | Blog | [blog] | NULL        |      |
+    When I run it with the following args:
+      """
+      refresh favicons
+      """
+    Then the folllowing favicons are returned:
+      | id     | url    | content_type | hash   |
+      | [id_1] | [icon] | image/gif    | {hash} |
+
+  @cli @favicons
+  Scenario: Falls back to favicon.ico
+    Given a web server is running with the following pages:
+      | url    | path         | status | location | body                |
+      | {blog} | /blog        | 200    |          | <title>Blog</title> |
+      | {icon} | /favicon.ico | 200    |          | GIF89a              |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | {id_1} | NULL      | false     | Blog | [blog] | NULL        |      |
+    When I run it with the following args:
+      """
+      refresh favicons
+      """
+    Then the folllowing favicons are returned:
+      | id     | url    | content_type | hash   |
+      | [id_1] | [icon] | image/gif    | {hash} |
+
+  @cli @favicons
+  Scenario: Bookmarks on the same site share an icon
+    Given a web server is running with the following pages:
+      | url     | path         | status | location | body                |
+      | {post1} | /post1       | 200    |          | <title>One</title>  |
+      | {post2} | /post2       | 200    |          | <title>Two</title>  |
+      | {icon}  | /favicon.ico | 200    |          | GIF89a              |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url     | description | tags |
+      | {id_1} | NULL      | false     | One  | [post1] | NULL        |      |
+      | {id_2} | NULL      | false     | Two  | [post2] | NULL        |      |
+    When I run it with the following args:
+      """
+      refresh favicons
+      """
+    Then the folllowing favicons are returned:
+      | id     | url    | content_type | hash   |
+      | [id_1] | [icon] | image/gif    | {hash} |
+      | [id_2] | [icon] | image/gif    | [hash] |
+
+  @cli @favicons
+  Scenario: Only bookmarks without an icon are refreshed
+    Given a web server is running with the following pages:
+      | url    | path         | status | location | body                |
+      | {blog} | /blog        | 200    |          | <title>Blog</title> |
+      | {icon} | /favicon.ico | 200    |          | GIF89a              |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | {id_1} | NULL      | false     | Blog | [blog] | NULL        |      |
+    When I run it with the following args:
+      """
+      refresh favicons
+      """
+    And I run it with the following args:
+      """
+      refresh favicons
+      """
+    Then the folllowing favicons are returned:
+      | id | url | content_type | hash |
+
+  @cli @favicons
+  Scenario: Can refresh the icons of bookmarks in a folder
+    Given a web server is running with the following pages:
+      | url    | path         | status | location | body                |
+      | {blog} | /blog        | 200    |          | <title>Blog</title> |
+      | {news} | /news        | 200    |          | <title>News</title> |
+      | {icon} | /favicon.ico | 200    |          | GIF89a              |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | {work} | NULL      | true      | Work | NULL   | NULL        |      |
+      | {id_1} | [work]    | false     | Blog | [blog] | NULL        |      |
+      | {id_2} | NULL      | false     | News | [news] | NULL        |      |
+    When I run it with the following args:
+      """
+      refresh favicons --folder [work]
+      """
+    Then the folllowing favicons are returned:
+      | id     | url    | content_type | hash   |
+      | [id_1] | [icon] | image/gif    | {hash} |
+
+  @cli @favicons
+  Scenario: Pages without an icon are skipped
+    Given a web server is running with the following pages:
+      | url    | path  | status | location | body                |
+      | {blog} | /blog | 200    |          | <title>Blog</title> |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url    | description | tags |
+      | {id_1} | NULL      | false     | Blog | [blog] | NULL        |      |
+    When I run it with the following args:
+      """
+      refresh favicons
+      """
+    Then the folllowing favicons are returned:
+      | id | url | content_type | hash |
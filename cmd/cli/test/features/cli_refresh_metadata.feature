@@ -0,0 +1,93 @@
+Feature: Fetch Metadata with CLI
+
+  @cli @metadata
+  Scenario: Can fill in a bookmark's name and description when adding it
+    Given a web server is running with the following pages:
+      | url    | path  | status | location | body                                                                                 |
+      | {blog} | /blog | 200    |          | <title>The Flat Field</title><meta name="description" content="A blog about code."> |
+    When I run it with the following args:
+      """
+      add book [blog] --fetch-metadata
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url    | description        | tags |
+      | {id} | NULL      | false     | The Flat Field | [blog] | A blog about code. |      |
+
+  @cli @metadata
+  Scenario: Provided names and descriptions are kept when adding a bookmark
+    Given a web server is running with the following pages:
+      | url    | path  | status | location | body                                                                                 |
+      | {blog} | /blog | 200    |          | <title>The Flat Field</title><meta name="description" content="A blog about code."> |
+    When I run it with the following args:
+      """
+      add book [blog] --fetch-metadata --name "Blog" --description "Mine"
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name | url    | description | tags |
+      | {id} | NULL      | false     | Blog | [blog] | Mine        |      |
+
+  @cli @metadata
+  Scenario: Can use the canonical URL when adding a bookmark
+    Given a web server is running with the following pages:
+      | url         | path       | status | location | body                                                           |
+      | {blog}      | /blog      | 200    |          | <title>Blog</title><link rel="canonical" href="/canonical"> |
+      | {canonical} | /canonical | 200    |          |                                                                |
+    When I run it with the following args:
+      """
+      add book [blog] --fetch-metadata --canonical
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name | url         | description | tags |
+      | {id} | NULL      | false     | Blog | [canonical] | NULL        |      |
+
+  @cli @metadata
+  Scenario: A bookmark is still added when its page can't be fetched
+    Given a web server is running with the following pages:
+      | url       | path     | status | location |
+      | {missing} | /missing | 404    |          |
+    When I run it with the following args:
+      """
+      add book [missing] --fetch-metadata
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name      | url       | description | tags |
+      | {id} | NULL      | false     | [missing] | [missing] | NULL        |      |
+
+  @cli @metadata
+  Scenario: Can refresh the metadata of existing bookmarks
+    Given a web server is running with the following pages:
+      | url     | path   | status | location | body                                                                        |
+      | {blog}  | /blog  | 200    |          | <title>The Flat Field</title><meta property="og:description" content="Code"> |
+      | {named} | /named | 200    |          | <title>Named</title>                                                        |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name   | url     | description | tags |
+      | {id_1} | NULL      | false     | [blog] | [blog]  | NULL        |      |
+      | {id_2} | NULL      | false     | Mine   | [named] | NULL        |      |
+    When I run it with the following args:
+      """
+      refresh metadata
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name           | url    | description | tags |
+      | [id_1] | NULL      | false     | The Flat Field | [blog] | Code        |      |
+
+  @cli @metadata
+  Scenario: Can overwrite the metadata of bookmarks in a folder
+    Given a web server is running with the following pages:
+      | url     | path   | status | location | body                 |
+      | {blog}  | /blog  | 200    |          | <title>Blog</title>  |
+      | {named} | /named | 200    |          | <title>Named</title> |
+    And the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url     | description | tags |
+      | {work} | NULL      | true      | Work | NULL    | NULL        |      |
+      | {id_1} | [work]    | false     | Mine | [blog]  | NULL        |      |
+      | {id_2} | NULL      | false     | Mine | [named] | NULL        |      |
+    When I run it with the following args:
+      """
+      refresh metadata --folder [work] --overwrite
+      """
+    Then the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name | url     | description | tags |
+      | [work] | NULL      | true      | Work | NULL    | NULL        |      |
+      | [id_1] | [work]    | false     | Blog | [blog]  | NULL        |      |
+      | [id_2] | NULL      | false     | Mine | [named] | NULL        |      |
@@ -0,0 +1,127 @@
+Feature: Created and Modified Timestamps with CLI
+
+  @cli @timestamps
+  Scenario: Can list bookmarks/folders modified after a time
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url             | description | tags | created             | modified            |
+      | {id_1} | NULL      | false     | Old  | https://old.com | NULL        |      | 2024-01-01 00:00:00 | 2024-01-01 00:00:00 |
+      | {id_2} | NULL      | false     | New  | https://new.com | NULL        |      | 2024-01-01 00:00:00 | 2024-03-01 12:00:00 |
+    When I run it with the following args:
+      """
+      list all --modified-after 2024-02-01
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url             | description | tags |
+      | [id_2] | NULL      | false     | New  | https://new.com | NULL        |      |
+
+  @cli @timestamps
+  Scenario: Can list bookmarks/folders created before a time
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url             | description | tags | created             | modified            |
+      | {id_1} | NULL      | false     | Old  | https://old.com | NULL        |      | 2024-01-01 00:00:00 | 2024-03-01 00:00:00 |
+      | {id_2} | NULL      | false     | New  | https://new.com | NULL        |      | 2024-03-01 12:00:00 | 2024-03-01 12:00:00 |
+    When I run it with the following args:
+      """
+      list books --created-before "2024-03-01 12:00:00"
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url             | description | tags |
+      | [id_1] | NULL      | false     | Old  | https://old.com | NULL        |      |
+
+  @cli @timestamps
+  Scenario: Updating a bookmark modifies it
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url             | description | tags | created             | modified            |
+      | {id_1} | NULL      | false     | Old  | https://old.com | NULL        |      | 2024-01-01 00:00:00 | 2024-01-01 00:00:00 |
+      | {id_2} | NULL      | false     | New  | https://new.com | NULL        |      | 2024-01-01 00:00:00 | 2024-01-01 00:00:00 |
+    When I run it with the following args:
+      """
+      update book [id_1] --name "Older"
+      """
+    And I run it with the following args:
+      """
+      list books --modified-after 2024-02-01
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name  | url             | description | tags |
+      | [id_1] | NULL      | false     | Older | https://old.com | NULL        |      |
+
+  @cli @timestamps
+  Scenario: Moving a folder modifies it
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url  | description | tags | created             | modified            |
+      | {id_1} | NULL      | true      | Work | NULL | NULL        |      | 2024-01-01 00:00:00 | 2024-01-01 00:00:00 |
+      | {id_2} | NULL      | true      | Docs | NULL | NULL        |      | 2024-01-01 00:00:00 | 2024-01-01 00:00:00 |
+    When I run it with the following args:
+      """
+      update folder [id_2] --folder [id_1]
+      """
+    And I run it with the following args:
+      """
+      list folders --recursive --modified-after 2024-02-01
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url  | description | tags |
+      | [id_2] | [id_1]    | true      | Docs | NULL | NULL        |      |
+
+  @cli @timestamps
+  Scenario: Changing tags modifies a bookmark
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url             | description | tags | created             | modified            |
+      | {id_1} | NULL      | false     | Old  | https://old.com | NULL        | blog | 2024-01-01 00:00:00 | 2024-01-01 00:00:00 |
+      | {id_2} | NULL      | false     | New  | https://new.com | NULL        | blog | 2024-01-01 00:00:00 | 2024-01-01 00:00:00 |
+    When I run it with the following args:
+      """
+      remove tag [id_2] --tag blog
+      """
+    And I run it with the following args:
+      """
+      list books --modified-after 2024-02-01
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url             | description | tags |
+      | [id_2] | NULL      | false     | New  | https://new.com | NULL        |      |
+
+  @cli @timestamps
+  Scenario: Can order bookmarks by when they were modified
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url             | description | tags | created             | modified            |
+      | {id_1} | NULL      | false     | Old  | https://old.com | NULL        |      | 2024-01-01 00:00:00 | 2024-01-01 00:00:00 |
+      | {id_2} | NULL      | false     | New  | https://new.com | NULL        |      | 2024-01-02 00:00:00 | 2024-01-02 00:00:00 |
+    When I run it with the following args:
+      """
+      update book [id_1] --description "Still around"
+      """
+    And I run it with the following args:
+      """
+      list books --order modified
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url             | description  | tags |
+      | [id_2] | NULL      | false     | New  | https://new.com | NULL         |      |
+      | [id_1] | NULL      | false     | Old  | https://old.com | Still around |      |
+
+  @cli @timestamps
+  Scenario: Can search by when bookmarks were created
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name | url             | description | tags | created             | modified            |
+      | {id_1} | NULL      | false     | Old  | https://old.com | NULL        |      | 2024-01-01 00:00:00 | 2024-05-01 00:00:00 |
+      | {id_2} | NULL      | false     | New  | https://new.com | NULL        |      | 2024-03-01 00:00:00 | 2024-03-01 00:00:00 |
+    When I run it with the following args:
+      """
+      list books --query "created:<2024-02-01"
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id | is_folder | name | url             | description | tags |
+      | [id_1] | NULL      | false     | Old  | https://old.com | NULL        |      |
+
+  @cli @timestamps
+  Scenario: Times must be valid
+    When I run it with the following args:
+      """
+      list all --modified-after yesterday
+      """
+    Then the following error is returned:
+      """
+      Times must be formatted as YYYY-MM-DD or YYYY-MM-DD HH:MM:SS
+      """
@@ -0,0 +1,252 @@
+Feature: Trash with CLI
+
+  The Armaria CLI can be used to list, restore, and purge removed bookmarks and folders.
+
+  @cli @trash
+  Scenario: Can list the trash
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id}          | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+    When I run it with the following args:
+      """
+      remove book [id]
+      """
+    And I run it with the following args:
+      """
+      trash list
+      """
+    Then the folllowing books are returned:
+      | id   | parent_id     | is_folder | name           | url            | description | tags |
+      | [id] | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+
+  @cli @trash
+  Scenario: Removed tags are hidden
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags              |
+      | {id_1} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog, programming |
+      | {id_2} | NULL      | false     | https://go.dev | https://go.dev | NULL        | programming       |
+    When I run it with the following args:
+      """
+      remove book [id_1]
+      """
+    And I run it with the following args:
+      """
+      list tags
+      """
+    Then the folllowing tags are returned:
+      | tag         |
+      | programming |
+
+  @cli @trash
+  Scenario: Can restore a bookmark
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id_1}        | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | {id_2}        | [parent_1_id] | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      remove book [id_1]
+      """
+    And I run it with the following args:
+      """
+      trash restore [id_1]
+      """
+    Then the following bookmarks/folders exist:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | [parent_1_id] | NULL          | true      | blogs          | NULL           | NULL        |      |
+      | [id_1]        | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | [id_2]        | [parent_1_id] | false     | https://go.dev | https://go.dev | NULL        |      |
+    And the folllowing tags exist:
+      | tag  |
+      | blog |
+
+  @cli @trash
+  Scenario: Can restore a folder
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | tech           |                | NULL        |      |
+      | {parent_2_id} | [parent_1_id] | true      | blogs          |                | NULL        |      |
+      | {id}          | [parent_2_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+    When I run it with the following args:
+      """
+      remove folder [parent_1_id]
+      """
+    And I run it with the following args:
+      """
+      trash restore [parent_1_id]
+      """
+    Then the following bookmarks/folders exist:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | [parent_1_id] | NULL          | true      | tech           | NULL           | NULL        |      |
+      | [parent_2_id] | [parent_1_id] | true      | blogs          | NULL           | NULL        |      |
+      | [id]          | [parent_2_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+
+  @cli @trash
+  Scenario: Restoring a folder leaves what was removed before it in the trash
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id_1}        | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | {id_2}        | [parent_1_id] | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      remove book [id_1]
+      """
+    And I run it with the following args:
+      """
+      remove folder [parent_1_id]
+      """
+    And I run it with the following args:
+      """
+      trash restore [parent_1_id]
+      """
+    Then the following bookmarks/folders exist:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | [parent_1_id] | NULL          | true      | blogs          | NULL           | NULL        |      |
+      | [id_2]        | [parent_1_id] | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      trash list
+      """
+    Then the folllowing books are returned:
+      | id     | parent_id     | is_folder | name           | url            | description | tags |
+      | [id_1] | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+
+  @cli @trash
+  Scenario: Restoring a bookmark whose folder is in the trash moves it to the top level
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id_1}        | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+      | {id_2}        | NULL          | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      remove folder [parent_1_id]
+      """
+    And I run it with the following args:
+      """
+      trash restore [id_1]
+      """
+    Then the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_2] | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+
+  @cli @trash
+  Scenario: Restoring a bookmark whose folder was purged moves it to the top level
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id}          | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      remove book [id]
+      """
+    And I run it with the following args:
+      """
+      remove folder [parent_1_id]
+      """
+    And I run it with the following args:
+      """
+      trash empty --older-than 24h
+      """
+    And I run it with the following args:
+      """
+      trash restore [id]
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | [id] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+
+  @cli @trash
+  Scenario: Must be in the trash to restore
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      trash restore [id]
+      """
+    Then the following error is returned:
+      """
+      Not in trash
+      """
+
+  @cli @trash
+  Scenario: Can empty the trash
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | blogs          |                | NULL        |      |
+      | {id_1}        | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+      | {id_2}        | NULL          | false     | https://go.dev | https://go.dev | NULL        |      |
+    When I run it with the following args:
+      """
+      remove folder [parent_1_id]
+      """
+    And I run it with the following args:
+      """
+      trash empty
+      """
+    And I run it with the following args:
+      """
+      trash list
+      """
+    Then the folllowing books are returned:
+      | id | parent_id | is_folder | name | url | description | tags |
+    And the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_2] | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+
+  @cli @trash
+  Scenario: Emptying the trash keeps recently removed items
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+    When I run it with the following args:
+      """
+      remove book [id]
+      """
+    And I run it with the following args:
+      """
+      trash empty --older-than 720h
+      """
+    And I run it with the following args:
+      """
+      trash list
+      """
+    Then the folllowing books are returned:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | [id] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+
+  @cli @trash
+  Scenario: Older than must be positive
+    When I run it with the following args:
+      """
+      trash empty --older-than=-1h
+      """
+    Then the following error is returned:
+      """
+      Older than too small
+      """
+
+  @cli @trash
+  Scenario: Can undo restoring a bookmark
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      remove book [id]
+      """
+    And I run it with the following args:
+      """
+      trash restore [id]
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id | parent_id | is_folder | name | url | description | tags |
@@ -0,0 +1,62 @@
+Feature: Show Tree with CLI
+
+  Background:
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name    | url                 | description | tags |
+      | {work} | NULL      | true      | Work    | NULL                | NULL        |      |
+      | {docs} | [work]    | true      | Docs    | NULL                | NULL        |      |
+      | {id_1} | [docs]    | false     | Go Docs | https://go.dev/doc  | NULL        | go   |
+      | {id_2} | [work]    | false     | Blog    | https://jho.pe      | NULL        |      |
+      | {id_3} | NULL      | false     | Go Tour | https://go.dev/tour | NULL        | go   |
+
+  @cli @tree
+  Scenario: Can show the whole hierarchy
+    When I run it with the following args:
+      """
+      tree
+      """
+    Then the folllowing tree is returned:
+      """
+      Work
+        Docs
+          Go Docs
+        Blog
+      Go Tour
+      """
+
+  @cli @tree
+  Scenario: Can show the hierarchy of a folder
+    When I run it with the following args:
+      """
+      tree --folder [docs]
+      """
+    Then the folllowing tree is returned:
+      """
+      Docs
+        Go Docs
+      """
+
+  @cli @tree
+  Scenario: Can limit the depth of the hierarchy
+    When I run it with the following args:
+      """
+      tree --depth 2
+      """
+    Then the folllowing tree is returned:
+      """
+      Work
+        Docs
+        Blog
+      Go Tour
+      """
+
+  @cli @tree
+  Scenario: Folder must be a folder
+    When I run it with the following args:
+      """
+      tree --folder [id_1]
+      """
+    Then the following error is returned:
+      """
+      Folder not found
+      """
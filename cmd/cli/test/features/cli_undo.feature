@@ -0,0 +1,238 @@
+Feature: Undo and Redo with CLI
+
+  The Armaria CLI can be used to undo and redo changes to bookmarks and folders.
+
+  @cli @undo
+  Scenario: Can undo removing a folder
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags              |
+      | {parent_1_id} | NULL          | true      | tech           |                | NULL        |                   |
+      | {parent_2_id} | [parent_1_id] | true      | blogs          |                | NULL        |                   |
+      | {id}          | [parent_2_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog, programming |
+    When I run it with the following args:
+      """
+      remove folder [parent_1_id]
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id            | parent_id     | is_folder | name           | url            | description | tags              |
+      | [parent_1_id] | NULL          | true      | tech           | NULL           | NULL        |                   |
+      | [parent_2_id] | [parent_1_id] | true      | blogs          | NULL           | NULL        |                   |
+      | [id]          | [parent_2_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog, programming |
+    And the folllowing tags exist:
+      | tag         |
+      | blog        |
+      | programming |
+
+  @cli @undo
+  Scenario: Can redo removing a folder
+    Given the DB already has the following entries:
+      | id            | parent_id     | is_folder | name           | url            | description | tags |
+      | {parent_1_id} | NULL          | true      | tech           |                | NULL        |      |
+      | {id}          | [parent_1_id] | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+    When I run it with the following args:
+      """
+      remove folder [parent_1_id]
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    And I run it with the following args:
+      """
+      redo
+      """
+    Then the following bookmarks/folders exist:
+      | id | parent_id | is_folder | name | url | description | tags |
+    And the folllowing tags exist:
+      | tag |
+
+  @cli @undo
+  Scenario: Can undo updating a bookmark
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      update book [id] --name blog --url https://jho.pe/blog --description "My blog"
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | [id] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+
+  @cli @undo
+  Scenario: Can undo adding tags
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+    When I run it with the following args:
+      """
+      add tag [id] --tag programming
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | [id] | NULL      | false     | https://jho.pe | https://jho.pe | NULL        | blog |
+    And the folllowing tags exist:
+      | tag  |
+      | blog |
+
+  @cli @undo
+  Scenario: Can undo adding a bookmark
+    When I run it with the following args:
+      """
+      add book https://jho.pe --tag blog
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id | parent_id | is_folder | name | url | description | tags |
+    And the folllowing tags exist:
+      | tag |
+
+  @cli @undo
+  Scenario: Can undo adding a session
+    When I run it with the following args:
+      """
+      add session --name Tabs --url https://jho.pe --url https://go.dev --tag tabs
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id | parent_id | is_folder | name | url | description | tags |
+    And the folllowing tags exist:
+      | tag |
+
+  @cli @undo
+  Scenario: Can undo merging duplicates
+    Given the DB already has the following entries:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | {id_1} | NULL      | false     | https://jho.pe | https://jho.pe | one         | blog |
+      | {id_2} | NULL      | false     | https://jho.pe | https://jho.pe | two         | web  |
+    When I run it with the following args:
+      """
+      add book https://go.dev
+      """
+    And I run it with the following args:
+      """
+      dedupe merge [id_1] [id_2]
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    Then the following bookmarks/folders exist:
+      | id     | parent_id | is_folder | name           | url            | description | tags |
+      | [id_1] | NULL      | false     | https://jho.pe | https://jho.pe | one         | blog |
+      | [id_2] | NULL      | false     | https://jho.pe | https://jho.pe | two         | web  |
+      | {id_3} | NULL      | false     | https://go.dev | https://go.dev | NULL        |      |
+    And the folllowing tags exist:
+      | tag  |
+      | blog |
+      | web  |
+
+  @cli @undo
+  Scenario: Can list history
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      add tag [id] --tag blog
+      """
+    And I run it with the following args:
+      """
+      remove book [id]
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    And I run it with the following args:
+      """
+      history
+      """
+    Then the folllowing history is returned:
+      | operation   | description                         | undone |
+      | remove-book | Removed bookmark "https://jho.pe"   | true   |
+      | add-tags    | Added tags blog to "https://jho.pe" | false  |
+      | add-book    | Added bookmark "https://jho.pe"     | false  |
+
+  @cli @undo
+  Scenario: Can limit history
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      add tag [id] --tag blog
+      """
+    And I run it with the following args:
+      """
+      history --first 1
+      """
+    Then the folllowing history is returned:
+      | operation | description                         | undone |
+      | add-tags  | Added tags blog to "https://jho.pe" | false  |
+
+  @cli @undo
+  Scenario: A new change can't be redone over
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name           | url            | description | tags |
+      | {id} | NULL      | false     | https://jho.pe | https://jho.pe | NULL        |      |
+    When I run it with the following args:
+      """
+      remove book [id]
+      """
+    And I run it with the following args:
+      """
+      undo
+      """
+    And I run it with the following args:
+      """
+      add folder blogs
+      """
+    And I run it with the following args:
+      """
+      redo
+      """
+    Then the following error is returned:
+      """
+      Nothing to redo
+      """
+
+  @cli @undo
+  Scenario: Must have something to undo
+    When I run it with the following args:
+      """
+      undo
+      """
+    Then the following error is returned:
+      """
+      Nothing to undo
+      """
+
+  @cli @undo
+  Scenario: Must have something to redo
+    When I run it with the following args:
+      """
+      redo
+      """
+    Then the following error is returned:
+      """
+      Nothing to redo
+      """
@@ -152,6 +152,41 @@ Feature: Update Folders with CLI
       Folder not found
       """
 
+  @cli @update_folder
+  Scenario: Cannot move folder into itself
+    Given the DB already has the following entries:
+      | id   | parent_id | is_folder | name  | url  | description | tags |
+      | {id} | NULL      | true      | blogs | NULL | NULL        |      |
+    When I run it with the following args:
+      """
+      update folder [id] --folder [id]
+      """
+    Then the following error is returned:
+      """
+      Can't move a folder into itself
+      """
+
+  @cli @update_folder
+  Scenario: Cannot move folder into its descendants
+    Given the DB already has the following entries:
+      | id           | parent_id  | is_folder | name  | url  | description | tags |
+      | {id}         | NULL       | true      | tech  | NULL | NULL        |      |
+      | {child_id}   | [id]       | true      | blogs | NULL | NULL        |      |
+      | {grandchild} | [child_id] | true      | go    | NULL | NULL        |      |
+    When I run it with the following args:
+      """
+      update folder [id] --folder [grandchild]
+      """
+    Then the following error is returned:
+      """
+      Can't move a folder into itself
+      """
+    And the following bookmarks/folders exist:
+      | id           | parent_id  | is_folder | name  | url  | description | tags |
+      | [id]         | NULL       | true      | tech  | NULL | NULL        |      |
+      | [child_id]   | [id]       | true      | blogs | NULL | NULL        |      |
+      | [grandchild] | [child_id] | true      | go    | NULL | NULL        |      |
+
   @cli @update_folder
   Scenario: Cannot move and remove folder at the same time
     Given the DB already has the following entries:
@@ -2,6 +2,8 @@ package test
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -41,7 +43,7 @@ func TestAddBook(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -86,7 +88,7 @@ func TestAddBookToFolder(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -122,7 +124,7 @@ func TestAddBookWithName(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -159,7 +161,7 @@ func TestAddBookWithDescription(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -195,7 +197,51 @@ func TestAddBookWithTags(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestAddBookWithFetchMetadata(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		//nolint:errcheck
+		w.Write([]byte(`<title>The Flat Field</title><meta name="description" content="A blog about code.">`))
+	}))
+	defer server.Close()
+
+	got, err := nativeMessageLoop(messaging.MessageKindAddBook, messaging.AddBookPayload{
+		DB:            null.NullStringFrom(db),
+		URL:           server.URL,
+		FetchMetadata: true,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	id, err := getLastInsertedID(db, []string{})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindBook, messaging.BookPayload{
+		Book: messaging.BookDTO{
+			ID:          id,
+			URL:         null.NullStringFrom(server.URL),
+			Name:        "The Flat Field",
+			Description: null.NullStringFrom("A blog about code."),
+			Tags:        []string{},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -41,7 +41,7 @@ func TestAddFolder(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -86,7 +86,7 @@ func TestAddFolderToFolder(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+func TestAddSession(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	got, err := nativeMessageLoop(messaging.MessageKindAddSession, messaging.AddSessionPayload{
+		DB:   null.NullStringFrom(db),
+		Name: "Tabs",
+		URLs: []string{"https://jho.pe", "https://go.dev"},
+		Tags: []string{"tabs"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	payload, err := messaging.GetPayload[messaging.BooksPayload](got)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(payload.Books) != 3 {
+		t.Fatalf("got %+v books; want %+v", len(payload.Books), 3)
+	}
+	folderID := payload.Books[0].ID
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindBooks, messaging.BooksPayload{
+		Books: []messaging.BookDTO{
+			{
+				ID:       folderID,
+				Name:     "Tabs",
+				IsFolder: true,
+				Tags:     []string{},
+			},
+			{
+				ID:         payload.Books[1].ID,
+				URL:        null.NullStringFrom("https://jho.pe"),
+				Name:       "https://jho.pe",
+				ParentID:   null.NullStringFrom(folderID),
+				ParentName: null.NullStringFrom("Tabs"),
+				Tags:       []string{"tabs"},
+			},
+			{
+				ID:         payload.Books[2].ID,
+				URL:        null.NullStringFrom("https://go.dev"),
+				Name:       "https://go.dev",
+				ParentID:   null.NullStringFrom(folderID),
+				ParentName: null.NullStringFrom("Tabs"),
+				Tags:       []string{"tabs"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
@@ -44,7 +44,7 @@ func TestAddTags(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -0,0 +1,132 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/pkg"
+	"github.com/samber/lo"
+)
+
+func TestBatch(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	got, err := nativeMessageLoop(messaging.MessageKindBatch, messaging.BatchPayload{
+		DB: null.NullStringFrom(db),
+		Operations: []messaging.NativeMessage{
+			batchOperation(t, messaging.MessageKindAddFolder, map[string]interface{}{"name": "Tabs"}),
+			batchOperation(t, messaging.MessageKindAddBook, map[string]interface{}{"url": "https://jho.pe", "parentId": "$0.id"}),
+			batchOperation(t, messaging.MessageKindAddBook, map[string]interface{}{"url": "https://go.dev", "parentId": "$0.id"}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got.Kind != messaging.MessageKindBatchResults {
+		t.Fatalf("got %+v; want %+v: %s", got.Kind, messaging.MessageKindBatchResults, got.Payload)
+	}
+
+	payload, err := messaging.GetPayload[messaging.BatchResultsPayload](got)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var books []messaging.BookDTO
+	for _, result := range payload.Results {
+		book, err := messaging.GetPayload[messaging.BookPayload](result)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		books = append(books, book.Book)
+	}
+
+	gotParents := lo.Map(books, func(book messaging.BookDTO, _ int) string {
+		return book.ParentID.String
+	})
+	wantParents := []string{"", books[0].ID, books[0].ID}
+
+	diff := cmp.Diff(gotParents, wantParents)
+	if diff != "" {
+		t.Errorf("Expected and actual parents different:\n%s", diff)
+	}
+}
+
+func TestBatchRollsBackOnError(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	got, err := nativeMessageLoop(messaging.MessageKindBatch, messaging.BatchPayload{
+		DB: null.NullStringFrom(db),
+		Operations: []messaging.NativeMessage{
+			batchOperation(t, messaging.MessageKindAddFolder, map[string]interface{}{"name": "Tabs"}),
+			batchOperation(t, messaging.MessageKindAddBook, map[string]interface{}{"url": "https://jho.pe", "parentId": "$0.id"}),
+			batchOperation(t, messaging.MessageKindAddBook, map[string]interface{}{"url": "", "parentId": "$0.id"}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got.Kind != messaging.MessageKindError {
+		t.Errorf("got %+v; want %+v", got.Kind, messaging.MessageKindError)
+	}
+
+	options := armaria.DefaultListBooksOptions()
+	options.WithDB(db)
+	books, err := armaria.ListBooks(options)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(books) != 0 {
+		t.Errorf("got %+v books; want %+v", len(books), 0)
+	}
+}
+
+func TestBatchInvalidReference(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	got, err := nativeMessageLoop(messaging.MessageKindBatch, messaging.BatchPayload{
+		DB: null.NullStringFrom(db),
+		Operations: []messaging.NativeMessage{
+			batchOperation(t, messaging.MessageKindAddBook, map[string]interface{}{"url": "https://jho.pe", "parentId": "$1.id"}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindError, messaging.ErrorPayload{
+		Error: `error while executing with a transaction: error in batch operation 0: invalid reference "$1.id"`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+// batchOperation creates an operation for a batch.
+func batchOperation(t *testing.T, kind messaging.MessageKind, payload map[string]interface{}) messaging.NativeMessage {
+	json, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return messaging.NativeMessage{
+		Kind:    kind,
+		Payload: string(json),
+	}
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+)
+
+func TestGetDBConfigMissing(t *testing.T) {
+	// The config lives in SNAP_USER_COMMON when it's set.
+	t.Setenv("SNAP_USER_COMMON", t.TempDir())
+
+	got, err := nativeMessageLoop(messaging.MessageKindGetDBConfig, messaging.GetDBConfigPayload{})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindConfigValue, messaging.ConfigValuePayload{
+		Value: "",
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestSetDBConfig(t *testing.T) {
+	// The config lives in SNAP_USER_COMMON when it's set.
+	t.Setenv("SNAP_USER_COMMON", t.TempDir())
+
+	got, err := nativeMessageLoop(messaging.MessageKindSetDBConfig, messaging.SetDBConfigPayload{
+		DB: "bookmarks.db",
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindVoid, messaging.VoidPayload{})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+
+	got, err = nativeMessageLoop(messaging.MessageKindGetDBConfig, messaging.GetDBConfigPayload{})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err = messaging.PayloadToMessage(messaging.MessageKindConfigValue, messaging.ConfigValuePayload{
+		Value: "bookmarks.db",
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff = cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/pkg"
+)
+
+func TestGetBook(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	folderOptions := armaria.DefaultAddFolderOptions()
+	folderOptions.WithDB(db)
+	folder, err := armaria.AddFolder("Blogs", folderOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithParentID(folder.ID)
+	bookOptions.WithName("The Flat Field")
+	bookOptions.WithTags([]string{"blog"})
+	book, err := armaria.AddBook("https://jho.pe", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindGetBook, messaging.GetBookPayload{
+		DB: null.NullStringFrom(db),
+		ID: book.ID,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindBook, messaging.BookPayload{
+		Book: messaging.BookDTO{
+			ID:         book.ID,
+			URL:        null.NullStringFrom("https://jho.pe"),
+			Name:       "The Flat Field",
+			Tags:       []string{"blog"},
+			ParentID:   null.NullStringFrom(folder.ID),
+			ParentName: null.NullStringFrom("Blogs"),
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestGetBookNotFound(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	got, err := nativeMessageLoop(messaging.MessageKindGetBook, messaging.GetBookPayload{
+		DB: null.NullStringFrom(db),
+		ID: uuid.New().String(),
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if got.Kind != messaging.MessageKindError {
+		t.Errorf("got %+v; want %+v", got.Kind, messaging.MessageKindError)
+	}
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/pkg"
+)
+
+func TestGetFavicons(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/favicon.ico" {
+			w.Header().Set("Content-Type", "image/gif")
+			//nolint:errcheck
+			w.Write([]byte("GIF89a"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html")
+		//nolint:errcheck
+		w.Write([]byte(`<title>The Flat Field</title>`))
+	}))
+	defer server.Close()
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	book, err := armaria.AddBook(server.URL+"/blog", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	_, err = armaria.AddBook(server.URL+"/news", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	refreshOptions := armaria.DefaultRefreshFaviconsOptions()
+	refreshOptions.WithDB(db)
+	favicons, err := armaria.RefreshFavicons(refreshOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if len(favicons) != 2 {
+		t.Fatalf("expected 2 favicons, got %d", len(favicons))
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindGetFavicons, messaging.GetFaviconsPayload{
+		DB:  null.NullStringFrom(db),
+		IDs: []string{book.ID},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindFavicons, messaging.FaviconsPayload{
+		Favicons: []messaging.FaviconDTO{
+			{
+				BookID:      book.ID,
+				Hash:        favicons[0].Hash,
+				URL:         server.URL + "/favicon.ico",
+				ContentType: "image/gif",
+				Size:        6,
+				Fetched:     favicons[0].Fetched,
+				Data:        "data:image/gif;base64,R0lGODlh",
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
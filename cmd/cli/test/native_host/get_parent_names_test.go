@@ -0,0 +1,60 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/pkg"
+)
+
+func TestGetParentNames(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	folderOptions := armaria.DefaultAddFolderOptions()
+	folderOptions.WithDB(db)
+	folder, err := armaria.AddFolder("Work", folderOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	folderOptions.WithParentID(folder.ID)
+	subfolder, err := armaria.AddFolder("Blogs", folderOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithParentID(subfolder.ID)
+	bookOptions.WithName("The Flat Field")
+	book, err := armaria.AddBook("https://jho.pe", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindGetParentNames, messaging.GetParentNamesPayload{
+		DB: null.NullStringFrom(db),
+		ID: book.ID,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindParentNames, messaging.ParentNamesPayload{
+		ParentNames: []string{"Work", "Blogs", "The Flat Field"},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
@@ -3,8 +3,10 @@ package test
 import (
 	"bytes"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/blockloop/scan/v2"
+	"github.com/google/go-cmp/cmp"
 	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
 	"github.com/nullism/bqb"
 )
@@ -50,7 +52,7 @@ func getLastInsertedID(dbLocation string, ignoreIds []string) (string, error) {
 		where.And(`"id" NOT IN (?)`, ignoreIds)
 	}
 	query.Space(`?`, where)
-	query.Space(`ORDER BY "modified" DESC LIMIT 1`)
+	query.Space(`ORDER BY "created" DESC LIMIT 1`)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -70,3 +72,38 @@ func getLastInsertedID(dbLocation string, ignoreIds []string) (string, error) {
 
 	return ids[0], err
 }
+
+// ignoreTimestamps compares messages without the created and modified timestamps of bookmarks/folders.
+// They depend on when the test ran so they can't be known ahead of time.
+var ignoreTimestamps = cmp.Transformer("ignoreTimestamps", func(msg messaging.NativeMessage) messaging.NativeMessage {
+	var payload interface{}
+	if err := json.Unmarshal([]byte(msg.Payload), &payload); err != nil {
+		return msg
+	}
+
+	stripped, err := json.Marshal(stripTimestamps(payload))
+	if err != nil {
+		return msg
+	}
+
+	msg.Payload = string(stripped)
+	return msg
+})
+
+// stripTimestamps removes created and modified timestamps from a decoded JSON payload.
+func stripTimestamps(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		delete(v, "created")
+		delete(v, "modified")
+		for key, child := range v {
+			v[key] = stripTimestamps(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = stripTimestamps(child)
+		}
+	}
+
+	return value
+}
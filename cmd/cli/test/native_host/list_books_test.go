@@ -61,7 +61,7 @@ func TestListBooksFolders(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -110,7 +110,7 @@ func TestListBooks(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -159,7 +159,7 @@ func TestListFolder(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -209,7 +209,7 @@ func TestListBooksFoldersWithLimit(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -264,7 +264,7 @@ func TestListBooksFoldersNamAsc(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -319,7 +319,7 @@ func TestListBooksFoldersNamDesc(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -369,7 +369,7 @@ func TestListBooksFoldersWithAfter(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -422,7 +422,7 @@ func TestListBooksFoldersWithParentID(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -473,7 +473,7 @@ func TestListBooksFoldersWithoutParentID(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -523,7 +523,7 @@ func TestListBooksFoldersWithQuery(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -574,7 +574,268 @@ func TestListBooksFoldersWithTags(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestListBooksFoldersWithAllTagsAndExcludedTags(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithTags([]string{"blog", "programming"})
+	book, err := armaria.AddBook("https://jho.pe", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	oneTagOptions := armaria.DefaultAddBookOptions()
+	oneTagOptions.WithDB(db)
+	oneTagOptions.WithTags([]string{"blog"})
+	_, err = armaria.AddBook("https://armaria.net", oneTagOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	excludedOptions := armaria.DefaultAddBookOptions()
+	excludedOptions.WithDB(db)
+	excludedOptions.WithTags([]string{"blog", "programming", "archived"})
+	_, err = armaria.AddBook("https://old.jho.pe", excludedOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindListBooks, messaging.ListBooksPayload{
+		DB:               null.NullStringFrom(db),
+		IncludeBookmarks: true,
+		IncludeFolders:   true,
+		Order:            string(armaria.OrderName),
+		Direction:        string(armaria.DirectionAsc),
+		Tags:             []string{"blog", "programming"},
+		TagMode:          string(armaria.TagModeAll),
+		ExcludeTags:      []string{"archived"},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindBooks, messaging.BooksPayload{
+		Books: []messaging.BookDTO{
+			{
+				ID:   book.ID,
+				URL:  null.NullStringFrom("https://jho.pe"),
+				Name: "https://jho.pe",
+				Tags: []string{"blog", "programming"},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestListBooksFoldersWithRelevance(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	descriptionOptions := armaria.DefaultAddBookOptions()
+	descriptionOptions.WithDB(db)
+	descriptionOptions.WithName("Armaria")
+	descriptionOptions.WithDescription("Not a flat field.")
+	description, err := armaria.AddBook("https://armaria.net", descriptionOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	nameOptions := armaria.DefaultAddBookOptions()
+	nameOptions.WithDB(db)
+	nameOptions.WithName("The Flat Field")
+	name, err := armaria.AddBook("https://jho.pe", nameOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindListBooks, messaging.ListBooksPayload{
+		DB:               null.NullStringFrom(db),
+		IncludeBookmarks: true,
+		IncludeFolders:   true,
+		Order:            string(armaria.OrderRelevance),
+		Direction:        string(armaria.DirectionAsc),
+		Query:            null.NullStringFrom(`"flat field"`),
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindBooks, messaging.BooksPayload{
+		Books: []messaging.BookDTO{
+			{
+				ID:      name.ID,
+				URL:     null.NullStringFrom("https://jho.pe"),
+				Name:    "The Flat Field",
+				Tags:    []string{},
+				Snippet: null.NullStringFrom("The <mark>Flat Field</mark>"),
+			},
+			{
+				ID:          description.ID,
+				URL:         null.NullStringFrom("https://armaria.net"),
+				Name:        "Armaria",
+				Description: null.NullStringFrom("Not a flat field."),
+				Tags:        []string{},
+				Snippet:     null.NullStringFrom("Not a <mark>flat field</mark>."),
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestListBooksFoldersWithStructuredQuery(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	folderOptions := armaria.DefaultAddFolderOptions()
+	folderOptions.WithDB(db)
+	folder, err := armaria.AddFolder("Blogs", folderOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	archivedOptions := armaria.DefaultAddBookOptions()
+	archivedOptions.WithDB(db)
+	archivedOptions.WithParentID(folder.ID)
+	archivedOptions.WithTags([]string{"blog", "archived"})
+	_, err = armaria.AddBook("https://old.jho.pe", archivedOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithParentID(folder.ID)
+	bookOptions.WithTags([]string{"blog"})
+	book, err := armaria.AddBook("https://jho.pe", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindListBooks, messaging.ListBooksPayload{
+		DB:               null.NullStringFrom(db),
+		IncludeBookmarks: true,
+		IncludeFolders:   true,
+		Order:            string(armaria.OrderManual),
+		Direction:        string(armaria.DirectionAsc),
+		Query:            null.NullStringFrom("folder:Blogs tag:blog tag:-archived"),
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindBooks, messaging.BooksPayload{
+		Books: []messaging.BookDTO{
+			{
+				ID:         book.ID,
+				URL:        null.NullStringFrom("https://jho.pe"),
+				Name:       "https://jho.pe",
+				ParentID:   null.NullStringFrom(folder.ID),
+				ParentName: null.NullStringFrom("Blogs"),
+				Tags:       []string{"blog"},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestListBooksFoldersRecursive(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	folderOptions := armaria.DefaultAddFolderOptions()
+	folderOptions.WithDB(db)
+	folder, err := armaria.AddFolder("Blogs", folderOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	childOptions := armaria.DefaultAddFolderOptions()
+	childOptions.WithDB(db)
+	childOptions.WithParentID(folder.ID)
+	child, err := armaria.AddFolder("Programming", childOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithParentID(child.ID)
+	book, err := armaria.AddBook("https://jho.pe", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindListBooks, messaging.ListBooksPayload{
+		DB:               null.NullStringFrom(db),
+		IncludeBookmarks: true,
+		IncludeFolders:   true,
+		ParentID:         null.NullStringFrom(folder.ID),
+		Recursive:        true,
+		Order:            string(armaria.OrderManual),
+		Direction:        string(armaria.DirectionAsc),
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindBooks, messaging.BooksPayload{
+		Books: []messaging.BookDTO{
+			{
+				ID:         child.ID,
+				Name:       "Programming",
+				ParentID:   null.NullStringFrom(folder.ID),
+				IsFolder:   true,
+				ParentName: null.NullStringFrom("Blogs"),
+				Tags:       []string{},
+				Depth:      null.NullInt64From(1),
+				Path:       []string{"Blogs", "Programming"},
+			},
+			{
+				ID:         book.ID,
+				URL:        null.NullStringFrom("https://jho.pe"),
+				Name:       "https://jho.pe",
+				ParentID:   null.NullStringFrom(child.ID),
+				ParentName: null.NullStringFrom("Programming"),
+				Tags:       []string{},
+				Depth:      null.NullInt64From(2),
+				Path:       []string{"Blogs", "Programming", "https://jho.pe"},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -208,3 +208,93 @@ func TestListTagsWithQuery(t *testing.T) {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
 }
+
+func TestListTagsWithCounts(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithTags([]string{"blog", "programming"})
+	_, err := armaria.AddBook("https://jho.pe", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	bookOptions = armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithTags([]string{"programming"})
+	_, err = armaria.AddBook("https://go.dev", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindListTags, messaging.ListTagsPayload{
+		DB:     null.NullStringFrom(db),
+		Counts: true,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindTagCounts, messaging.TagCountsPayload{
+		Tags: []messaging.TagCountDTO{
+			{Tag: "blog", Count: 1},
+			{Tag: "programming", Count: 2},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestListTagsAsTree(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithTags([]string{"blog", "lang/go"})
+	_, err := armaria.AddBook("https://jho.pe", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	bookOptions = armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithTags([]string{"lang/go"})
+	_, err = armaria.AddBook("https://go.dev", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindListTags, messaging.ListTagsPayload{
+		DB:   null.NullStringFrom(db),
+		Tree: true,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindTagTree, messaging.TagTreePayload{
+		Tree: []messaging.TagTreeDTO{
+			{Name: "blog", Tag: "blog", Count: 1, Children: []messaging.TagTreeDTO{}},
+			{Name: "lang", Tag: "lang", Count: 0, Children: []messaging.TagTreeDTO{
+				{Name: "go", Tag: "lang/go", Count: 2, Children: []messaging.TagTreeDTO{}},
+			}},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
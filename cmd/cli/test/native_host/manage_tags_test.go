@@ -0,0 +1,144 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/pkg"
+)
+
+func TestRenameTag(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithTags([]string{"golang"})
+	book, err := armaria.AddBook("https://go.dev", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindRenameTag, messaging.RenameTagPayload{
+		DB:   null.NullStringFrom(db),
+		From: "golang",
+		To:   "go",
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindTagCount, messaging.TagCountPayload{
+		Tag: messaging.TagCountDTO{Tag: "go", Count: 1},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+
+	getOptions := armaria.DefaultGetBookOptions()
+	getOptions.WithDB(db)
+	book, err = armaria.GetBook(book.ID, getOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff = cmp.Diff(book.Tags, []string{"go"})
+	if diff != "" {
+		t.Errorf("Expected and actual tags different:\n%s", diff)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithTags([]string{"golang", "go"})
+	_, err := armaria.AddBook("https://go.dev", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	bookOptions = armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithTags([]string{"go-lang"})
+	_, err = armaria.AddBook("https://pkg.go.dev", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindMergeTags, messaging.MergeTagsPayload{
+		DB:   null.NullStringFrom(db),
+		Tags: []string{"golang", "go-lang"},
+		Into: "go",
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindTagCount, messaging.TagCountPayload{
+		Tag: messaging.TagCountDTO{Tag: "go", Count: 2},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	bookOptions := armaria.DefaultAddBookOptions()
+	bookOptions.WithDB(db)
+	bookOptions.WithTags([]string{"blog", "programming"})
+	_, err := armaria.AddBook("https://jho.pe", bookOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindDeleteTag, messaging.DeleteTagPayload{
+		DB:  null.NullStringFrom(db),
+		Tag: "blog",
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindVoid, messaging.VoidPayload{})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+
+	tagsOptions := armaria.DefaultListTagsOptions()
+	tagsOptions.WithDB(db)
+	tags, err := armaria.ListTags(tagsOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff = cmp.Diff(tags, []string{"programming"})
+	if diff != "" {
+		t.Errorf("Expected and actual tags different:\n%s", diff)
+	}
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+func TestSession(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	in := bytes.NewBuffer(nil)
+
+	addFolder, err := messaging.PayloadToMessage(messaging.MessageKindAddFolder, messaging.AddFolderPayload{
+		DB:   null.NullStringFrom(db),
+		Name: "Blogs",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	addFolder.RequestID = "1"
+	if err := addFolder.SendMessage(in); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	unknown := messaging.NativeMessage{Kind: "unknown", RequestID: "2"}
+	if err := unknown.SendMessage(in); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	listBooks, err := messaging.PayloadToMessage(messaging.MessageKindListBooks, messaging.ListBooksPayload{
+		DB:             null.NullStringFrom(db),
+		IncludeFolders: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	listBooks.RequestID = "3"
+	if err := listBooks.SendMessage(in); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	out := bytes.NewBuffer(nil)
+	if err := messaging.Serve(in, out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got [][]string
+	for out.Len() > 0 {
+		msg, err := messaging.ReceiveMessage(out)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got = append(got, []string{msg.RequestID, string(msg.Kind)})
+	}
+
+	want := [][]string{
+		{"1", string(messaging.MessageKindBook)},
+		{"2", string(messaging.MessageKindError)},
+		{"3", string(messaging.MessageKindBooks)},
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual messages different:\n%s", diff)
+	}
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jonathanhope/armaria/cmd/cli/internal/messaging"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/pkg"
+)
+
+func TestUndo(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	addOptions := armaria.DefaultAddFolderOptions()
+	addOptions.WithDB(db)
+	folder, err := armaria.AddFolder("Blogs", addOptions)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	removeOptions := armaria.DefaultRemoveFolderOptions()
+	removeOptions.WithDB(db)
+	if err := armaria.RemoveFolder(folder.ID, removeOptions); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindUndo, messaging.UndoPayload{
+		DB: null.NullStringFrom(db),
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindJournalEntry, messaging.JournalEntryPayload{
+		Entry: messaging.JournalEntryDTO{
+			ID:          2,
+			Operation:   "remove-folder",
+			Description: `Removed folder "Blogs"`,
+			Undone:      true,
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+
+	getOptions := armaria.DefaultGetBookOptions()
+	getOptions.WithDB(db)
+	if _, err := armaria.GetBook(folder.ID, getOptions); err != nil {
+		t.Errorf("expected folder to be restored: %s", err)
+	}
+}
+
+func TestRedo(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	addOptions := armaria.DefaultAddFolderOptions()
+	addOptions.WithDB(db)
+	if _, err := armaria.AddFolder("Blogs", addOptions); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	undoOptions := armaria.DefaultUndoOptions()
+	undoOptions.WithDB(db)
+	if _, err := armaria.Undo(undoOptions); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindRedo, messaging.RedoPayload{
+		DB: null.NullStringFrom(db),
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindJournalEntry, messaging.JournalEntryPayload{
+		Entry: messaging.JournalEntryDTO{
+			ID:          1,
+			Operation:   "add-folder",
+			Description: `Added folder "Blogs"`,
+			Undone:      false,
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
+
+func TestListHistory(t *testing.T) {
+	db := fmt.Sprintf("%s.db", uuid.New().String())
+	defer func() { os.Remove(db) }()
+
+	addOptions := armaria.DefaultAddFolderOptions()
+	addOptions.WithDB(db)
+	if _, err := armaria.AddFolder("Blogs", addOptions); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if _, err := armaria.AddFolder("News", addOptions); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	got, err := nativeMessageLoop(messaging.MessageKindListHistory, messaging.ListHistoryPayload{
+		DB:    null.NullStringFrom(db),
+		First: null.NullInt64From(1),
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	want, err := messaging.PayloadToMessage(messaging.MessageKindJournalEntries, messaging.JournalEntriesPayload{
+		Entries: []messaging.JournalEntryDTO{
+			{
+				ID:          2,
+				Operation:   "add-folder",
+				Description: `Added folder "News"`,
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want, ignoreTimestamps)
+	if diff != "" {
+		t.Errorf("Expected and actual message different:\n%s", diff)
+	}
+}
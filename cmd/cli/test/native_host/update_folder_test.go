@@ -45,7 +45,7 @@ func TestUpdateFolder(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -91,7 +91,7 @@ func TestUpdateFolderParentID(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -137,7 +137,7 @@ func TestUpdateFolderRemoveParentID(t *testing.T) {
 		t.Errorf("unexpected error: %s", err)
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual message different:\n%s", diff)
 	}
@@ -191,7 +191,7 @@ func TestUpdateFolderOrderStart(t *testing.T) {
 		folder3.ID,
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual orders different:\n%s", diff)
 	}
@@ -245,7 +245,7 @@ func TestUpdateFolderOrderEnd(t *testing.T) {
 		folder2.ID,
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual orders different:\n%s", diff)
 	}
@@ -300,7 +300,7 @@ func TestUpdateFolderOrderBetween(t *testing.T) {
 		folder2.ID,
 	}
 
-	diff := cmp.Diff(got, want)
+	diff := cmp.Diff(got, want, ignoreTimestamps)
 	if diff != "" {
 		t.Errorf("Expected and actual orders different:\n%s", diff)
 	}
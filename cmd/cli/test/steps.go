@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/cucumber/godog"
@@ -20,6 +24,9 @@ import (
 type dbContextKey struct{}
 type outputContextKey struct{}
 type variablesContextKey struct{}
+type filesContextKey struct{}
+type inputContextKey struct{}
+type serverContextKey struct{}
 
 // InitializeTestSuite wires up events.
 func InitializeTestSuite(ctx *godog.TestSuiteContext) {
@@ -30,10 +37,17 @@ func InitializeTestSuite(ctx *godog.TestSuiteContext) {
 
 		ctx = context.WithValue(ctx, variablesContextKey{}, make(map[string]interface{}))
 
+		ctx = context.WithValue(ctx, filesContextKey{}, &[]string{})
+
 		return ctx, nil
 	})
 
 	ctx.ScenarioContext().After(func(ctx context.Context, sc *godog.Scenario, err error) (context.Context, error) {
+		// A web server the scenario started is shut down even if the scenario failed.
+		if server, ok := ctx.Value(serverContextKey{}).(*httptest.Server); ok {
+			server.Close()
+		}
+
 		if err != nil {
 			return nil, err
 		}
@@ -65,6 +79,17 @@ func InitializeTestSuite(ctx *godog.TestSuiteContext) {
 			}
 		}
 
+		// Any files the scenario created are deleted as well.
+
+		files, ok := ctx.Value(filesContextKey{}).(*[]string)
+		if !ok {
+			return ctx, errors.New("Missing files")
+		}
+
+		for _, file := range *files {
+			os.RemoveAll(file)
+		}
+
 		return ctx, nil
 	})
 }
@@ -72,16 +97,31 @@ func InitializeTestSuite(ctx *godog.TestSuiteContext) {
 // InitializeScenario wires up the steps.
 func InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Step(`^the DB already has the following entries:$`, theDBAlreadyHasTheFollowingEntries)
+	ctx.Step(`^the file "([^"]*)" has the following contents:$`, theFileHasTheFollowingContents)
+	ctx.Step(`^the folder "([^"]*)" has the file "([^"]*)" with the following contents:$`, theFolderHasTheFileWithTheFollowingContents)
+	ctx.Step(`^the following input is piped in:$`, theFollowingInputIsPipedIn)
+	ctx.Step(`^a web server is running with the following pages:$`, aWebServerIsRunningWithTheFollowingPages)
 	ctx.Step(`^I run it with the following args:$`, iRunItWithTheFollowingArgs)
 	ctx.Step(`^the following bookmarks\/folders exist:$`, theFollowingBookmarksFoldersExist)
 	ctx.Step(`^the folllowing tags exist:$`, theFollowingTagsExist)
 	ctx.Step(`^the following error is returned:$`, theFollowingErrorIsReturned)
 	ctx.Step(`^the folllowing tags are returned:$`, theFolllowingTagsAreReturned)
+	ctx.Step(`^the folllowing tag counts are returned:$`, theFolllowingTagCountsAreReturned)
 	ctx.Step(`^the folllowing names are returned:$`, theFolllowingNamesAreReturned)
 	ctx.Step(`^the folllowing books are returned:$`, theFolllowingBooksAreReturned)
+	ctx.Step(`^the following output is returned:$`, theFollowingOutputIsReturned)
+	ctx.Step(`^the folllowing paths are returned:$`, theFolllowingPathsAreReturned)
+	ctx.Step(`^the folllowing tree is returned:$`, theFolllowingTreeIsReturned)
+	ctx.Step(`^the folllowing tag tree is returned:$`, theFolllowingTagTreeIsReturned)
+	ctx.Step(`^the folllowing duplicates are returned:$`, theFolllowingDuplicatesAreReturned)
+	ctx.Step(`^the folllowing link checks are returned:$`, theFolllowingLinkChecksAreReturned)
+	ctx.Step(`^the folllowing archives are returned:$`, theFolllowingArchivesAreReturned)
+	ctx.Step(`^the folllowing favicons are returned:$`, theFolllowingFaviconsAreReturned)
+	ctx.Step(`^the folllowing history is returned:$`, theFolllowingHistoryIsReturned)
 }
 
 // theDBAlreadyHasTheFollowingEntries inserts data from a cucumber table into the bookmarks database.
+// Entries can optionally have created and modified timestamps; otherwise they're created now.
 func theDBAlreadyHasTheFollowingEntries(ctx context.Context, table *godog.Table) (context.Context, error) {
 	vars, ok := ctx.Value(variablesContextKey{}).(map[string]interface{})
 	if !ok {
@@ -108,6 +148,78 @@ func theDBAlreadyHasTheFollowingEntries(ctx context.Context, table *godog.Table)
 		if err != nil {
 			return ctx, err
 		}
+
+		// The entry's ID is only known if it was stored as a variable.
+		if len(row.Cells) > 8 {
+			_, _, key, err := handleString(vars, row.Cells[0].Value)
+			if err != nil {
+				return ctx, err
+			}
+
+			id, ok := vars[key].(string)
+			if !ok {
+				return ctx, errors.New("Entries with timestamps need their ID stored as a variable")
+			}
+
+			if err := setTimestamps(db, id, row.Cells[7].Value, row.Cells[8].Value); err != nil {
+				return ctx, err
+			}
+		}
+	}
+
+	return ctx, nil
+}
+
+// theFileHasTheFollowingContents writes a per scenario file.
+// The path to the file is stored as a variable with the provided name.
+func theFileHasTheFollowingContents(ctx context.Context, name string, contents *godog.DocString) (context.Context, error) {
+	vars, ok := ctx.Value(variablesContextKey{}).(map[string]interface{})
+	if !ok {
+		return ctx, errors.New("Missing variables")
+	}
+
+	files, ok := ctx.Value(filesContextKey{}).(*[]string)
+	if !ok {
+		return ctx, errors.New("Missing files")
+	}
+
+	path := fmt.Sprintf("%s-%s", uuid.New(), name)
+	if err := os.WriteFile(path, []byte(contents.Content), 0600); err != nil {
+		return ctx, err
+	}
+
+	*files = append(*files, path)
+	vars[name] = path
+
+	return ctx, nil
+}
+
+// theFolderHasTheFileWithTheFollowingContents writes a file to a per scenario folder.
+// The path to the folder is stored as a variable with the provided name.
+func theFolderHasTheFileWithTheFollowingContents(ctx context.Context, folder string, name string, contents *godog.DocString) (context.Context, error) {
+	vars, ok := ctx.Value(variablesContextKey{}).(map[string]interface{})
+	if !ok {
+		return ctx, errors.New("Missing variables")
+	}
+
+	files, ok := ctx.Value(filesContextKey{}).(*[]string)
+	if !ok {
+		return ctx, errors.New("Missing files")
+	}
+
+	path, ok := vars[folder].(string)
+	if !ok {
+		path = fmt.Sprintf("%s-%s", uuid.New(), folder)
+		if err := os.Mkdir(path, 0700); err != nil {
+			return ctx, err
+		}
+
+		*files = append(*files, path)
+		vars[folder] = path
+	}
+
+	if err := os.WriteFile(filepath.Join(path, name), []byte(contents.Content), 0600); err != nil {
+		return ctx, err
 	}
 
 	return ctx, nil
@@ -130,11 +242,77 @@ func iRunItWithTheFollowingArgs(ctx context.Context, args *godog.DocString) (con
 		return ctx, err
 	}
 
+	// Input is optional.
+	input, _ := ctx.Value(inputContextKey{}).(string)
+	input, err = processCommand(vars, input)
+	if err != nil {
+		return ctx, err
+	}
+
 	// Store the output for future use.
-	output, err := invokeCli(fmt.Sprintf("%s --db %s --formatter json", cmd, db))
+	output, err := invokeCliWithInput(fmt.Sprintf("%s --db %s --formatter json", cmd, db), input)
 	return context.WithValue(ctx, outputContextKey{}, output), err
 }
 
+// theFollowingInputIsPipedIn stores input to pipe into the CLI.
+func theFollowingInputIsPipedIn(ctx context.Context, input *godog.DocString) (context.Context, error) {
+	return context.WithValue(ctx, inputContextKey{}, input.Content), nil
+}
+
+// aWebServerIsRunningWithTheFollowingPages starts a per scenario web server that serves a table of pages.
+// Each page responds with its status, and redirects to its location if it has one.
+// Pages can optionally have a body which is HTML unless the path has an extension.
+// The full URL of each page is stored as a variable.
+func aWebServerIsRunningWithTheFollowingPages(ctx context.Context, table *godog.Table) (context.Context, error) {
+	vars, ok := ctx.Value(variablesContextKey{}).(map[string]interface{})
+	if !ok {
+		return ctx, errors.New("Missing variables")
+	}
+
+	mux := http.NewServeMux()
+	paths := make(map[string]string)
+
+	for _, row := range table.Rows[1:] {
+		_, _, key, err := handleString(vars, row.Cells[0].Value)
+		if err != nil {
+			return ctx, err
+		}
+
+		path := row.Cells[1].Value
+		location := row.Cells[3].Value
+		body := ""
+		if len(row.Cells) > 4 {
+			body = row.Cells[4].Value
+		}
+		status, err := strconv.Atoi(row.Cells[2].Value)
+		if err != nil {
+			return ctx, err
+		}
+
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if location != "" {
+				w.Header().Set("Location", location)
+			}
+			// Bodies for paths with an extension are sniffed by the server so they can be something other than HTML.
+			if body != "" && filepath.Ext(path) == "" {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			}
+			w.WriteHeader(status)
+			//nolint:errcheck
+			w.Write([]byte(body))
+		})
+
+		paths[key] = path
+	}
+
+	server := httptest.NewServer(mux)
+	for key, path := range paths {
+		vars[key] = server.URL + path
+	}
+
+	return context.WithValue(ctx, serverContextKey{}, server), nil
+}
+
 // theFollowingBookmarksFoldersExist compares the JSON output of the list all command with a cucumber results table.
 func theFollowingBookmarksFoldersExist(ctx context.Context, table *godog.Table) error {
 	vars, ok := ctx.Value(variablesContextKey{}).(map[string]interface{})
@@ -245,6 +423,24 @@ func theFollowingErrorIsReturned(ctx context.Context, message *godog.DocString)
 	return nil
 }
 
+// theFollowingOutputIsReturned compares the raw output of the CLI run with a cucumber string.
+func theFollowingOutputIsReturned(ctx context.Context, contents *godog.DocString) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	actual := strings.TrimSpace(output)
+	expected := strings.TrimSpace(contents.Content)
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual output different:\n%s", diff)
+	}
+
+	return nil
+}
+
 // theFolllowingTagsAreReturned compares the JSON output of the CLI with a set of tags.
 func theFolllowingTagsAreReturned(ctx context.Context, table *godog.Table) error {
 	output, ok := ctx.Value(outputContextKey{}).(string)
@@ -271,6 +467,45 @@ func theFolllowingTagsAreReturned(ctx context.Context, table *godog.Table) error
 	return nil
 }
 
+// theFolllowingTagCountsAreReturned compares the JSON output of the CLI with a set of tags and counts.
+func theFolllowingTagCountsAreReturned(ctx context.Context, table *godog.Table) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	var actual []messaging.TagCountDTO
+	if payload, err := receiveMessage[messaging.TagCountPayload](output, messaging.MessageKindTagCount); err == nil {
+		actual = []messaging.TagCountDTO{payload.Tag}
+	} else {
+		payload, err := receiveMessage[messaging.TagCountsPayload](output, messaging.MessageKindTagCounts)
+		if err != nil {
+			return err
+		}
+		actual = payload.Tags
+	}
+
+	var expected []messaging.TagCountDTO
+	for _, row := range table.Rows[1:] {
+		count, err := strconv.ParseInt(row.Cells[1].Value, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		expected = append(expected, messaging.TagCountDTO{
+			Tag:   row.Cells[0].Value,
+			Count: count,
+		})
+	}
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual tag counts different:\n%s", diff)
+	}
+
+	return nil
+}
+
 // theFolllowingNamesAreReturned compares the JSON output of the CLI with a set of names.
 func theFolllowingNamesAreReturned(ctx context.Context, table *godog.Table) error {
 	output, ok := ctx.Value(outputContextKey{}).(string)
@@ -350,3 +585,340 @@ func receiveMessage[T messaging.Payload](output string, kind messaging.MessageKi
 
 	return payload, nil
 }
+
+// theFolllowingPathsAreReturned compares the depths and paths in the JSON output of the CLI with a table.
+func theFolllowingPathsAreReturned(ctx context.Context, table *godog.Table) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	payload, err := receiveMessage[messaging.BooksPayload](output, messaging.MessageKindBooks)
+	if err != nil {
+		return err
+	}
+
+	var actual [][]string
+	for _, book := range payload.Books {
+		actual = append(actual, []string{strconv.FormatInt(book.Depth.Int64, 10), strings.Join(book.Path, " / ")})
+	}
+
+	var expected [][]string
+	for _, row := range table.Rows[1:] {
+		expected = append(expected, []string{row.Cells[0].Value, row.Cells[1].Value})
+	}
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual paths different:\n%s", diff)
+	}
+
+	return nil
+}
+
+// theFolllowingTreeIsReturned compares the JSON output of the CLI with an outline of names.
+// Each level of the outline is indented by two spaces.
+func theFolllowingTreeIsReturned(ctx context.Context, contents *godog.DocString) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	payload, err := receiveMessage[messaging.TreePayload](output, messaging.MessageKindTree)
+	if err != nil {
+		return err
+	}
+
+	actual := outlineTree(payload.Tree, "")
+	expected := strings.Split(contents.Content, "\n")
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual tree different:\n%s", diff)
+	}
+
+	return nil
+}
+
+// outlineTree converts a tree into an outline of names.
+func outlineTree(nodes []messaging.TreeDTO, indent string) []string {
+	var lines []string
+	for _, node := range nodes {
+		lines = append(lines, indent+node.Name)
+		lines = append(lines, outlineTree(node.Children, indent+"  ")...)
+	}
+
+	return lines
+}
+
+// theFolllowingTagTreeIsReturned compares the JSON output of the CLI with an outline of tag names.
+// Each level of the outline is indented by two spaces.
+// Tags applied to bookmarks are followed by their count in parens.
+func theFolllowingTagTreeIsReturned(ctx context.Context, contents *godog.DocString) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	payload, err := receiveMessage[messaging.TagTreePayload](output, messaging.MessageKindTagTree)
+	if err != nil {
+		return err
+	}
+
+	actual := outlineTagTree(payload.Tree, "")
+	expected := strings.Split(contents.Content, "\n")
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual tag tree different:\n%s", diff)
+	}
+
+	return nil
+}
+
+// outlineTagTree converts a tag tree into an outline of names.
+func outlineTagTree(nodes []messaging.TagTreeDTO, indent string) []string {
+	var lines []string
+	for _, node := range nodes {
+		line := indent + node.Name
+		if node.Count > 0 {
+			line = fmt.Sprintf("%s (%d)", line, node.Count)
+		}
+
+		lines = append(lines, line)
+		lines = append(lines, outlineTagTree(node.Children, indent+"  ")...)
+	}
+
+	return lines
+}
+
+// theFolllowingDuplicatesAreReturned compares the JSON output of the CLI with a table of duplicates.
+// Each row is a bookmark in a group along with the normalized URL of the group and the bookmark's path.
+func theFolllowingDuplicatesAreReturned(ctx context.Context, table *godog.Table) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	vars, ok := ctx.Value(variablesContextKey{}).(map[string]interface{})
+	if !ok {
+		return errors.New("Missing variables")
+	}
+
+	payload, err := receiveMessage[messaging.DuplicatesPayload](output, messaging.MessageKindDuplicates)
+	if err != nil {
+		return err
+	}
+
+	var actual [][]string
+	for _, group := range payload.Duplicates {
+		for _, book := range group.Books {
+			actual = append(actual, []string{group.URL, book.ID, strings.Join(book.Path, " / ")})
+		}
+	}
+
+	var expected [][]string
+	for _, row := range table.Rows[1:] {
+		id, _, _, err := handleString(vars, row.Cells[1].Value)
+		if err != nil {
+			return err
+		}
+
+		expected = append(expected, []string{row.Cells[0].Value, id, row.Cells[2].Value})
+	}
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual duplicates different:\n%s", diff)
+	}
+
+	return nil
+}
+
+// theFolllowingLinkChecksAreReturned compares the JSON output of the CLI with a table of link checks.
+func theFolllowingLinkChecksAreReturned(ctx context.Context, table *godog.Table) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	vars, ok := ctx.Value(variablesContextKey{}).(map[string]interface{})
+	if !ok {
+		return errors.New("Missing variables")
+	}
+
+	payload, err := receiveMessage[messaging.LinkChecksPayload](output, messaging.MessageKindLinkChecks)
+	if err != nil {
+		return err
+	}
+
+	var actual [][]string
+	for _, check := range payload.LinkChecks {
+		status := "NULL"
+		if check.Status.Valid {
+			status = strconv.FormatInt(check.Status.Int64, 10)
+		}
+
+		redirect := "NULL"
+		if check.Redirect.Valid {
+			redirect = check.Redirect.String
+		}
+
+		actual = append(actual, []string{check.Book.ID, status, redirect, strconv.FormatBool(check.Broken)})
+	}
+
+	var expected [][]string
+	for _, row := range table.Rows[1:] {
+		id, _, _, err := handleString(vars, row.Cells[0].Value)
+		if err != nil {
+			return err
+		}
+
+		redirect, _, _, err := handleString(vars, row.Cells[2].Value)
+		if err != nil {
+			return err
+		}
+
+		expected = append(expected, []string{id, row.Cells[1].Value, redirect, row.Cells[3].Value})
+	}
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual link checks different:\n%s", diff)
+	}
+
+	return nil
+}
+
+// theFolllowingArchivesAreReturned checks the archives returned by the CLI.
+func theFolllowingArchivesAreReturned(ctx context.Context, table *godog.Table) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	vars, ok := ctx.Value(variablesContextKey{}).(map[string]interface{})
+	if !ok {
+		return errors.New("Missing variables")
+	}
+
+	payload, err := receiveMessage[messaging.ArchivesPayload](output, messaging.MessageKindArchives)
+	if err != nil {
+		return err
+	}
+
+	var actual [][]string
+	for _, archive := range payload.Archives {
+		actual = append(actual, []string{archive.BookID, archive.URL, archive.ContentType, strconv.FormatBool(archive.Inlined)})
+	}
+
+	var expected [][]string
+	for _, row := range table.Rows[1:] {
+		id, _, _, err := handleString(vars, row.Cells[0].Value)
+		if err != nil {
+			return err
+		}
+
+		url, _, _, err := handleString(vars, row.Cells[1].Value)
+		if err != nil {
+			return err
+		}
+
+		expected = append(expected, []string{id, url, row.Cells[2].Value, row.Cells[3].Value})
+	}
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual archives different:\n%s", diff)
+	}
+
+	return nil
+}
+
+// theFolllowingFaviconsAreReturned checks the favicons returned by the CLI.
+// Hashes can be stored and retrieved as variables to check that icons are shared.
+func theFolllowingFaviconsAreReturned(ctx context.Context, table *godog.Table) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	vars, ok := ctx.Value(variablesContextKey{}).(map[string]interface{})
+	if !ok {
+		return errors.New("Missing variables")
+	}
+
+	payload, err := receiveMessage[messaging.FaviconsPayload](output, messaging.MessageKindFavicons)
+	if err != nil {
+		return err
+	}
+
+	if len(payload.Favicons) != len(table.Rows)-1 {
+		return fmt.Errorf("Expected %d favicons, got %d", len(table.Rows)-1, len(payload.Favicons))
+	}
+
+	var actual [][]string
+	var expected [][]string
+	for i, row := range table.Rows[1:] {
+		favicon := payload.Favicons[i]
+		actual = append(actual, []string{favicon.BookID, favicon.URL, favicon.ContentType, favicon.Hash})
+
+		id, _, _, err := handleString(vars, row.Cells[0].Value)
+		if err != nil {
+			return err
+		}
+
+		url, _, _, err := handleString(vars, row.Cells[1].Value)
+		if err != nil {
+			return err
+		}
+
+		hash, store, key, err := handleString(vars, row.Cells[3].Value)
+		if err != nil {
+			return err
+		}
+		if store {
+			vars[key] = favicon.Hash
+			hash = favicon.Hash
+		}
+
+		expected = append(expected, []string{id, url, row.Cells[2].Value, hash})
+	}
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual favicons different:\n%s", diff)
+	}
+
+	return nil
+}
+
+// theFolllowingHistoryIsReturned checks the operations in the journal returned by the CLI.
+func theFolllowingHistoryIsReturned(ctx context.Context, table *godog.Table) error {
+	output, ok := ctx.Value(outputContextKey{}).(string)
+	if !ok {
+		return errors.New("Missing output")
+	}
+
+	payload, err := receiveMessage[messaging.JournalEntriesPayload](output, messaging.MessageKindJournalEntries)
+	if err != nil {
+		return err
+	}
+
+	var actual [][]string
+	for _, entry := range payload.Entries {
+		actual = append(actual, []string{entry.Operation, entry.Description, strconv.FormatBool(entry.Undone)})
+	}
+
+	var expected [][]string
+	for _, row := range table.Rows[1:] {
+		expected = append(expected, []string{row.Cells[0].Value, row.Cells[1].Value, row.Cells[2].Value})
+	}
+
+	diff := cmp.Diff(expected, actual)
+	if diff != "" {
+		return fmt.Errorf("Expected and actual history different:\n%s", diff)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"bytes"
+	"database/sql"
 	"errors"
 	"fmt"
 	"regexp"
@@ -20,6 +21,11 @@ import (
 
 // invokeCli runs the Armaria CLI with the provided args.
 func invokeCli(args string) (string, error) {
+	return invokeCliWithInput(args, "")
+}
+
+// invokeCliWithInput invokes the CLI with input piped into it.
+func invokeCliWithInput(args string, input string) (string, error) {
 	// All of this is to invoke a Kong CLI app directly in code.
 	// A buffer is used to intercept output.
 
@@ -53,8 +59,10 @@ func invokeCli(args string) (string, error) {
 	err = ctx.Run(&cmd.Context{
 		DB:         rootCmd.DB,
 		Formatter:  rootCmd.Formatter,
+		Reader:     strings.NewReader(input),
 		Writer:     w,
-		ReturnCode: noop})
+		ReturnCode: noop,
+		Open:       func(string) error { return nil }})
 	if err != nil {
 		return "", err
 	}
@@ -179,11 +187,23 @@ func insert(args insertArgs) error {
 	return nil
 }
 
+// setTimestamps overwrites when a bookmark or folder was created and modified directly in the bookmarks DB.
+func setTimestamps(dbLocation string, id string, created string, modified string) error {
+	db, err := sql.Open("sqlite", dbLocation)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`UPDATE "bookmarks" SET "created" = ?, "modified" = ? WHERE "id" = ?`, created, modified, id)
+	return err
+}
+
 // tableToBooks converts a cucumber table to a collection of bookmarks/folders.
 func tableToBooks(vars map[string]interface{}, actual []messaging.BookDTO, table *godog.Table) ([]messaging.BookDTO, error) {
 	books := make([]messaging.BookDTO, 0)
-	for _, row := range table.Rows[1:] {
-		id, storeId, _, err := handleString(vars, row.Cells[0].Value)
+	for i, row := range table.Rows[1:] {
+		id, storeId, idKey, err := handleString(vars, row.Cells[0].Value)
 		if err != nil {
 			return nil, err
 		}
@@ -219,12 +239,18 @@ func tableToBooks(vars map[string]interface{}, actual []messaging.BookDTO, table
 		}
 
 		// The {...} is used as a wildcard in the result table.
-		// Using the last inserted result was the best way I could think of to model this.
+		// It takes its value from the result in the same position.
+		// If there is no result in that position the last inserted result is used.
+		// IDs are stored so later rows can refer to them with [...].
 
 		last := actual[len(actual)-1]
+		if i < len(actual) {
+			last = actual[i]
+		}
 
 		if storeId {
 			id = last.ID
+			vars[idKey] = id
 		}
 
 		if storeParentId {
@@ -435,6 +461,7 @@ func noop(code int) {}
 
 // markDirty marks every field as dirty.
 // The dirty field is not relevant to tests.
+// Timestamps depend on when the test ran so they're cleared too.
 func markDirty(expected []messaging.BookDTO, actual []messaging.BookDTO) {
 	for i := range expected {
 		expected[i].Description.Dirty = false
@@ -442,6 +469,12 @@ func markDirty(expected []messaging.BookDTO, actual []messaging.BookDTO) {
 		expected[i].ParentName.Dirty = false
 		expected[i].URL.Dirty = false
 		expected[i].ParentName = null.NullString{}
+		expected[i].Snippet = null.NullString{}
+		expected[i].Depth = null.NullInt64{}
+		expected[i].Path = nil
+		expected[i].Created = ""
+		expected[i].Modified = ""
+		expected[i].DeletedAt = null.NullString{}
 	}
 
 	for i := range actual {
@@ -450,6 +483,12 @@ func markDirty(expected []messaging.BookDTO, actual []messaging.BookDTO) {
 		actual[i].ParentName.Dirty = false
 		actual[i].URL.Dirty = false
 		actual[i].ParentName = null.NullString{}
+		actual[i].Snippet = null.NullString{}
+		actual[i].Depth = null.NullInt64{}
+		actual[i].Path = nil
+		actual[i].Created = ""
+		actual[i].Modified = ""
+		actual[i].DeletedAt = null.NullString{}
 	}
 }
 
@@ -21,6 +21,7 @@ require (
 	github.com/nullism/bqb v1.7.2
 	github.com/pressly/goose/v3 v3.20.0
 	github.com/samber/lo v1.39.0
+	golang.org/x/net v0.25.0
 	golang.org/x/sys v0.20.0
 	gopkg.in/DATA-DOG/go-sqlmock.v1 v1.3.0
 	modernc.org/sqlite v1.30.0
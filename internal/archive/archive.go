@@ -0,0 +1,199 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"github.com/jonathanhope/armaria/internal/web"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// Options control how pages are archived.
+type Options struct {
+	Inline     bool  // inline stylesheets and images into the page
+	MaxSize    int64 // the max size of the page and of each resource that is inlined
+	MaxInlined int64 // the max total size of all of the resources that are inlined
+}
+
+// Page is an archived copy of a web page.
+type Page struct {
+	URL         string // the URL the page was downloaded from after following redirects
+	ContentType string // the media type of the page
+	Content     []byte // the page itself; HTML pages are always re-encoded as UTF-8
+	Text        string // the readable text of the page; empty if the page isn't HTML
+	Inlined     bool   // whether stylesheets and images were inlined
+}
+
+// Fetch downloads a copy of a web page.
+func Fetch(ctx context.Context, client *http.Client, rawURL string, options Options) (Page, error) {
+	body, contentType, final, err := download(ctx, client, rawURL, options.MaxSize)
+	if err != nil {
+		return Page{}, err
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "application/octet-stream"
+	}
+
+	// Anything that isn't HTML is kept exactly as it was downloaded.
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return Page{
+			URL:         final.String(),
+			ContentType: mediaType,
+			Content:     body,
+		}, nil
+	}
+
+	reader, err := charset.NewReader(bytes.NewReader(body), contentType)
+	if err != nil {
+		return Page{}, err
+	}
+
+	doc, err := html.Parse(reader)
+	if err != nil {
+		return Page{}, err
+	}
+
+	if options.Inline {
+		// Pages can set their own base for relative references.
+		base := final
+		if node := find(doc, "base"); node != nil {
+			if resolved, ok := web.Resolve(final, getAttr(node, "href")); ok {
+				base = resolved
+			}
+		}
+
+		inliner := inliner{
+			ctx:       ctx,
+			client:    client,
+			maxSize:   options.MaxSize,
+			remaining: options.MaxInlined,
+		}
+		inliner.inline(doc, base)
+	}
+
+	addBase(doc, final)
+
+	var content bytes.Buffer
+	if err := html.Render(&content, doc); err != nil {
+		return Page{}, err
+	}
+
+	return Page{
+		URL:         final.String(),
+		ContentType: "text/html",
+		Content:     content.Bytes(),
+		Text:        Text(doc),
+		Inlined:     options.Inline,
+	}, nil
+}
+
+// download gets the body of a URL along with its content type and the URL it ended up at.
+func download(ctx context.Context, client *http.Client, rawURL string, maxSize int64) ([]byte, string, *url.URL, error) {
+	req, err := web.NewRequest(ctx, rawURL)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return nil, "", nil, fmt.Errorf("%w: %d", ErrBadStatus, res.StatusCode)
+	}
+
+	// One extra byte is read so a body that is exactly the max size isn't rejected.
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if int64(len(body)) > maxSize {
+		return nil, "", nil, ErrTooLarge
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	return body, contentType, res.Request.URL, nil
+}
+
+// addBase points relative links at the original page.
+// If the page already has a <base> element it's left alone.
+func addBase(doc *html.Node, base *url.URL) {
+	if find(doc, "base") != nil {
+		return
+	}
+
+	head := find(doc, "head")
+	if head == nil {
+		return
+	}
+
+	head.InsertBefore(&html.Node{
+		Type: html.ElementNode,
+		Data: "base",
+		Attr: []html.Attribute{{Key: "href", Val: base.String()}},
+	}, head.FirstChild)
+}
+
+// find finds the first element with a tag name.
+func find(node *html.Node, tag string) *html.Node {
+	if node.Type == html.ElementNode && node.Data == tag {
+		return node
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := find(child, tag); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// getAttr gets the value of an attribute of an element.
+func getAttr(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
+
+// setAttr sets the value of an attribute of an element.
+func setAttr(node *html.Node, key string, val string) {
+	for i, attr := range node.Attr {
+		if attr.Key == key {
+			node.Attr[i].Val = val
+			return
+		}
+	}
+
+	node.Attr = append(node.Attr, html.Attribute{Key: key, Val: val})
+}
+
+// removeAttr removes an attribute from an element.
+func removeAttr(node *html.Node, key string) {
+	attrs := node.Attr[:0]
+	for _, attr := range node.Attr {
+		if attr.Key != key {
+			attrs = append(attrs, attr)
+		}
+	}
+	node.Attr = attrs
+}
@@ -0,0 +1,174 @@
+package archive
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+// png is a 1x1 transparent PNG.
+var png, _ = base64.StdEncoding.DecodeString("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII=")
+
+func newServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		//nolint:errcheck
+		w.Write([]byte(`<html><head><title>Page</title><link rel="stylesheet" href="/style.css"></head>
+			<body><h1>Hello</h1><script>var hidden = 1;</script><p>World <img src="/dot.png" srcset="/dot.png 2x"></p>
+			<img src="/missing.png"><a href="/other">Other</a></body></html>`))
+	})
+	mux.HandleFunc("/style.css", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		//nolint:errcheck
+		w.Write([]byte(`body { background: url('dot.png'); }`))
+	})
+	mux.HandleFunc("/dot.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		//nolint:errcheck
+		w.Write(png)
+	})
+	mux.HandleFunc("/missing.png", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/file.pdf", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		//nolint:errcheck
+		w.Write([]byte("%PDF-1.4"))
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestFetch(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+
+	page, err := Fetch(context.Background(), server.Client(), server.URL+"/page", Options{MaxSize: 1024 * 1024})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content := string(page.Content)
+
+	if page.ContentType != "text/html" || page.Inlined {
+		t.Errorf("got %s %t; want text/html false", page.ContentType, page.Inlined)
+	}
+
+	if !strings.Contains(content, `<base href="`+server.URL+`/page"/>`) {
+		t.Errorf("base missing from %s", content)
+	}
+
+	if !strings.Contains(content, `href="/style.css"`) {
+		t.Errorf("stylesheet should not be inlined in %s", content)
+	}
+
+	if page.Text != "Hello World Other" {
+		t.Errorf("got text %q; want %q", page.Text, "Hello World Other")
+	}
+}
+
+func TestFetchInline(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+
+	page, err := Fetch(context.Background(), server.Client(), server.URL+"/page", Options{
+		Inline:     true,
+		MaxSize:    1024 * 1024,
+		MaxInlined: 1024 * 1024,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content := string(page.Content)
+	dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString(png)
+
+	if !page.Inlined {
+		t.Errorf("page should be inlined")
+	}
+
+	if strings.Contains(content, "style.css") {
+		t.Errorf("stylesheet should be inlined in %s", content)
+	}
+
+	if !strings.Contains(content, `<style>body { background: url("`+dataURL+`"); }</style>`) {
+		t.Errorf("CSS image should be inlined in %s", content)
+	}
+
+	if !strings.Contains(content, `<img src="`+dataURL+`"/>`) {
+		t.Errorf("image should be inlined without srcset in %s", content)
+	}
+
+	if !strings.Contains(content, `<img src="/missing.png"/>`) {
+		t.Errorf("missing image should be left alone in %s", content)
+	}
+}
+
+func TestFetchInlineLimit(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+
+	page, err := Fetch(context.Background(), server.Client(), server.URL+"/page", Options{
+		Inline:     true,
+		MaxSize:    1024 * 1024,
+		MaxInlined: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(string(page.Content), `href="/style.css"`) {
+		t.Errorf("stylesheet should not be inlined past the limit in %s", page.Content)
+	}
+}
+
+func TestFetchNotHTML(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+
+	page, err := Fetch(context.Background(), server.Client(), server.URL+"/file.pdf", Options{MaxSize: 1024})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if page.ContentType != "application/pdf" || string(page.Content) != "%PDF-1.4" || page.Text != "" {
+		t.Errorf("got %+v", page)
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	server := newServer()
+	defer server.Close()
+
+	_, err := Fetch(context.Background(), server.Client(), server.URL+"/gone", Options{MaxSize: 1024})
+	if !errors.Is(err, ErrBadStatus) {
+		t.Errorf("got %+v; want %+v", err, ErrBadStatus)
+	}
+
+	_, err = Fetch(context.Background(), server.Client(), server.URL+"/page", Options{MaxSize: 10})
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("got %+v; want %+v", err, ErrTooLarge)
+	}
+}
+
+func TestText(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<head><title>T</title><style>p {}</style></head>
+		<body><p>One   two</p><noscript>No</noscript><p>three</p></body>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := Text(doc); got != "One two three" {
+		t.Errorf("got %q; want %q", got, "One two three")
+	}
+}
@@ -0,0 +1,6 @@
+// archive contains the logic to download a copy of a web page so it can be read after the page is gone.
+// HTML pages get a <base> element so relative links still point at the original site.
+// Optionally stylesheets and images are inlined so the page is a single self-contained file:
+// stylesheets become <style> elements and images (including those referenced from CSS) become data URLs.
+// The readable text of a page is also extracted so it can be searched.
+package archive
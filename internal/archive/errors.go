@@ -0,0 +1,9 @@
+package archive
+
+import "errors"
+
+// ErrBadStatus is returned when a page responds with an error status.
+var ErrBadStatus = errors.New("bad status")
+
+// ErrTooLarge is returned when a page is larger than the max size.
+var ErrTooLarge = errors.New("page too large")
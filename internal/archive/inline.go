@@ -0,0 +1,142 @@
+package archive
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/web"
+	"golang.org/x/net/html"
+)
+
+// cssURL matches url(...) references in a stylesheet.
+var cssURL = regexp.MustCompile(`url\(\s*(['"]?)([^'")]+)['"]?\s*\)`)
+
+// inliner replaces references to stylesheets and images with their contents.
+// Resources that can't be downloaded are left pointing at the original site.
+type inliner struct {
+	ctx       context.Context
+	client    *http.Client
+	maxSize   int64 // the max size of each resource
+	remaining int64 // how many more bytes of resources can be inlined
+}
+
+// inline walks a document inlining stylesheets and images.
+func (in *inliner) inline(node *html.Node, base *url.URL) {
+	// The next sibling is captured first since the node may be replaced.
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		in.inline(child, base)
+		child = next
+	}
+
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	switch node.Data {
+
+	case "link":
+		if !web.HasRel(getAttr(node, "rel"), "stylesheet") {
+			return
+		}
+
+		ref, ok := web.Resolve(base, getAttr(node, "href"))
+		if !ok {
+			return
+		}
+
+		css, _, err := in.download(ref)
+		if err != nil {
+			return
+		}
+
+		style := &html.Node{Type: html.ElementNode, Data: "style"}
+		if media := getAttr(node, "media"); media != "" {
+			setAttr(style, "media", media)
+		}
+		style.AppendChild(&html.Node{Type: html.TextNode, Data: in.inlineCSS(string(css), ref)})
+
+		node.Parent.InsertBefore(style, node)
+		node.Parent.RemoveChild(node)
+
+	case "style":
+		if node.FirstChild != nil && node.FirstChild.Type == html.TextNode {
+			node.FirstChild.Data = in.inlineCSS(node.FirstChild.Data, base)
+		}
+
+	case "img":
+		ref, ok := web.Resolve(base, getAttr(node, "src"))
+		if !ok {
+			return
+		}
+
+		if data, ok := in.dataURL(ref); ok {
+			setAttr(node, "src", data)
+			// Browsers prefer srcset over src so it has to go for the inlined image to be used.
+			removeAttr(node, "srcset")
+		}
+	}
+}
+
+// inlineCSS replaces url(...) references in a stylesheet with data URLs.
+// References that can't be inlined are made absolute so they still resolve.
+func (in *inliner) inlineCSS(css string, base *url.URL) string {
+	return cssURL.ReplaceAllStringFunc(css, func(match string) string {
+		ref := cssURL.FindStringSubmatch(match)[2]
+		if strings.HasPrefix(ref, "data:") {
+			return match
+		}
+
+		resolved, ok := web.Resolve(base, ref)
+		if !ok {
+			return match
+		}
+
+		if data, ok := in.dataURL(resolved); ok {
+			return fmt.Sprintf(`url("%s")`, data)
+		}
+
+		return fmt.Sprintf(`url("%s")`, resolved.String())
+	})
+}
+
+// dataURL downloads a resource and encodes it as a data URL.
+func (in *inliner) dataURL(ref *url.URL) (string, bool) {
+	body, contentType, err := in.download(ref)
+	if err != nil {
+		return "", false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = http.DetectContentType(body)
+	}
+
+	return fmt.Sprintf("data:%s;base64,%s", mediaType, base64.StdEncoding.EncodeToString(body)), true
+}
+
+// download downloads a resource as long as there is room left to inline it.
+func (in *inliner) download(ref *url.URL) ([]byte, string, error) {
+	if in.remaining <= 0 {
+		return nil, "", ErrTooLarge
+	}
+
+	maxSize := in.maxSize
+	if in.remaining < maxSize {
+		maxSize = in.remaining
+	}
+
+	body, contentType, _, err := download(in.ctx, in.client, ref.String(), maxSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	in.remaining -= int64(len(body))
+	return body, contentType, nil
+}
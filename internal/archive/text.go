@@ -0,0 +1,42 @@
+package archive
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// skipped are elements whose contents aren't readable text.
+var skipped = map[string]bool{
+	"head":     true,
+	"script":   true,
+	"style":    true,
+	"noscript": true,
+	"template": true,
+	"svg":      true,
+}
+
+// Text extracts the readable text of a document.
+// Runs of whitespace are collapsed into single spaces.
+func Text(doc *html.Node) string {
+	var builder strings.Builder
+	text(doc, &builder)
+
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+// text writes the readable text of a node and its children.
+func text(node *html.Node, builder *strings.Builder) {
+	if node.Type == html.ElementNode && skipped[node.Data] {
+		return
+	}
+
+	if node.Type == html.TextNode {
+		builder.WriteString(node.Data)
+		builder.WriteString(" ")
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		text(child, builder)
+	}
+}
@@ -0,0 +1,87 @@
+package browser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/jonathanhope/armaria/internal/netscape"
+)
+
+// chromeFile is the name of the file Chrome keeps its bookmarks in.
+const chromeFile = "Bookmarks"
+
+// chromeBookmarks is the document stored in the Chrome bookmarks file.
+type chromeBookmarks struct {
+	Roots struct {
+		BookmarkBar *chromeNode `json:"bookmark_bar"`
+		Other       *chromeNode `json:"other"`
+		Synced      *chromeNode `json:"synced"`
+	} `json:"roots"`
+}
+
+// chromeNode is a bookmark or folder in the Chrome bookmarks file.
+type chromeNode struct {
+	Type     string       `json:"type"`     // either url or folder
+	Name     string       `json:"name"`     // name of the bookmark/folder
+	URL      string       `json:"url"`      // address of the bookmark; empty for folders
+	Children []chromeNode `json:"children"` // children of the folder; empty for bookmarks
+}
+
+// ReadChrome reads the bookmarks out of a Chrome or Chromium profile.
+// The built in bookmarks bar, other, and mobile folders are rebuilt as folders.
+func ReadChrome(profile string) ([]*netscape.Node, error) {
+	path := filepath.Join(profile, chromeFile)
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrProfileNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("error opening file while reading chrome bookmarks: %w", err)
+	}
+	defer file.Close()
+
+	var bookmarks chromeBookmarks
+	if err := json.NewDecoder(file).Decode(&bookmarks); err != nil {
+		return nil, fmt.Errorf("error decoding file while reading chrome bookmarks: %w", err)
+	}
+
+	nodes := make([]*netscape.Node, 0)
+	roots := []*chromeNode{bookmarks.Roots.BookmarkBar, bookmarks.Roots.Other, bookmarks.Roots.Synced}
+	for _, root := range roots {
+		if root == nil {
+			continue
+		}
+
+		folder := chromeToNode(*root)
+		if len(folder.Children) > 0 {
+			nodes = append(nodes, folder)
+		}
+	}
+
+	return nodes, nil
+}
+
+// chromeToNode converts a Chrome bookmark/folder (and all of its children) into a node.
+func chromeToNode(bookmark chromeNode) *netscape.Node {
+	if bookmark.Type != "folder" {
+		return &netscape.Node{
+			Name: bookmark.Name,
+			URL:  bookmark.URL,
+			Tags: make([]string, 0),
+		}
+	}
+
+	folder := &netscape.Node{
+		IsFolder: true,
+		Name:     bookmark.Name,
+	}
+
+	for _, child := range bookmark.Children {
+		folder.Children = append(folder.Children, chromeToNode(child))
+	}
+
+	return folder
+}
@@ -0,0 +1,101 @@
+package browser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jonathanhope/armaria/internal/netscape"
+)
+
+func TestReadChrome(t *testing.T) {
+	profile := t.TempDir()
+
+	contents := `{
+  "checksum": "0",
+  "roots": {
+    "bookmark_bar": {
+      "children": [
+        {
+          "children": [
+            {"name": "The Flat Field", "type": "url", "url": "https://jho.pe"}
+          ],
+          "name": "blogs",
+          "type": "folder"
+        },
+        {"name": "Armaria", "type": "url", "url": "https://armaria.net"}
+      ],
+      "name": "Bookmarks bar",
+      "type": "folder"
+    },
+    "other": {"children": [], "name": "Other bookmarks", "type": "folder"},
+    "synced": {
+      "children": [
+        {"name": "Armaria", "type": "url", "url": "https://armaria.net"}
+      ],
+      "name": "Mobile bookmarks",
+      "type": "folder"
+    }
+  },
+  "version": 1
+}`
+
+	if err := os.WriteFile(filepath.Join(profile, chromeFile), []byte(contents), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := ReadChrome(profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []*netscape.Node{
+		{
+			IsFolder: true,
+			Name:     "Bookmarks bar",
+			Children: []*netscape.Node{
+				{
+					IsFolder: true,
+					Name:     "blogs",
+					Children: []*netscape.Node{
+						{
+							Name: "The Flat Field",
+							URL:  "https://jho.pe",
+							Tags: []string{},
+						},
+					},
+				},
+				{
+					Name: "Armaria",
+					URL:  "https://armaria.net",
+					Tags: []string{},
+				},
+			},
+		},
+		{
+			IsFolder: true,
+			Name:     "Mobile bookmarks",
+			Children: []*netscape.Node{
+				{
+					Name: "Armaria",
+					URL:  "https://armaria.net",
+					Tags: []string{},
+				},
+			},
+		},
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual nodes different:\n%s", diff)
+	}
+}
+
+func TestReadChromeProfileNotFound(t *testing.T) {
+	_, err := ReadChrome(t.TempDir())
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("got %+v; want %+v", err, ErrProfileNotFound)
+	}
+}
@@ -0,0 +1,6 @@
+// browser contains the logic to read bookmarks directly out of browser profiles.
+// Firefox keeps its bookmarks in a SQLite database called places.sqlite.
+// Chrome (and Chromium) keep their bookmarks in a JSON file called Bookmarks.
+// Both are read into the same tree of nodes the Netscape bookmark file parser produces.
+// It can also open URLs and files in the default browser.
+package browser
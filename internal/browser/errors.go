@@ -0,0 +1,9 @@
+package browser
+
+import "errors"
+
+// ErrProfileNotFound is returned when a browser profile doesn't exist or doesn't have bookmarks.
+var ErrProfileNotFound = errors.New("browser profile not found")
+
+// ErrProfileLocked is returned when a browser has its bookmarks locked and they can't be read.
+var ErrProfileLocked = errors.New("browser profile locked")
@@ -0,0 +1,288 @@
+package browser
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/netscape"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// firefoxDatabase is the name of the database Firefox keeps its bookmarks in.
+const firefoxDatabase = "places.sqlite"
+
+// Firefox identifies its built in folders with fixed GUIDs.
+const (
+	firefoxMenuGUID    = "menu________"
+	firefoxToolbarGUID = "toolbar_____"
+	firefoxTagsGUID    = "tags________"
+	firefoxUnfiledGUID = "unfiled_____"
+	firefoxMobileGUID  = "mobile______"
+)
+
+// Each row in moz_bookmarks has a type.
+const (
+	firefoxTypeBookmark = 1 // a bookmark; its URL is in moz_places
+	firefoxTypeFolder   = 2 // a folder
+)
+
+// firefoxRoot is a built in Firefox folder that gets imported.
+type firefoxRoot struct {
+	guid string // GUID Firefox uses for the folder
+	name string // name the folder is imported with
+}
+
+// firefoxRoots are the built in folders that are imported (in the order they are imported).
+// The tags folder isn't imported as a folder; its contents are used to tag the bookmarks instead.
+var firefoxRoots = []firefoxRoot{
+	{guid: firefoxToolbarGUID, name: "Bookmarks Toolbar"},
+	{guid: firefoxMenuGUID, name: "Bookmarks Menu"},
+	{guid: firefoxUnfiledGUID, name: "Other Bookmarks"},
+	{guid: firefoxMobileGUID, name: "Mobile Bookmarks"},
+}
+
+// firefoxBookmark is a row in moz_bookmarks joined to moz_places.
+type firefoxBookmark struct {
+	id      int64  // ID of the bookmark/folder
+	kind    int64  // type of the row (bookmark, folder, or separator)
+	parent  int64  // ID of the parent folder
+	placeID int64  // ID of the row in moz_places; 0 for folders
+	title   string // name of the bookmark/folder
+	url     string // address of the bookmark; empty for folders
+	guid    string // GUID of the bookmark/folder
+}
+
+// ReadFirefox reads the bookmarks out of a Firefox profile.
+// The built in toolbar, menu, other, and mobile folders are rebuilt as folders.
+// Firefox tags and keywords are converted to tags.
+// The database is opened read only so Firefox's data is never modified.
+// Firefox keeps the database locked while it's running so a copy of it is read instead when it's locked.
+func ReadFirefox(profile string) ([]*netscape.Node, error) {
+	path := filepath.Join(profile, firefoxDatabase)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrProfileNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("error checking database while reading firefox bookmarks: %w", err)
+	}
+
+	bookmarks, tags, err := readFirefoxDatabase(path, "mode=ro")
+	if isLocked(err) {
+		bookmarks, tags, err = readFirefoxCopy(path)
+	}
+	if isLocked(err) {
+		return nil, ErrProfileLocked
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading database while reading firefox bookmarks: %w", err)
+	}
+
+	children := make(map[int64][]firefoxBookmark)
+	roots := make(map[string]int64)
+	for _, bookmark := range bookmarks {
+		children[bookmark.parent] = append(children[bookmark.parent], bookmark)
+		roots[bookmark.guid] = bookmark.id
+	}
+
+	// Each tag is a folder under the tags folder.
+	// The tag folder has a bookmark in it for every URL the tag is applied to.
+	if tagsID, ok := roots[firefoxTagsGUID]; ok {
+		for _, tag := range children[tagsID] {
+			for _, bookmark := range children[tag.id] {
+				tags[bookmark.placeID] = append(tags[bookmark.placeID], tag.title)
+			}
+		}
+	}
+
+	nodes := make([]*netscape.Node, 0)
+	for _, root := range firefoxRoots {
+		id, ok := roots[root.guid]
+		if !ok {
+			continue
+		}
+
+		folder := &netscape.Node{
+			IsFolder: true,
+			Name:     root.name,
+			Children: firefoxNodes(children, tags, id),
+		}
+
+		if len(folder.Children) > 0 {
+			nodes = append(nodes, folder)
+		}
+	}
+
+	return nodes, nil
+}
+
+// readFirefoxDatabase gets the bookmarks and keywords out of a Firefox database.
+// Keywords are converted to tags so they are returned as the starting point for the tags.
+func readFirefoxDatabase(path string, query string) ([]firefoxBookmark, map[int64][]string, error) {
+	dsn := url.URL{Scheme: "file", Path: filepath.ToSlash(path), RawQuery: query}
+	db, err := sql.Open("sqlite", dsn.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening database: %w", err)
+	}
+	defer db.Close()
+
+	bookmarks, err := firefoxBookmarks(db)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting bookmarks: %w", err)
+	}
+
+	tags, err := firefoxKeywords(db)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting keywords: %w", err)
+	}
+
+	return bookmarks, tags, nil
+}
+
+// readFirefoxCopy reads a copy of a Firefox database that's locked.
+// Recent changes may only be in the write-ahead log so it's copied too (if there is one).
+func readFirefoxCopy(path string) ([]firefoxBookmark, map[int64][]string, error) {
+	dir, err := os.MkdirTemp("", "armaria-firefox-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	copied := filepath.Join(dir, firefoxDatabase)
+	if err := copyFile(path, copied); err != nil {
+		return nil, nil, fmt.Errorf("error copying database: %w", err)
+	}
+	if err := copyFile(path+"-wal", copied+"-wal"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("error copying write-ahead log: %w", err)
+	}
+
+	return readFirefoxDatabase(copied, "")
+}
+
+// copyFile copies the file at src to dst.
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// isLocked returns true if err is SQLite reporting that a database is locked.
+func isLocked(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	code := sqliteErr.Code() & 0xff
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
+
+// firefoxNodes converts the children of a Firefox folder into nodes.
+func firefoxNodes(children map[int64][]firefoxBookmark, tags map[int64][]string, parent int64) []*netscape.Node {
+	nodes := make([]*netscape.Node, 0)
+
+	for _, bookmark := range children[parent] {
+		switch bookmark.kind {
+
+		case firefoxTypeFolder:
+			nodes = append(nodes, &netscape.Node{
+				IsFolder: true,
+				Name:     bookmark.title,
+				Children: firefoxNodes(children, tags, bookmark.id),
+			})
+
+		case firefoxTypeBookmark:
+			// Firefox has smart bookmarks with place: URLs.
+			// They only make sense inside of Firefox so they are skipped.
+			if bookmark.url == "" || strings.HasPrefix(bookmark.url, "place:") {
+				continue
+			}
+
+			nodes = append(nodes, &netscape.Node{
+				Name: bookmark.title,
+				URL:  bookmark.url,
+				Tags: append(make([]string, 0), tags[bookmark.placeID]...),
+			})
+		}
+	}
+
+	return nodes
+}
+
+// firefoxBookmarks gets every bookmark/folder in a Firefox database.
+// They are ordered by the position they have in their parent folder.
+func firefoxBookmarks(db *sql.DB) ([]firefoxBookmark, error) {
+	rows, err := db.Query(`
+		SELECT "b"."id", "b"."type", "b"."parent", IFNULL("b"."fk", 0), IFNULL("b"."title", ''), IFNULL("p"."url", ''), IFNULL("b"."guid", '')
+		FROM "moz_bookmarks" AS "b"
+		LEFT JOIN "moz_places" AS "p" ON "p"."id" = "b"."fk"
+		ORDER BY "b"."parent", "b"."position"`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying bookmarks: %w", err)
+	}
+	defer rows.Close()
+
+	bookmarks := make([]firefoxBookmark, 0)
+	for rows.Next() {
+		var bookmark firefoxBookmark
+		if err := rows.Scan(&bookmark.id, &bookmark.kind, &bookmark.parent, &bookmark.placeID, &bookmark.title, &bookmark.url, &bookmark.guid); err != nil {
+			return nil, fmt.Errorf("error scanning bookmark: %w", err)
+		}
+
+		bookmarks = append(bookmarks, bookmark)
+	}
+
+	return bookmarks, rows.Err()
+}
+
+// firefoxKeywords gets the keywords in a Firefox database grouped by the place they apply to.
+// Older versions of Firefox don't have the moz_keywords table so no keywords are returned for them.
+func firefoxKeywords(db *sql.DB) (map[int64][]string, error) {
+	keywords := make(map[int64][]string)
+
+	var exists int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM "sqlite_master" WHERE "type" = 'table' AND "name" = 'moz_keywords'`).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("error checking for keywords: %w", err)
+	}
+
+	if exists == 0 {
+		return keywords, nil
+	}
+
+	rows, err := db.Query(`SELECT "place_id", "keyword" FROM "moz_keywords" ORDER BY "keyword"`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying keywords: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var placeID int64
+		var keyword string
+		if err := rows.Scan(&placeID, &keyword); err != nil {
+			return nil, fmt.Errorf("error scanning keyword: %w", err)
+		}
+
+		keywords[placeID] = append(keywords[placeID], keyword)
+	}
+
+	return keywords, rows.Err()
+}
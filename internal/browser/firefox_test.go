@@ -0,0 +1,157 @@
+package browser
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jonathanhope/armaria/internal/netscape"
+)
+
+func TestReadFirefox(t *testing.T) {
+	profile := t.TempDir()
+
+	db, err := sql.Open("sqlite", filepath.Join(profile, firefoxDatabase))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer db.Close()
+
+	// This is a cut down version of the places.sqlite schema.
+	statements := []string{
+		`CREATE TABLE moz_places (id INTEGER PRIMARY KEY, url TEXT)`,
+		`CREATE TABLE moz_bookmarks (id INTEGER PRIMARY KEY, type INTEGER, fk INTEGER, parent INTEGER, position INTEGER, title TEXT, guid TEXT)`,
+		`CREATE TABLE moz_keywords (id INTEGER PRIMARY KEY, keyword TEXT, place_id INTEGER)`,
+		`INSERT INTO moz_places VALUES (1, 'https://jho.pe'), (2, 'https://armaria.net'), (3, 'place:sort=8')`,
+		`INSERT INTO moz_bookmarks VALUES
+			(1, 2, NULL, 0, 0, '', 'root________'),
+			(2, 2, NULL, 1, 0, 'menu', 'menu________'),
+			(3, 2, NULL, 1, 1, 'toolbar', 'toolbar_____'),
+			(4, 2, NULL, 1, 2, 'tags', 'tags________'),
+			(5, 2, NULL, 1, 3, 'unfiled', 'unfiled_____'),
+			(6, 2, NULL, 1, 4, 'mobile', 'mobile______'),
+			(7, 2, NULL, 3, 1, 'blogs', 'a'),
+			(8, 1, 1, 7, 0, 'The Flat Field', 'b'),
+			(9, 1, 2, 3, 0, 'Armaria', 'c'),
+			(10, 3, NULL, 3, 2, NULL, 'd'),
+			(11, 1, 3, 2, 0, 'Recent Tags', 'e'),
+			(12, 2, NULL, 4, 0, 'programming', 'f'),
+			(13, 1, 1, 12, 0, NULL, 'g'),
+			(14, 1, 2, 5, 0, NULL, 'h')`,
+		`INSERT INTO moz_keywords VALUES (1, 'jho', 1)`,
+	}
+
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	got, err := ReadFirefox(profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []*netscape.Node{
+		{
+			IsFolder: true,
+			Name:     "Bookmarks Toolbar",
+			Children: []*netscape.Node{
+				{
+					Name: "Armaria",
+					URL:  "https://armaria.net",
+					Tags: []string{},
+				},
+				{
+					IsFolder: true,
+					Name:     "blogs",
+					Children: []*netscape.Node{
+						{
+							Name: "The Flat Field",
+							URL:  "https://jho.pe",
+							Tags: []string{"jho", "programming"},
+						},
+					},
+				},
+			},
+		},
+		{
+			IsFolder: true,
+			Name:     "Other Bookmarks",
+			Children: []*netscape.Node{
+				{
+					URL:  "https://armaria.net",
+					Tags: []string{},
+				},
+			},
+		},
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual nodes different:\n%s", diff)
+	}
+}
+
+func TestReadFirefoxProfileNotFound(t *testing.T) {
+	_, err := ReadFirefox(t.TempDir())
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("got %+v; want %+v", err, ErrProfileNotFound)
+	}
+}
+
+func TestReadFirefoxWhileLocked(t *testing.T) {
+	profile := t.TempDir()
+
+	db, err := sql.Open("sqlite", filepath.Join(profile, firefoxDatabase))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer db.Close()
+
+	// Firefox holds an exclusive lock on the database and keeps recent changes in the write-ahead log.
+	db.SetMaxOpenConns(1)
+	statements := []string{
+		`PRAGMA journal_mode = WAL`,
+		`PRAGMA locking_mode = EXCLUSIVE`,
+		`CREATE TABLE moz_places (id INTEGER PRIMARY KEY, url TEXT)`,
+		`CREATE TABLE moz_bookmarks (id INTEGER PRIMARY KEY, type INTEGER, fk INTEGER, parent INTEGER, position INTEGER, title TEXT, guid TEXT)`,
+		`INSERT INTO moz_places VALUES (1, 'https://jho.pe')`,
+		`INSERT INTO moz_bookmarks VALUES
+			(1, 2, NULL, 0, 0, '', 'root________'),
+			(2, 2, NULL, 1, 0, 'toolbar', 'toolbar_____'),
+			(3, 1, 1, 2, 0, 'The Flat Field', 'a')`,
+	}
+
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	got, err := ReadFirefox(profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []*netscape.Node{
+		{
+			IsFolder: true,
+			Name:     "Bookmarks Toolbar",
+			Children: []*netscape.Node{
+				{
+					Name: "The Flat Field",
+					URL:  "https://jho.pe",
+					Tags: []string{},
+				},
+			},
+		},
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual nodes different:\n%s", diff)
+	}
+}
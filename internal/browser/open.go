@@ -0,0 +1,25 @@
+package browser
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// Open opens a URL or file in the default browser.
+func Open(target string) error {
+	var cmd string
+	var args []string
+
+	switch runtime.GOOS {
+	case "windows":
+		cmd = "cmd"
+		args = []string{"/c", "start"}
+	case "darwin":
+		cmd = "open"
+	default: // "linux", "freebsd", "openbsd", "netbsd"
+		cmd = "xdg-open"
+	}
+
+	args = append(args, target)
+	return exec.Command(cmd, args...).Start()
+}
@@ -0,0 +1,86 @@
+package browser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// firefoxProfilesFile is the name of the file Firefox lists its profiles in.
+const firefoxProfilesFile = "profiles.ini"
+
+// FirefoxDefaultProfile finds the default profile in the folder Firefox keeps its profiles in.
+// Each Firefox install has its own default profile; the first one listed is used.
+// Older versions of Firefox mark the default profile instead.
+func FirefoxDefaultProfile(folder string) (string, error) {
+	file, err := os.Open(filepath.Join(folder, firefoxProfilesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrProfileNotFound
+	} else if err != nil {
+		return "", fmt.Errorf("error opening profiles while getting default firefox profile: %w", err)
+	}
+	defer file.Close()
+
+	sections, err := parseINI(file)
+	if err != nil {
+		return "", fmt.Errorf("error parsing profiles while getting default firefox profile: %w", err)
+	}
+
+	var profile string
+	for _, section := range sections {
+		if strings.HasPrefix(section.name, "Install") && section.values["Default"] != "" {
+			profile = section.values["Default"]
+			break
+		}
+	}
+
+	if profile == "" {
+		for _, section := range sections {
+			if strings.HasPrefix(section.name, "Profile") && (profile == "" || section.values["Default"] == "1") {
+				profile = section.values["Path"]
+			}
+		}
+	}
+
+	if profile == "" {
+		return "", ErrProfileNotFound
+	}
+
+	if filepath.IsAbs(profile) {
+		return profile, nil
+	}
+
+	return filepath.Join(folder, filepath.FromSlash(profile)), nil
+}
+
+// iniSection is a section in an INI file.
+type iniSection struct {
+	name   string            // name of the section without the brackets
+	values map[string]string // key value pairs in the section
+}
+
+// parseINI parses the sections of an INI file in the order they appear.
+func parseINI(reader io.Reader) ([]iniSection, error) {
+	sections := make([]iniSection, 0)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			sections = append(sections, iniSection{
+				name:   strings.TrimSuffix(strings.TrimPrefix(line, "["), "]"),
+				values: make(map[string]string),
+			})
+		} else if key, value, ok := strings.Cut(line, "="); ok && len(sections) > 0 {
+			sections[len(sections)-1].values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return sections, scanner.Err()
+}
@@ -0,0 +1,88 @@
+package browser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFirefoxDefaultProfile(t *testing.T) {
+	type test struct {
+		name     string
+		profiles string
+		want     string
+	}
+
+	tests := []test{
+		{
+			name: "install default",
+			profiles: `[Profile1]
+Name=default
+IsRelative=1
+Path=Profiles/abc.default
+Default=1
+
+[Profile0]
+Name=default-release
+IsRelative=1
+Path=Profiles/xyz.default-release
+
+[Install4F96D1932A9F858E]
+Default=Profiles/xyz.default-release
+Locked=1`,
+			want: "Profiles/xyz.default-release",
+		},
+		{
+			name: "profile default",
+			profiles: `[General]
+StartWithLastProfile=1
+
+[Profile0]
+Name=other
+IsRelative=1
+Path=Profiles/123.other
+
+[Profile1]
+Name=default
+IsRelative=1
+Path=Profiles/abc.default
+Default=1`,
+			want: "Profiles/abc.default",
+		},
+		{
+			name: "first profile",
+			profiles: `[Profile0]
+Name=default
+IsRelative=1
+Path=Profiles/abc.default`,
+			want: "Profiles/abc.default",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			folder := t.TempDir()
+			if err := os.WriteFile(filepath.Join(folder, firefoxProfilesFile), []byte(tc.profiles), 0600); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := FirefoxDefaultProfile(folder)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			want := filepath.Join(folder, filepath.FromSlash(tc.want))
+			if got != want {
+				t.Errorf("got %+v; want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestFirefoxDefaultProfileNotFound(t *testing.T) {
+	_, err := FirefoxDefaultProfile(t.TempDir())
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("got %+v; want %+v", err, ErrProfileNotFound)
+	}
+}
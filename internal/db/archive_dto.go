@@ -0,0 +1,13 @@
+package db
+
+// ArchiveDTO is a DTO for a row of the archives table.
+type ArchiveDTO struct {
+	BookmarkID  string `db:"bookmark_id"`
+	URL         string `db:"url"`
+	ContentType string `db:"content_type"`
+	Content     []byte `db:"content"`
+	Text        string `db:"text"`
+	Inlined     bool   `db:"inlined"`
+	Archived    string `db:"archived"`
+	Size        int64  `db:"size"`
+}
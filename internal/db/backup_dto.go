@@ -0,0 +1,33 @@
+package db
+
+import (
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// BookmarkRowDTO is a DTO for a row of the bookmarks table.
+type BookmarkRowDTO struct {
+	ID           string          `db:"id"`
+	ParentID     null.NullString `db:"parent_id"`
+	IsFolder     bool            `db:"is_folder"`
+	Name         string          `db:"name"`
+	URL          null.NullString `db:"url"`
+	Description  null.NullString `db:"description"`
+	Created      string          `db:"created"`
+	Modified     string          `db:"modified"`
+	Order        string          `db:"order"`
+	DeletedAt    null.NullString `db:"deleted_at"`
+	DeletedBatch null.NullString `db:"deleted_batch"`
+}
+
+// TagRowDTO is a DTO for a row of the tags table.
+type TagRowDTO struct {
+	Tag      string `db:"tag"`
+	Modified string `db:"modified"`
+}
+
+// BookmarkTagRowDTO is a DTO for a row of the bookmarks_tags table.
+type BookmarkTagRowDTO struct {
+	BookmarkID string `db:"bookmark_id"`
+	Tag        string `db:"tag"`
+	Modified   string `db:"modified"`
+}
@@ -6,13 +6,20 @@ import (
 
 // BookDTO is a DTO to stuff DB results into.
 type BookDTO struct {
-	ID          string          `db:"id"`
-	URL         null.NullString `db:"url"`
-	Name        string          `db:"name"`
-	Description null.NullString `db:"description"`
-	ParentID    null.NullString `db:"parent_id"`
-	IsFolder    bool            `db:"is_folder"`
-	Order       string          `db:"order"`
-	ParentName  null.NullString `db:"parent_name"`
-	Tags        string          `db:"tags"`
+	ID           string          `db:"id"`
+	URL          null.NullString `db:"url"`
+	Name         string          `db:"name"`
+	Description  null.NullString `db:"description"`
+	ParentID     null.NullString `db:"parent_id"`
+	IsFolder     bool            `db:"is_folder"`
+	Order        string          `db:"order"`
+	Created      string          `db:"created"`
+	Modified     string          `db:"modified"`
+	DeletedAt    null.NullString `db:"deleted_at"`
+	DeletedBatch null.NullString `db:"deleted_batch"`
+	ParentName   null.NullString `db:"parent_name"`
+	Tags         string          `db:"tags"`
+	Snippet      null.NullString `db:"snippet"`
+	Depth        null.NullInt64  `db:"depth"`
+	Path         null.NullString `db:"path"`
 }
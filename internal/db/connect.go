@@ -5,6 +5,7 @@ import (
 	"embed"
 	"fmt"
 
+	_ "github.com/jonathanhope/armaria/internal/db/migrations"
 	"github.com/jonathanhope/armaria/internal/null"
 	"github.com/jonathanhope/armaria/internal/paths"
 	"github.com/pressly/goose/v3"
@@ -20,22 +21,30 @@ type connectDBFn func(inputPath null.NullString, configPath string) (*sql.DB, er
 // connectDB returns a connection to the bookmarks database.
 // The DB will be created if it doesn't already exist.
 // The DB will also be migrated up to the latest version, and have its PRAGMAS properly set.
+// If there is a session the same connection is returned every time.
 func connectDB(inputPath null.NullString, configPath string) (*sql.DB, error) {
 	dbLocation, err := paths.Database(inputPath, configPath)
 	if err != nil {
 		return nil, fmt.Errorf("error getting database location wile connecting to database: %w", err)
 	}
 
+	return sessionDB(dbLocation, openDB)
+}
+
+// openDB opens, configures, and migrates the database at a location.
+func openDB(dbLocation string) (*sql.DB, error) {
 	db, err := sql.Open("sqlite", dbLocation)
 	if err != nil {
 		return nil, fmt.Errorf("error while connecting to database: %w", err)
 	}
 
 	if err := configureDB(db); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("error configuring database wile connecting to database: %w", err)
 	}
 
 	if err := migrateDB(db); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("error applying migrations to database wile connecting to database: %w", err)
 	}
 
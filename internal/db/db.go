@@ -1,11 +1,14 @@
 package db
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jonathanhope/armaria/internal/normalize"
 	"github.com/jonathanhope/armaria/internal/null"
 	"github.com/nullism/bqb"
+	"github.com/samber/lo"
 )
 
 // create
@@ -14,8 +17,8 @@ import (
 func AddBook(tx Transaction, url string, name string, description null.NullString, parentID null.NullString, order string) (string, error) {
 	id := uuid.New().String()
 
-	insert := bqb.New(`INSERT INTO "bookmarks"("id", "url", "is_folder", "name", "description", "parent_id", "order")`)
-	insert.Space("VALUES(?, ?, ?, ?, ?, ?, ?)", id, url, false, name, description, parentID, order)
+	insert := bqb.New(`INSERT INTO "bookmarks"("id", "url", "normalized_url", "is_folder", "name", "description", "parent_id", "order")`)
+	insert.Space("VALUES(?, ?, ?, ?, ?, ?, ?, ?)", id, url, normalize.URL(url), false, name, description, parentID, order)
 
 	err := exec(tx, insert)
 	return id, err
@@ -61,42 +64,262 @@ func LinkTags(tx Transaction, bookmarkID string, tags []string) error {
 		insert.Comma(`(?, ?)`, bookmarkID, tag)
 	}
 
+	if err := exec(tx, insert); err != nil {
+		return err
+	}
+
+	return touch(tx, []string{bookmarkID})
+}
+
+// UpsertBookmarkRow inserts a row into the bookmarks table as is.
+// If a row with the same ID already exists it is overwritten.
+func UpsertBookmarkRow(tx Transaction, row BookmarkRowDTO) error {
+	insert := bqb.New(`INSERT INTO "bookmarks"("id", "parent_id", "is_folder", "name", "url", "normalized_url", "description", "created", "modified", "order", "deleted_at", "deleted_batch")`)
+	insert.Space(`VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, row.ID, row.ParentID, row.IsFolder, row.Name, row.URL, normalizedURL(row.URL), row.Description, row.Created, row.Modified, row.Order, row.DeletedAt, row.DeletedBatch)
+	insert.Space(`ON CONFLICT("id") DO UPDATE`)
+	insert.Space(`SET "parent_id" = "excluded"."parent_id"`)
+	insert.Comma(`"is_folder" = "excluded"."is_folder"`)
+	insert.Comma(`"name" = "excluded"."name"`)
+	insert.Comma(`"url" = "excluded"."url"`)
+	insert.Comma(`"normalized_url" = "excluded"."normalized_url"`)
+	insert.Comma(`"description" = "excluded"."description"`)
+	insert.Comma(`"created" = "excluded"."created"`)
+	insert.Comma(`"modified" = "excluded"."modified"`)
+	insert.Comma(`"order" = "excluded"."order"`)
+	insert.Comma(`"deleted_at" = "excluded"."deleted_at"`)
+	insert.Comma(`"deleted_batch" = "excluded"."deleted_batch"`)
+
+	return exec(tx, insert)
+}
+
+// normalizedURL normalizes a URL that might be NULL so it can be stored alongside it.
+func normalizedURL(url null.NullString) null.NullString {
+	if !url.Valid {
+		return url
+	}
+
+	return null.NullStringFrom(normalize.URL(url.String))
+}
+
+// UpsertTagRow inserts a row into the tags table as is.
+// If the tag already exists its modified timestamp is overwritten.
+func UpsertTagRow(tx Transaction, row TagRowDTO) error {
+	insert := bqb.New(`INSERT INTO "tags"("tag", "modified")`)
+	insert.Space(`VALUES(?, ?)`, row.Tag, row.Modified)
+	insert.Space(`ON CONFLICT("tag") DO UPDATE`)
+	insert.Space(`SET "modified" = "excluded"."modified"`)
+
 	return exec(tx, insert)
 }
 
+// UpsertBookmarkTagRow inserts a row into the bookmarks_tags table as is.
+// If the tag is already applied to the bookmark its modified timestamp is overwritten.
+func UpsertBookmarkTagRow(tx Transaction, row BookmarkTagRowDTO) error {
+	insert := bqb.New(`INSERT INTO "bookmarks_tags"("bookmark_id", "tag", "modified")`)
+	insert.Space(`VALUES(?, ?, ?)`, row.BookmarkID, row.Tag, row.Modified)
+	insert.Space(`ON CONFLICT("bookmark_id", "tag") DO UPDATE`)
+	insert.Space(`SET "modified" = "excluded"."modified"`)
+
+	return exec(tx, insert)
+}
+
+// AddLinkCheck records the outcome of checking a bookmark's URL.
+func AddLinkCheck(tx Transaction, check LinkCheckDTO) error {
+	insert := bqb.New(`INSERT INTO "link_checks"("bookmark_id", "status", "redirect", "error", "checked")`)
+	insert.Space(`VALUES(?, ?, ?, ?, ?)`, check.BookmarkID, check.Status, check.Redirect, check.Error, check.Checked)
+
+	return exec(tx, insert)
+}
+
+// UpsertArchive stores an archived copy of a bookmark's page.
+// If the bookmark was already archived the old copy is replaced.
+func UpsertArchive(tx Transaction, archive ArchiveDTO) error {
+	insert := bqb.New(`INSERT INTO "archives"("bookmark_id", "url", "content_type", "content", "text", "inlined", "archived")`)
+	insert.Space(`VALUES(?, ?, ?, ?, ?, ?, ?)`, archive.BookmarkID, archive.URL, archive.ContentType, archive.Content, archive.Text, archive.Inlined, archive.Archived)
+	insert.Space(`ON CONFLICT("bookmark_id") DO UPDATE`)
+	insert.Space(`SET "url" = "excluded"."url"`)
+	insert.Comma(`"content_type" = "excluded"."content_type"`)
+	insert.Comma(`"content" = "excluded"."content"`)
+	insert.Comma(`"text" = "excluded"."text"`)
+	insert.Comma(`"inlined" = "excluded"."inlined"`)
+	insert.Comma(`"archived" = "excluded"."archived"`)
+
+	return exec(tx, insert)
+}
+
+// UpsertFavicon stores the icon of a bookmark.
+// Icons are stored once per hash no matter how many bookmarks use them.
+// If the bookmark already had an icon it's replaced.
+func UpsertFavicon(tx Transaction, favicon FaviconDTO) error {
+	icon := bqb.New(`INSERT INTO "favicons"("hash", "content_type", "content")`)
+	icon.Space(`VALUES(?, ?, ?)`, favicon.Hash, favicon.ContentType, favicon.Content)
+	icon.Space(`ON CONFLICT("hash") DO NOTHING`)
+
+	if err := exec(tx, icon); err != nil {
+		return err
+	}
+
+	link := bqb.New(`INSERT INTO "bookmarks_favicons"("bookmark_id", "hash", "url", "fetched")`)
+	link.Space(`VALUES(?, ?, ?, ?)`, favicon.BookmarkID, favicon.Hash, favicon.URL, favicon.Fetched)
+	link.Space(`ON CONFLICT("bookmark_id") DO UPDATE`)
+	link.Space(`SET "hash" = "excluded"."hash"`)
+	link.Comma(`"url" = "excluded"."url"`)
+	link.Comma(`"fetched" = "excluded"."fetched"`)
+
+	return exec(tx, link)
+}
+
+// AddJournalEntry records an operation in the journal.
+// Operations that were undone can no longer be redone once a new operation is recorded.
+// Only the most recent operations are kept.
+func AddJournalEntry(tx Transaction, entry JournalEntryDTO, keep int) error {
+	if err := exec(tx, bqb.New(`DELETE FROM "journal" WHERE "undone" = ?`, true)); err != nil {
+		return err
+	}
+
+	insert := bqb.New(`INSERT INTO "journal"("operation", "description", "before", "after")`)
+	insert.Space(`VALUES(?, ?, ?, ?)`, entry.Operation, entry.Description, entry.Before, entry.After)
+
+	if err := exec(tx, insert); err != nil {
+		return err
+	}
+
+	recent := bqb.New(`SELECT "id"`)
+	recent.Space(`FROM "journal"`)
+	recent.Space(`ORDER BY "id" DESC`)
+	recent.Space(`LIMIT ?`, keep)
+
+	trim := bqb.New(`DELETE FROM "journal"`)
+	trim.Space(`WHERE "id" NOT IN (?)`, recent)
+
+	return exec(tx, trim)
+}
+
 // read
 
+// HighlightStart and HighlightEnd surround the matched text in search snippets.
+const (
+	HighlightStart = "<mark>"
+	HighlightEnd   = "</mark>"
+)
+
 // GetBooksArgs are the args for getBooksDB.
 type GetBooksArgs struct {
 	IDFilter       string
 	IncludeBooks   bool
 	IncludeFolders bool
 	ParentID       null.NullString
-	Query          null.NullString
+	Filter         *bqb.Query      // predicate built from filters such as TagFilter
+	Match          null.NullString // FTS5 MATCH expression used to rank results and build snippets
 	Tags           []string
+	TagMode        TagMode
+	ExcludeTags    []string
+	Recursive      bool           // include everything under ParentID instead of just its direct children
+	MaxDepth       null.NullInt64 // how many levels deep to go when recursive
 	After          null.NullString
 	Order          Order
 	Direction      Direction
 	First          null.NullInt64
+	IncludeDeleted bool // include bookmarks/folders in the trash
+	DeletedOnly    bool // only include bookmarks/folders in the trash
 }
 
 // GetBooks lists bookmarks/folders in the bookmarks DB.
 func GetBooks(tx Transaction, args GetBooksArgs) ([]BookDTO, error) {
+	matching := args.Match.Dirty && args.Match.Valid
+
+	// There is nothing to rank results on without a search.
+	if args.Order == OrderRelevance && !matching {
+		args.Order = OrderManual
+	}
+
 	tags := bqb.New(`SELECT GROUP_CONCAT("tag")`)
 	tags.Space(`FROM "bookmarks_tags"`)
 	tags.Space(`WHERE "bookmark_id" = "child"."id"`)
 
-	books := bqb.New(`SELECT "child"."id"`)
+	books := bqb.New("")
+	with := bqb.Optional("WITH RECURSIVE")
+
+	if args.Recursive {
+		// Paths start at the top level so the names of the folder being listed are needed.
+		path := make([]string, 0)
+		if args.ParentID.Dirty && args.ParentID.Valid {
+			names, err := GetBookFolderParents(tx, args.ParentID.String)
+			if err != nil {
+				return nil, err
+			}
+			path = lo.Reverse(names)
+		}
+
+		pathJSON, err := json.Marshal(path)
+		if err != nil {
+			return nil, err
+		}
+
+		// Results are sorted depth first by joining the manual orders of their ancestors.
+		first := bqb.New(`SELECT "id", 1, json_insert(?, '$[#]', "name"), "order"`, string(pathJSON))
+		first.Space(`FROM "bookmarks"`)
+		if args.ParentID.Dirty && args.ParentID.Valid {
+			first.Space(`WHERE "parent_id" = ?`, args.ParentID.String)
+		} else {
+			first.Space(`WHERE "parent_id" IS NULL`)
+		}
+
+		rest := bqb.New(`SELECT "bookmarks"."id"`)
+		rest.Comma(`"tree"."depth" + 1`)
+		rest.Comma(`json_insert("tree"."path", '$[#]', "bookmarks"."name")`)
+		rest.Comma(`"tree"."sort" || ' ' || "bookmarks"."order"`)
+		rest.Space(`FROM "bookmarks"`)
+		rest.Space(`INNER JOIN "tree" ON "bookmarks"."parent_id" = "tree"."id"`)
+		if args.MaxDepth.Dirty && args.MaxDepth.Valid {
+			rest.Space(`WHERE "tree"."depth" < ?`, args.MaxDepth.Int64)
+		}
+
+		with.Comma(`"tree"("id", "depth", "path", "sort") AS (? UNION ALL ?)`, first, rest)
+	}
+
+	if matching {
+		// Matches in the name are weighted the highest, then the URL, then the description, then archived text.
+		// Lower bm25 scores are better matches; results that don't match at all score 0.
+		search := bqb.New(`SELECT "id"`)
+		search.Comma(`bm25("bookmarks_fts", 0.0, 10.0, 5.0, 1.0, 0.5) AS "rank"`)
+		search.Comma(`snippet("bookmarks_fts", -1, ?, ?, '…', 64) AS "snippet"`, HighlightStart, HighlightEnd)
+		search.Space(`FROM "bookmarks_fts"`)
+		search.Space(`WHERE "bookmarks_fts" MATCH ?`, args.Match.String)
+
+		with.Comma(`"search" AS (?)`, search)
+	}
+
+	books.Space(`?`, with)
+	books.Space(`SELECT "child"."id"`)
 	books.Comma(`"child"."url"`)
 	books.Comma(`"child"."name"`)
 	books.Comma(`"child"."description"`)
 	books.Comma(`"child"."parent_id"`)
 	books.Comma(`"child"."is_folder"`)
 	books.Comma(`"child"."order"`)
+	books.Comma(`"child"."created"`)
+	books.Comma(`"child"."modified"`)
+	books.Comma(`"child"."deleted_at"`)
+	books.Comma(`"child"."deleted_batch"`)
 	books.Comma(`"parent"."name" AS "parent_name"`)
 	books.Comma(`IFNULL((?), '') AS "tags"`, tags)
+	if matching {
+		books.Comma(`"search"."snippet"`)
+	}
+	if args.Recursive {
+		books.Comma(`"tree"."depth"`)
+		books.Comma(`"tree"."path"`)
+	}
 	books.Space(`FROM "bookmarks" AS "child"`)
 	books.Space(`LEFT JOIN "bookmarks" AS "parent" ON "parent"."id" = "child"."parent_id"`)
+	if args.Recursive {
+		books.Space(`INNER JOIN "tree" ON "tree"."id" = "child"."id"`)
+	}
+	if matching {
+		// Results are filtered separately so results that don't match are kept and ranked last.
+		books.Space(`LEFT JOIN "search" ON "search"."id" = "child"."id"`)
+	}
 
 	where := bqb.Optional("WHERE")
 
@@ -104,6 +327,12 @@ func GetBooks(tx Transaction, args GetBooksArgs) ([]BookDTO, error) {
 		where.And(`"child"."id" = ?`, args.IDFilter)
 	}
 
+	if args.DeletedOnly {
+		where.And(`"child"."deleted_at" IS NOT NULL`)
+	} else if !args.IncludeDeleted {
+		where.And(`"child"."deleted_at" IS NULL`)
+	}
+
 	if args.IncludeBooks && !args.IncludeFolders {
 		where.And(`"child"."is_folder" = ?`, false)
 	}
@@ -112,31 +341,43 @@ func GetBooks(tx Transaction, args GetBooksArgs) ([]BookDTO, error) {
 		where.And(`"child"."is_folder" = ?`, true)
 	}
 
-	if args.ParentID.Dirty && args.ParentID.Valid {
+	// When recursive the tree is already limited to the parent.
+	if !args.Recursive && args.ParentID.Dirty && args.ParentID.Valid {
 		where.And(`"child"."parent_id" = ?`, args.ParentID.String)
-	} else if args.ParentID.Dirty && !args.ParentID.Valid {
+	} else if !args.Recursive && args.ParentID.Dirty && !args.ParentID.Valid {
 		where.And(`"child"."parent_id" IS NULL`)
 	}
 
-	if args.Query.Dirty && args.Query.Valid {
-		searchFilter := bqb.New(`SELECT "id"`)
-		searchFilter.Space(`FROM "bookmarks_fts"`)
-		searchFilter.Space(`WHERE "name" LIKE ?`, fmt.Sprintf("%%%s%%", args.Query.String))
-		searchFilter.Space(`OR "description" LIKE ?`, fmt.Sprintf("%%%s%%", args.Query.String))
-		searchFilter.Space(`OR "url" LIKE ?`, fmt.Sprintf("%%%s%%", args.Query.String))
-
-		where.And(`"child"."id" IN (?)`, searchFilter)
+	if args.Filter != nil {
+		where.And(`?`, args.Filter)
 	}
 
-	if len(args.Tags) > 0 {
-		tagsFilter := bqb.New(`SELECT "bookmark_id"`)
-		tagsFilter.Space(`FROM "bookmarks_tags"`)
-		tagsFilter.Space(`WHERE "tag" IN (?)`, args.Tags)
+	// Bookmarks must match each of the tags separately to have all of them.
+	// A pattern such as lang/* is satisfied by any one tag underneath the namespace.
+	if len(args.Tags) > 0 && args.TagMode == TagModeAll {
+		for _, tag := range lo.Uniq(args.Tags) {
+			where.And(`?`, TagFilter(tag))
+		}
+	} else if len(args.Tags) > 0 {
+		where.And(`?`, TagsFilter(args.Tags))
+	}
 
-		where.And(`"child"."id" IN (?)`, tagsFilter)
+	if len(args.ExcludeTags) > 0 {
+		where.And(`?`, NotFilter(TagsFilter(args.ExcludeTags)))
 	}
 
-	if args.After.Dirty && args.After.Valid {
+	if args.After.Dirty && args.After.Valid && args.Order == OrderRelevance {
+		after := bqb.New(`SELECT IFNULL("search"."rank", 0), "bookmarks"."order", "bookmarks"."id"`)
+		after.Space(`FROM "bookmarks"`)
+		after.Space(`LEFT JOIN "search" ON "search"."id" = "bookmarks"."id"`)
+		after.Space(`WHERE "bookmarks"."id" = ?`, args.After.String)
+
+		if args.Direction == DirectionAsc {
+			where.And(`(IFNULL("search"."rank", 0), "child"."order", "child"."id") > (?)`, after)
+		} else {
+			where.And(`(IFNULL("search"."rank", 0), "child"."order", "child"."id") < (?)`, after)
+		}
+	} else if args.After.Dirty && args.After.Valid {
 		if args.Order == OrderName && args.Direction == DirectionAsc {
 			where.And(`("child"."name" > (SELECT "name" FROM "bookmarks" WHERE "id" = ?)`, args.After.String)
 		} else if args.Order == OrderName && args.Direction == DirectionDesc {
@@ -145,6 +386,10 @@ func GetBooks(tx Transaction, args GetBooksArgs) ([]BookDTO, error) {
 			where.And(`("child"."modified" > (SELECT "modified" FROM "bookmarks" WHERE "id" = ?)`, args.After.String)
 		} else if args.Order == OrderModified && args.Direction == DirectionDesc {
 			where.And(`("child"."modified" < (SELECT "modified" FROM "bookmarks" WHERE "id" = ?)`, args.After.String)
+		} else if args.Order == OrderManual && args.Recursive && args.Direction == DirectionAsc {
+			where.And(`("tree"."sort" > (SELECT "sort" FROM "tree" WHERE "id" = ?)`, args.After.String)
+		} else if args.Order == OrderManual && args.Recursive && args.Direction == DirectionDesc {
+			where.And(`("tree"."sort" < (SELECT "sort" FROM "tree" WHERE "id" = ?)`, args.After.String)
 		} else if args.Order == OrderManual && args.Direction == DirectionAsc {
 			where.And(`("child"."order" > (SELECT "order" FROM "bookmarks" WHERE "id" = ?)`, args.After.String)
 		} else if args.Order == OrderManual && args.Direction == DirectionDesc {
@@ -155,6 +400,8 @@ func GetBooks(tx Transaction, args GetBooksArgs) ([]BookDTO, error) {
 			where.Or(`("child"."name" = (SELECT "name" from "bookmarks" WHERE "id" = ?) AND "child"."id" > ?))`, args.After.String, args.After.String)
 		} else if args.Order == OrderModified {
 			where.Or(`("child"."modified" = (SELECT "modified" from "bookmarks" WHERE "id" = ?) AND "child"."id" > ?))`, args.After.String, args.After.String)
+		} else if args.Order == OrderManual && args.Recursive {
+			where.Or(`("tree"."sort" = (SELECT "sort" from "tree" WHERE "id" = ?) AND "child"."id" > ?))`, args.After.String, args.After.String)
 		} else if args.Order == OrderManual {
 			where.Or(`("child"."order" = (SELECT "order" from "bookmarks" WHERE "id" = ?) AND "child"."id" > ?))`, args.After.String, args.After.String)
 		}
@@ -170,10 +417,18 @@ func GetBooks(tx Transaction, args GetBooksArgs) ([]BookDTO, error) {
 		books.Space(`ORDER BY "child"."modified" ASC`)
 	} else if args.Direction == DirectionDesc && args.Order == OrderModified {
 		books.Space(`ORDER BY "child"."modified" DESC`)
+	} else if args.Direction == DirectionAsc && args.Order == OrderManual && args.Recursive {
+		books.Space(`ORDER BY "tree"."sort" ASC`)
+	} else if args.Direction == DirectionDesc && args.Order == OrderManual && args.Recursive {
+		books.Space(`ORDER BY "tree"."sort" DESC`)
 	} else if args.Direction == DirectionAsc && args.Order == OrderManual {
 		books.Space(`ORDER BY "child"."order" ASC`)
 	} else if args.Direction == DirectionDesc && args.Order == OrderManual {
 		books.Space(`ORDER BY "child"."order" DESC`)
+	} else if args.Direction == DirectionAsc && args.Order == OrderRelevance {
+		books.Space(`ORDER BY IFNULL("search"."rank", 0) ASC, "child"."order" ASC, "child"."id" ASC`)
+	} else if args.Direction == DirectionDesc && args.Order == OrderRelevance {
+		books.Space(`ORDER BY IFNULL("search"."rank", 0) DESC, "child"."order" DESC, "child"."id" DESC`)
 	}
 
 	if args.First.Dirty && args.First.Valid {
@@ -187,22 +442,57 @@ func GetBooks(tx Transaction, args GetBooksArgs) ([]BookDTO, error) {
 // GetTagsArgs are the args for getTagsDB.
 type GetTagsArgs struct {
 	IDFilter   null.NullString
-	TagsFilter []string
+	TagsFilter []string // only include these tags; patterns such as lang/* include every tag underneath a namespace
 	Query      null.NullString
 	After      null.NullString
 	Direction  Direction
 	First      null.NullInt64
+	ActiveOnly bool // leave out tags that are only applied to bookmarks in the trash
 }
 
 // GetTags lists tags in the bookmarks DB.
 func GetTags(tx Transaction, args GetTagsArgs) ([]string, error) {
+	return query[string](tx, tagsQuery(bqb.New(`SELECT "tag"`), args))
+}
+
+// GetTagCounts lists tags in the bookmarks DB along with how many bookmarks they are applied to.
+// Bookmarks in the trash aren't counted.
+func GetTagCounts(tx Transaction, args GetTagsArgs) ([]TagCountDTO, error) {
+	count := bqb.New(`SELECT COUNT(1)`)
+	count.Space(`FROM "bookmarks_tags"`)
+	count.Space(`INNER JOIN "bookmarks" ON "bookmarks"."id" = "bookmarks_tags"."bookmark_id"`)
+	count.Space(`WHERE "bookmarks_tags"."tag" = "tags"."tag"`)
+	count.Space(`AND "bookmarks"."deleted_at" IS NULL`)
+
 	tags := bqb.New(`SELECT "tag"`)
+	tags.Comma(`(?) AS "count"`, count)
+
+	return query[TagCountDTO](tx, tagsQuery(tags, args))
+}
+
+// tagsQuery adds the filtering, ordering, and limiting shared by GetTags and GetTagCounts to a select.
+func tagsQuery(tags *bqb.Query, args GetTagsArgs) *bqb.Query {
 	tags.Space(`FROM "tags"`)
 
 	where := bqb.Optional(`WHERE`)
 
 	if len(args.TagsFilter) > 0 {
-		where.And(`"tag" IN (?)`, args.TagsFilter)
+		tagsFilter := bqb.New(`?`, tagMatch(args.TagsFilter[0]))
+		for _, tag := range args.TagsFilter[1:] {
+			tagsFilter.Space(`OR ?`, tagMatch(tag))
+		}
+
+		where.And(`(?)`, tagsFilter)
+	}
+
+	if args.ActiveOnly {
+		active := bqb.New(`SELECT 1`)
+		active.Space(`FROM "bookmarks_tags"`)
+		active.Space(`INNER JOIN "bookmarks" ON "bookmarks"."id" = "bookmarks_tags"."bookmark_id"`)
+		active.Space(`WHERE "bookmarks_tags"."tag" = "tags"."tag"`)
+		active.Space(`AND "bookmarks"."deleted_at" IS NULL`)
+
+		where.And(`EXISTS (?)`, active)
 	}
 
 	if args.Query.Dirty && args.Query.Valid {
@@ -233,21 +523,34 @@ func GetTags(tx Transaction, args GetTagsArgs) ([]string, error) {
 		tags.Space(`LIMIT ?`, args.First.Int64)
 	}
 
-	return query[string](tx, tags)
+	return tags
 }
 
-// BookFolderExists returns true if the target book or folder exists.
+// BookFolderExists returns true if the target book or folder exists and isn't in the trash.
 func BookFolderExists(tx Transaction, ID string, isFolder bool) (bool, error) {
 	books := bqb.New(`SELECT COUNT(1) AS "num"`)
 	books.Space(`FROM "bookmarks"`)
 	books.Space(`WHERE "bookmarks"."id" = ?`, ID)
 	books.Space(`AND "bookmarks"."is_folder" = ?`, isFolder)
+	books.Space(`AND "bookmarks"."deleted_at" IS NULL`)
 
 	count, err := count(tx, books)
 	return count == 1, err
 }
 
+// URLExists returns true if a bookmark that isn't in the trash has the same URL once it's normalized.
+func URLExists(tx Transaction, url string) (bool, error) {
+	books := bqb.New(`SELECT COUNT(1) AS "num"`)
+	books.Space(`FROM "bookmarks"`)
+	books.Space(`WHERE "bookmarks"."normalized_url" = ?`, normalize.URL(url))
+	books.Space(`AND "bookmarks"."deleted_at" IS NULL`)
+
+	count, err := count(tx, books)
+	return count > 0, err
+}
+
 // GetParentAndChildren gets a parent and all of its children.
+// Children in the trash are included.
 func GetParentAndChildren(tx Transaction, ID string) ([]BookDTO, error) {
 	tags := bqb.New(`SELECT GROUP_CONCAT("tag")`)
 	tags.Space(`FROM "bookmarks_tags"`)
@@ -260,6 +563,8 @@ func GetParentAndChildren(tx Transaction, ID string) ([]BookDTO, error) {
 	first.Comma(`"child"."parent_id"`)
 	first.Comma(`"child"."is_folder"`)
 	first.Comma(`"child"."order"`)
+	first.Comma(`"child"."deleted_at"`)
+	first.Comma(`"child"."deleted_batch"`)
 	first.Comma(`"parent"."name" AS "parent"`)
 	first.Comma(`IFNULL((?), '') AS "tags"`, tags)
 	first.Space(`FROM "bookmarks" AS "child"`)
@@ -273,13 +578,16 @@ func GetParentAndChildren(tx Transaction, ID string) ([]BookDTO, error) {
 	rest.Comma(`"child"."parent_id"`)
 	rest.Comma(`"child"."is_folder"`)
 	rest.Comma(`"child"."order"`)
+	rest.Comma(`"child"."deleted_at"`)
+	rest.Comma(`"child"."deleted_batch"`)
 	rest.Comma(`"parent"."name" AS "parent"`)
 	rest.Comma(`IFNULL((?), '') AS "tags"`, tags)
 	rest.Space(`FROM "bookmarks" AS "child"`)
 	rest.Space(`LEFT JOIN "bookmarks" AS "parent" ON "parent"."id" = "child"."parent_id"`)
 	rest.Space(`INNER JOIN BOOK ON BOOK.id = "child"."parent_id"`)
 
-	books := bqb.New(`WITH RECURSIVE BOOK AS (? UNION ALL ?)`, first, rest)
+	// UNION rather than UNION ALL so a cycle in the folders can't make the query loop forever.
+	books := bqb.New(`WITH RECURSIVE BOOK AS (? UNION ?)`, first, rest)
 	books.Space(`SELECT "id"`)
 	books.Comma(`"url"`)
 	books.Comma(`"name"`)
@@ -287,6 +595,8 @@ func GetParentAndChildren(tx Transaction, ID string) ([]BookDTO, error) {
 	books.Comma(`"parent_id"`)
 	books.Comma(`"is_folder"`)
 	books.Comma(`"order"`)
+	books.Comma(`"deleted_at"`)
+	books.Comma(`"deleted_batch"`)
 	books.Comma(`"parent"`)
 	books.Comma(`"tags"`)
 	books.Space(`FROM BOOK`)
@@ -294,6 +604,274 @@ func GetParentAndChildren(tx Transaction, ID string) ([]BookDTO, error) {
 	return query[BookDTO](tx, books)
 }
 
+// GetDescendants gets every bookmark/folder underneath a parent.
+// If the parent is null every bookmark/folder is returned.
+// Bookmarks/folders in the trash aren't included.
+func GetDescendants(tx Transaction, parentID null.NullString) ([]BookDTO, error) {
+	tags := bqb.New(`SELECT GROUP_CONCAT("tag")`)
+	tags.Space(`FROM "bookmarks_tags"`)
+	tags.Space(`WHERE "bookmark_id" = "child"."id"`)
+
+	first := bqb.New(`SELECT "child"."id"`)
+	first.Comma(`"child"."url"`)
+	first.Comma(`"child"."name"`)
+	first.Comma(`"child"."description"`)
+	first.Comma(`"child"."parent_id"`)
+	first.Comma(`"child"."is_folder"`)
+	first.Comma(`"child"."order"`)
+	first.Comma(`"parent"."name" AS "parent_name"`)
+	first.Comma(`IFNULL((?), '') AS "tags"`, tags)
+	first.Space(`FROM "bookmarks" AS "child"`)
+	first.Space(`LEFT JOIN "bookmarks" AS "parent" ON "parent"."id" = "child"."parent_id"`)
+	if parentID.Valid {
+		first.Space(`WHERE "child"."parent_id" = ?`, parentID.String)
+	} else {
+		first.Space(`WHERE "child"."parent_id" IS NULL`)
+	}
+	first.Space(`AND "child"."deleted_at" IS NULL`)
+
+	rest := bqb.New(`SELECT "child"."id"`)
+	rest.Comma(`"child"."url"`)
+	rest.Comma(`"child"."name"`)
+	rest.Comma(`"child"."description"`)
+	rest.Comma(`"child"."parent_id"`)
+	rest.Comma(`"child"."is_folder"`)
+	rest.Comma(`"child"."order"`)
+	rest.Comma(`"parent"."name" AS "parent_name"`)
+	rest.Comma(`IFNULL((?), '') AS "tags"`, tags)
+	rest.Space(`FROM "bookmarks" AS "child"`)
+	rest.Space(`LEFT JOIN "bookmarks" AS "parent" ON "parent"."id" = "child"."parent_id"`)
+	rest.Space(`INNER JOIN BOOK ON BOOK.id = "child"."parent_id"`)
+	rest.Space(`WHERE "child"."deleted_at" IS NULL`)
+
+	// UNION rather than UNION ALL so a cycle in the folders can't make the query loop forever.
+	books := bqb.New(`WITH RECURSIVE BOOK AS (? UNION ?)`, first, rest)
+	books.Space(`SELECT "id"`)
+	books.Comma(`"url"`)
+	books.Comma(`"name"`)
+	books.Comma(`"description"`)
+	books.Comma(`"parent_id"`)
+	books.Comma(`"is_folder"`)
+	books.Comma(`"order"`)
+	books.Comma(`"parent_name"`)
+	books.Comma(`"tags"`)
+	books.Space(`FROM BOOK`)
+
+	return query[BookDTO](tx, books)
+}
+
+// GetBookmarkRowsArgs are the args for GetBookmarkRows.
+type GetBookmarkRowsArgs struct {
+	IDsFilter []string // only get these bookmarks/folders; every row if empty
+}
+
+// GetBookmarkRows gets rows of the bookmarks table.
+func GetBookmarkRows(tx Transaction, args GetBookmarkRowsArgs) ([]BookmarkRowDTO, error) {
+	rows := bqb.New(`SELECT "id"`)
+	rows.Comma(`"parent_id"`)
+	rows.Comma(`"is_folder"`)
+	rows.Comma(`"name"`)
+	rows.Comma(`"url"`)
+	rows.Comma(`"description"`)
+	rows.Comma(`"created"`)
+	rows.Comma(`"modified"`)
+	rows.Comma(`"order"`)
+	rows.Comma(`"deleted_at"`)
+	rows.Comma(`"deleted_batch"`)
+	rows.Space(`FROM "bookmarks"`)
+	if len(args.IDsFilter) > 0 {
+		rows.Space(`WHERE "id" IN (?)`, args.IDsFilter)
+	}
+	rows.Space(`ORDER BY "id"`)
+
+	return query[BookmarkRowDTO](tx, rows)
+}
+
+// GetTaggedBookIDs gets the IDs of the bookmarks that have any of a set of tags.
+// Bookmarks in the trash are included.
+func GetTaggedBookIDs(tx Transaction, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return []string{}, nil
+	}
+
+	IDs := bqb.New(`SELECT DISTINCT "bookmark_id"`)
+	IDs.Space(`FROM "bookmarks_tags"`)
+	IDs.Space(`WHERE "tag" IN (?)`, tags)
+	IDs.Space(`ORDER BY "bookmark_id"`)
+
+	return query[string](tx, IDs)
+}
+
+// GetTagRows gets every row of the tags table.
+func GetTagRows(tx Transaction) ([]TagRowDTO, error) {
+	rows := bqb.New(`SELECT "tag"`)
+	rows.Comma(`"modified"`)
+	rows.Space(`FROM "tags"`)
+	rows.Space(`ORDER BY "tag"`)
+
+	return query[TagRowDTO](tx, rows)
+}
+
+// GetBookmarkTagRowsArgs are the args for GetBookmarkTagRows.
+type GetBookmarkTagRowsArgs struct {
+	BookmarkIDsFilter []string // only get the tags of these bookmarks; every row if empty
+}
+
+// GetBookmarkTagRows gets rows of the bookmarks_tags table.
+func GetBookmarkTagRows(tx Transaction, args GetBookmarkTagRowsArgs) ([]BookmarkTagRowDTO, error) {
+	rows := bqb.New(`SELECT "bookmark_id"`)
+	rows.Comma(`"tag"`)
+	rows.Comma(`"modified"`)
+	rows.Space(`FROM "bookmarks_tags"`)
+	if len(args.BookmarkIDsFilter) > 0 {
+		rows.Space(`WHERE "bookmark_id" IN (?)`, args.BookmarkIDsFilter)
+	}
+	rows.Space(`ORDER BY "bookmark_id", "tag"`)
+
+	return query[BookmarkTagRowDTO](tx, rows)
+}
+
+// GetLinkChecksArgs are the args for GetLinkChecks.
+type GetLinkChecksArgs struct {
+	BrokenOnly bool // only include checks where the URL failed to resolve or returned an error status
+}
+
+// GetLinkChecks gets the most recent link check for each bookmark that has been checked.
+// Earlier checks are kept as history but aren't returned.
+func GetLinkChecks(tx Transaction, args GetLinkChecksArgs) ([]LinkCheckDTO, error) {
+	latest := bqb.New(`SELECT MAX("latest"."id")`)
+	latest.Space(`FROM "link_checks" AS "latest"`)
+	latest.Space(`WHERE "latest"."bookmark_id" = "link_checks"."bookmark_id"`)
+
+	checks := bqb.New(`SELECT "bookmark_id"`)
+	checks.Comma(`"status"`)
+	checks.Comma(`"redirect"`)
+	checks.Comma(`"error"`)
+	checks.Comma(`"checked"`)
+	checks.Space(`FROM "link_checks"`)
+	checks.Space(`WHERE "id" = (?)`, latest)
+	if args.BrokenOnly {
+		checks.Space(`AND ("error" IS NOT NULL OR "status" >= ?)`, 400)
+	}
+	checks.Space(`ORDER BY "id"`)
+
+	return query[LinkCheckDTO](tx, checks)
+}
+
+// GetArchives gets every archived copy of a bookmark's page.
+// The content and text of the pages aren't included.
+func GetArchives(tx Transaction) ([]ArchiveDTO, error) {
+	archives := bqb.New(`SELECT "bookmark_id"`)
+	archives.Comma(`"url"`)
+	archives.Comma(`"content_type"`)
+	archives.Comma(`"inlined"`)
+	archives.Comma(`"archived"`)
+	archives.Comma(`LENGTH("content") AS "size"`)
+	archives.Space(`FROM "archives"`)
+	archives.Space(`ORDER BY "bookmark_id"`)
+
+	return query[ArchiveDTO](tx, archives)
+}
+
+// GetArchive gets the archived copy of a bookmark's page including its content.
+func GetArchive(tx Transaction, bookmarkID string) ([]ArchiveDTO, error) {
+	archive := bqb.New(`SELECT "bookmark_id"`)
+	archive.Comma(`"url"`)
+	archive.Comma(`"content_type"`)
+	archive.Comma(`"content"`)
+	archive.Comma(`"text"`)
+	archive.Comma(`"inlined"`)
+	archive.Comma(`"archived"`)
+	archive.Comma(`LENGTH("content") AS "size"`)
+	archive.Space(`FROM "archives"`)
+	archive.Space(`WHERE "bookmark_id" = ?`, bookmarkID)
+
+	return query[ArchiveDTO](tx, archive)
+}
+
+// GetFaviconsArgs are the args for GetFavicons.
+type GetFaviconsArgs struct {
+	BookmarkIDs    []string // only get the icons of these bookmarks; all icons if empty
+	IncludeContent bool     // include the icons themselves
+}
+
+// GetFavicons gets the icons of bookmarks.
+func GetFavicons(tx Transaction, args GetFaviconsArgs) ([]FaviconDTO, error) {
+	favicons := bqb.New(`SELECT "bookmarks_favicons"."bookmark_id"`)
+	favicons.Comma(`"bookmarks_favicons"."hash"`)
+	favicons.Comma(`"bookmarks_favicons"."url"`)
+	favicons.Comma(`"bookmarks_favicons"."fetched"`)
+	favicons.Comma(`"favicons"."content_type"`)
+	favicons.Comma(`LENGTH("favicons"."content") AS "size"`)
+	if args.IncludeContent {
+		favicons.Comma(`"favicons"."content"`)
+	}
+	favicons.Space(`FROM "bookmarks_favicons"`)
+	favicons.Space(`INNER JOIN "favicons" ON "favicons"."hash" = "bookmarks_favicons"."hash"`)
+	if len(args.BookmarkIDs) > 0 {
+		favicons.Space(`WHERE "bookmarks_favicons"."bookmark_id" IN (?)`, args.BookmarkIDs)
+	}
+	favicons.Space(`ORDER BY "bookmarks_favicons"."bookmark_id"`)
+
+	return query[FaviconDTO](tx, favicons)
+}
+
+// GetJournalEntriesArgs are the args for GetJournalEntries.
+type GetJournalEntriesArgs struct {
+	First null.NullInt64 // max number of entries to return
+}
+
+// GetJournalEntries lists the operations in the journal from newest to oldest.
+// The states of the bookmarks/folders before and after each operation aren't included.
+func GetJournalEntries(tx Transaction, args GetJournalEntriesArgs) ([]JournalEntryDTO, error) {
+	entries := bqb.New(`SELECT "id"`)
+	entries.Comma(`"operation"`)
+	entries.Comma(`"description"`)
+	entries.Comma(`"undone"`)
+	entries.Comma(`"created"`)
+	entries.Space(`FROM "journal"`)
+	entries.Space(`ORDER BY "id" DESC`)
+	if args.First.Valid {
+		entries.Space(`LIMIT ?`, args.First.Int64)
+	}
+
+	return query[JournalEntryDTO](tx, entries)
+}
+
+// GetUndoEntry gets the most recent operation in the journal that hasn't been undone.
+func GetUndoEntry(tx Transaction) ([]JournalEntryDTO, error) {
+	entry := bqb.New(`SELECT "id"`)
+	entry.Comma(`"operation"`)
+	entry.Comma(`"description"`)
+	entry.Comma(`"before"`)
+	entry.Comma(`"after"`)
+	entry.Comma(`"undone"`)
+	entry.Comma(`"created"`)
+	entry.Space(`FROM "journal"`)
+	entry.Space(`WHERE "undone" = ?`, false)
+	entry.Space(`ORDER BY "id" DESC`)
+	entry.Space(`LIMIT 1`)
+
+	return query[JournalEntryDTO](tx, entry)
+}
+
+// GetRedoEntry gets the earliest operation in the journal that has been undone.
+func GetRedoEntry(tx Transaction) ([]JournalEntryDTO, error) {
+	entry := bqb.New(`SELECT "id"`)
+	entry.Comma(`"operation"`)
+	entry.Comma(`"description"`)
+	entry.Comma(`"before"`)
+	entry.Comma(`"after"`)
+	entry.Comma(`"undone"`)
+	entry.Comma(`"created"`)
+	entry.Space(`FROM "journal"`)
+	entry.Space(`WHERE "undone" = ?`, true)
+	entry.Space(`ORDER BY "id" ASC`)
+	entry.Space(`LIMIT 1`)
+
+	return query[JournalEntryDTO](tx, entry)
+}
+
 // MaxOrder returns the max order for a given parentID.
 func MaxOrder(tx Transaction, parentID null.NullString) (string, error) {
 	order := bqb.New(`SELECT IFNULL(MAX("bookmarks"."order"), '') AS "order"`)
@@ -330,6 +908,7 @@ func UpdateBook(tx Transaction, ID string, args UpdateBookArgs) error {
 
 	if args.URL.Dirty {
 		set.Comma(`"url" = ?`, args.URL)
+		set.Comma(`"normalized_url" = ?`, normalizedURL(args.URL))
 	}
 
 	if args.Description.Dirty {
@@ -344,6 +923,9 @@ func UpdateBook(tx Transaction, ID string, args UpdateBookArgs) error {
 		set.Comma(`"order" = ?`, args.Order)
 	}
 
+	// Anything that changes a bookmark, including moving it, counts as modifying it.
+	set.Comma(`"modified" = datetime()`)
+
 	update.Space(`?`, set)
 	update.Space(`WHERE "id" = ?`, ID)
 	update.Space(`AND "is_folder" = ?`, false)
@@ -375,6 +957,9 @@ func UpdateFolder(tx Transaction, ID string, args UpdateFolderArgs) error {
 		set.Comma(`"order" = ?`, args.Order)
 	}
 
+	// Anything that changes a folder, including moving it, counts as modifying it.
+	set.Comma(`"modified" = datetime()`)
+
 	update.Space(`?`, set)
 	update.Space(`WHERE "id" = ?`, ID)
 	update.Space(`AND "is_folder" = ?`, true)
@@ -382,6 +967,58 @@ func UpdateFolder(tx Transaction, ID string, args UpdateFolderArgs) error {
 	return exec(tx, update)
 }
 
+// TrashBooks moves bookmarks/folders to the trash.
+// Everything moved to the trash at once shares the same timestamp and batch ID.
+func TrashBooks(tx Transaction, IDs []string) error {
+	if len(IDs) == 0 {
+		return nil
+	}
+
+	update := bqb.New(`UPDATE "bookmarks"`)
+	update.Space(`SET "deleted_at" = datetime()`)
+	update.Comma(`"deleted_batch" = ?`, uuid.New().String())
+	update.Space(`WHERE "id" IN (?)`, IDs)
+
+	return exec(tx, update)
+}
+
+// RestoreBooks takes bookmarks/folders out of the trash.
+func RestoreBooks(tx Transaction, IDs []string) error {
+	if len(IDs) == 0 {
+		return nil
+	}
+
+	update := bqb.New(`UPDATE "bookmarks"`)
+	update.Space(`SET "deleted_at" = NULL`)
+	update.Comma(`"deleted_batch" = NULL`)
+	update.Space(`WHERE "id" IN (?)`, IDs)
+
+	return exec(tx, update)
+}
+
+// SetJournalEntryUndone marks an operation in the journal as undone or redone.
+func SetJournalEntryUndone(tx Transaction, ID int64, undone bool) error {
+	update := bqb.New(`UPDATE "journal"`)
+	update.Space(`SET "undone" = ?`, undone)
+	update.Space(`WHERE "id" = ?`, ID)
+
+	return exec(tx, update)
+}
+
+// touch marks bookmarks/folders as modified now.
+// It's used for changes that don't update the bookmarks table itself such as adding tags.
+func touch(tx Transaction, IDs []string) error {
+	if len(IDs) == 0 {
+		return nil
+	}
+
+	update := bqb.New(`UPDATE "bookmarks"`)
+	update.Space(`SET "modified" = datetime()`)
+	update.Space(`WHERE "id" IN (?)`, IDs)
+
+	return exec(tx, update)
+}
+
 // delete
 
 // UnlinkTags removes tags from a bookmark.
@@ -394,7 +1031,11 @@ func UnlinkTags(tx Transaction, ID string, tags []string) error {
 	remove.Space(`WHERE "bookmark_id" = ?`, ID)
 	remove.Space(`AND "tag" IN (?)`, tags)
 
-	return exec(tx, remove)
+	if err := exec(tx, remove); err != nil {
+		return err
+	}
+
+	return touch(tx, []string{ID})
 }
 
 // RemoveBook deletes a bookmark from the bookmarks DB.
@@ -428,6 +1069,55 @@ func CleanOrphanedTags(tx Transaction, tags []string) error {
 	return exec(tx, remove)
 }
 
+// RemoveAll deletes every bookmark, folder, and tag from the bookmarks DB.
+func RemoveAll(tx Transaction) error {
+	if err := exec(tx, bqb.New(`DELETE FROM "bookmarks_tags"`)); err != nil {
+		return err
+	}
+
+	if err := exec(tx, bqb.New(`DELETE FROM "tags"`)); err != nil {
+		return err
+	}
+
+	return exec(tx, bqb.New(`DELETE FROM "bookmarks"`))
+}
+
+// UnlinkAllTags removes every tag from a set of bookmarks.
+func UnlinkAllTags(tx Transaction, IDs []string) error {
+	if len(IDs) == 0 {
+		return nil
+	}
+
+	remove := bqb.New(`DELETE FROM "bookmarks_tags"`)
+	remove.Space(`WHERE "bookmark_id" IN (?)`, IDs)
+
+	return exec(tx, remove)
+}
+
+// CleanAllOrphanedTags removes every tag that isn't applied to a bookmark.
+func CleanAllOrphanedTags(tx Transaction) error {
+	existing := bqb.New(`SELECT 1`)
+	existing.Space(`FROM "bookmarks_tags"`)
+	existing.Space(`WHERE "bookmarks_tags"."tag" = "tags"."tag"`)
+
+	remove := bqb.New(`DELETE FROM "tags"`)
+	remove.Space(`WHERE NOT EXISTS (?)`, existing)
+
+	return exec(tx, remove)
+}
+
+// CleanOrphanedFavicons removes any icons that aren't used by a bookmark.
+func CleanOrphanedFavicons(tx Transaction) error {
+	existing := bqb.New(`SELECT 1`)
+	existing.Space(`FROM "bookmarks_favicons"`)
+	existing.Space(`WHERE "bookmarks_favicons"."hash" = "favicons"."hash"`)
+
+	remove := bqb.New(`DELETE FROM "favicons"`)
+	remove.Space(`WHERE NOT EXISTS (?)`, existing)
+
+	return exec(tx, remove)
+}
+
 // GetBookFolderParents returns the parent names of a bookmark or folder.
 func GetBookFolderParents(tx Transaction, ID string) ([]string, error) {
 	first := bqb.New(`SELECT "child"."id"`)
@@ -444,7 +1134,8 @@ func GetBookFolderParents(tx Transaction, ID string) ([]string, error) {
 	rest.Space(`FROM "bookmarks" AS "child"`)
 	rest.Space(`INNER JOIN BOOK ON BOOK.parent_id = "child"."id"`)
 
-	parents := bqb.New(`WITH RECURSIVE BOOK AS (? UNION ALL ?)`, first, rest)
+	// UNION rather than UNION ALL so a cycle in the folders can't make the query loop forever.
+	parents := bqb.New(`WITH RECURSIVE BOOK AS (? UNION ?)`, first, rest)
 	parents.Space(`SELECT "name"`)
 	parents.Space(`FROM BOOK`)
 
@@ -0,0 +1,12 @@
+package db
+
+// FaviconDTO is a DTO for a row of the bookmarks_favicons table joined to its icon.
+type FaviconDTO struct {
+	BookmarkID  string `db:"bookmark_id"`
+	Hash        string `db:"hash"`
+	URL         string `db:"url"`
+	ContentType string `db:"content_type"`
+	Content     []byte `db:"content"`
+	Fetched     string `db:"fetched"`
+	Size        int64  `db:"size"`
+}
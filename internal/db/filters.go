@@ -0,0 +1,184 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nullism/bqb"
+)
+
+// Filters are predicates on the "child" bookmark/folder in GetBooks.
+// They can be combined with AndFilter, OrFilter, and NotFilter.
+
+// Comparison is how a value is compared in a filter.
+type Comparison string
+
+const (
+	ComparisonEqual          Comparison = "="
+	ComparisonLess           Comparison = "<"
+	ComparisonLessOrEqual    Comparison = "<="
+	ComparisonGreater        Comparison = ">"
+	ComparisonGreaterOrEqual Comparison = ">="
+)
+
+// MatchFilter filters to bookmarks/folders that match an FTS5 MATCH expression.
+func MatchFilter(match string) *bqb.Query {
+	search := bqb.New(`SELECT "id"`)
+	search.Space(`FROM "bookmarks_fts"`)
+	search.Space(`WHERE "bookmarks_fts" MATCH ?`, match)
+
+	return bqb.New(`"child"."id" IN (?)`, search)
+}
+
+// TextFilter filters to bookmarks/folders whose name, URL, or description contains a value.
+// It's used for terms that are too short for the trigram index to match.
+func TextFilter(value string) *bqb.Query {
+	return OrFilter(OrFilter(NameFilter(value), URLFilter(value)), DescriptionFilter(value))
+}
+
+// TagSeparator separates the levels of a hierarchical tag such as lang/go.
+const TagSeparator = "/"
+
+// TagDescendants ends a tag pattern such as lang/* that matches every tag underneath a namespace.
+const TagDescendants = TagSeparator + "*"
+
+// TagFilter filters to bookmarks that have a tag.
+// The tag can be a pattern such as lang/* to match any tag underneath a namespace.
+func TagFilter(tag string) *bqb.Query {
+	return TagsFilter([]string{tag})
+}
+
+// TagsFilter filters to bookmarks that have any of a set of tags.
+// The tags can be patterns such as lang/* to match any tag underneath a namespace.
+func TagsFilter(tags []string) *bqb.Query {
+	where := bqb.Optional(`WHERE`)
+	for _, tag := range tags {
+		where.Or(`?`, tagMatch(tag))
+	}
+
+	filter := bqb.New(`SELECT "bookmark_id"`)
+	filter.Space(`FROM "bookmarks_tags"`)
+	filter.Space(`?`, where)
+
+	return bqb.New(`"child"."id" IN (?)`, filter)
+}
+
+// tagMatch matches the "tag" column against a tag or a pattern such as lang/*.
+// Tags can't contain glob characters so only the trailing * of a pattern is a wildcard.
+func tagMatch(tag string) *bqb.Query {
+	if strings.HasSuffix(tag, TagDescendants) {
+		return bqb.New(`"tag" GLOB ?`, tag)
+	}
+
+	return bqb.New(`"tag" = ?`, tag)
+}
+
+// FolderFilter filters to bookmarks/folders anywhere inside of a folder.
+// The folder is located by the names of the folders leading to it, starting at the top level.
+// Folder names are compared case insensitively.
+func FolderFilter(path []string) *bqb.Query {
+	folder := bqb.New(`SELECT "id"`)
+	folder.Space(`FROM "bookmarks"`)
+	folder.Space(`WHERE "is_folder" = ?`, true)
+	folder.Space(`AND "parent_id" IS NULL`)
+	folder.Space(`AND "name" = ? COLLATE NOCASE`, path[0])
+
+	for _, name := range path[1:] {
+		child := bqb.New(`SELECT "id"`)
+		child.Space(`FROM "bookmarks"`)
+		child.Space(`WHERE "is_folder" = ?`, true)
+		child.Space(`AND "parent_id" IN (?)`, folder)
+		child.Space(`AND "name" = ? COLLATE NOCASE`, name)
+		folder = child
+	}
+
+	first := bqb.New(`SELECT "id"`)
+	first.Space(`FROM "bookmarks"`)
+	first.Space(`WHERE "parent_id" IN (?)`, folder)
+
+	rest := bqb.New(`SELECT "bookmarks"."id"`)
+	rest.Space(`FROM "bookmarks"`)
+	rest.Space(`INNER JOIN "descendants" ON "bookmarks"."parent_id" = "descendants"."id"`)
+
+	descendants := bqb.New(`WITH RECURSIVE "descendants"("id") AS (? UNION ALL ?)`, first, rest)
+	descendants.Space(`SELECT "id" FROM "descendants"`)
+
+	return bqb.New(`"child"."id" IN (?)`, descendants)
+}
+
+// URLFilter filters to bookmarks whose URL contains a value.
+func URLFilter(value string) *bqb.Query {
+	return containsFilter("url", value)
+}
+
+// NameFilter filters to bookmarks/folders whose name contains a value.
+func NameFilter(value string) *bqb.Query {
+	return containsFilter("name", value)
+}
+
+// DescriptionFilter filters to bookmarks whose description contains a value.
+func DescriptionFilter(value string) *bqb.Query {
+	return containsFilter("description", value)
+}
+
+// ModifiedFilter filters to bookmarks/folders by the day they were last modified.
+// The date should be formatted as YYYY-MM-DD.
+func ModifiedFilter(comparison Comparison, date string) *bqb.Query {
+	return dateFilter("modified", comparison, date)
+}
+
+// CreatedFilter filters to bookmarks/folders by the day they were created.
+// The date should be formatted as YYYY-MM-DD.
+func CreatedFilter(comparison Comparison, date string) *bqb.Query {
+	return dateFilter("created", comparison, date)
+}
+
+// ModifiedAfterFilter filters to bookmarks/folders last modified after a timestamp.
+// The timestamp should be formatted as YYYY-MM-DD HH:MM:SS in UTC.
+func ModifiedAfterFilter(timestamp string) *bqb.Query {
+	return bqb.New(`"child"."modified" > ?`, timestamp)
+}
+
+// CreatedBeforeFilter filters to bookmarks/folders created before a timestamp.
+// The timestamp should be formatted as YYYY-MM-DD HH:MM:SS in UTC.
+func CreatedBeforeFilter(timestamp string) *bqb.Query {
+	return bqb.New(`"child"."created" < ?`, timestamp)
+}
+
+// AndFilter filters to bookmarks/folders that satisfy both filters.
+func AndFilter(left *bqb.Query, right *bqb.Query) *bqb.Query {
+	return bqb.New(`(? AND ?)`, left, right)
+}
+
+// OrFilter filters to bookmarks/folders that satisfy either filter.
+func OrFilter(left *bqb.Query, right *bqb.Query) *bqb.Query {
+	return bqb.New(`(? OR ?)`, left, right)
+}
+
+// NotFilter filters to bookmarks/folders that don't satisfy a filter.
+func NotFilter(filter *bqb.Query) *bqb.Query {
+	return bqb.New(`(NOT ?)`, filter)
+}
+
+// dateFilter filters to bookmarks/folders by the day of a timestamp column.
+func dateFilter(column string, comparison Comparison, date string) *bqb.Query {
+	switch comparison {
+	case ComparisonLess:
+		return bqb.New(fmt.Sprintf(`DATE("child"."%s") < ?`, column), date)
+	case ComparisonLessOrEqual:
+		return bqb.New(fmt.Sprintf(`DATE("child"."%s") <= ?`, column), date)
+	case ComparisonGreater:
+		return bqb.New(fmt.Sprintf(`DATE("child"."%s") > ?`, column), date)
+	case ComparisonGreaterOrEqual:
+		return bqb.New(fmt.Sprintf(`DATE("child"."%s") >= ?`, column), date)
+	default:
+		return bqb.New(fmt.Sprintf(`DATE("child"."%s") = ?`, column), date)
+	}
+}
+
+// containsFilter filters to bookmarks/folders where a column contains a value.
+// The comparison is case insensitive and NULL columns are treated as empty.
+func containsFilter(column string, value string) *bqb.Query {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+	return bqb.New(fmt.Sprintf(`IFNULL("child"."%s", '') LIKE ? ESCAPE '\'`, column), fmt.Sprintf("%%%s%%", escaped))
+}
@@ -0,0 +1,12 @@
+package db
+
+// JournalEntryDTO is a DTO for a row of the journal table.
+type JournalEntryDTO struct {
+	ID          int64  `db:"id"`
+	Operation   string `db:"operation"`
+	Description string `db:"description"`
+	Before      string `db:"before"`
+	After       string `db:"after"`
+	Undone      bool   `db:"undone"`
+	Created     string `db:"created"`
+}
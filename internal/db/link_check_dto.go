@@ -0,0 +1,14 @@
+package db
+
+import (
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// LinkCheckDTO is a DTO for a row of the link_checks table.
+type LinkCheckDTO struct {
+	BookmarkID string          `db:"bookmark_id"`
+	Status     null.NullInt64  `db:"status"`
+	Redirect   null.NullString `db:"redirect"`
+	Error      null.NullString `db:"error"`
+	Checked    string          `db:"checked"`
+}
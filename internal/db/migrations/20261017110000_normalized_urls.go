@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/normalize"
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upNormalizedURLs, downNormalizedURLs)
+}
+
+// upNormalizedURLs adds the normalized URL of each bookmark so duplicates can be looked up by index.
+// The URLs are normalized in Go so the existing bookmarks are filled in here instead of in SQL.
+func upNormalizedURLs(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `ALTER TABLE "bookmarks" ADD COLUMN "normalized_url" TEXT NULL`); err != nil {
+		return fmt.Errorf("error adding column: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `CREATE INDEX "ix_bookmarks_normalized_url" ON "bookmarks"("normalized_url")`); err != nil {
+		return fmt.Errorf("error adding index: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT "id", "url" FROM "bookmarks" WHERE "url" IS NOT NULL`)
+	if err != nil {
+		return fmt.Errorf("error querying bookmarks: %w", err)
+	}
+
+	urls := make(map[string]string)
+	for rows.Next() {
+		var id, url string
+		if err := rows.Scan(&id, &url); err != nil {
+			rows.Close()
+			return fmt.Errorf("error scanning bookmark: %w", err)
+		}
+
+		urls[id] = url
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading bookmarks: %w", err)
+	}
+
+	for id, url := range urls {
+		if _, err := tx.ExecContext(ctx, `UPDATE "bookmarks" SET "normalized_url" = ? WHERE "id" = ?`, normalize.URL(url), id); err != nil {
+			return fmt.Errorf("error updating bookmark: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// downNormalizedURLs removes the normalized URL of each bookmark.
+func downNormalizedURLs(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, `DROP INDEX "ix_bookmarks_normalized_url"`); err != nil {
+		return fmt.Errorf("error dropping index: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `ALTER TABLE "bookmarks" DROP COLUMN "normalized_url"`); err != nil {
+		return fmt.Errorf("error dropping column: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "link_checks" (
+  "id" INTEGER PRIMARY KEY,
+  "bookmark_id" TEXT NOT NULL,
+  "status" INTEGER NULL,
+  "redirect" TEXT NULL,
+  "error" TEXT NULL,
+  "checked" TEXT NOT NULL DEFAULT(datetime()),
+  FOREIGN KEY("bookmark_id") REFERENCES "bookmarks"("id") ON DELETE CASCADE
+) STRICT;
+
+CREATE INDEX "ix_link_checks_bookmark_id"
+ON "link_checks"("bookmark_id", "checked");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "ix_link_checks_bookmark_id";
+
+DROP TABLE "link_checks";
+-- +goose StatementEnd
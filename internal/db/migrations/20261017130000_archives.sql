@@ -0,0 +1,130 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "archives" (
+  "bookmark_id" TEXT PRIMARY KEY NOT NULL,
+  "url" TEXT NOT NULL,
+  "content_type" TEXT NOT NULL,
+  "content" BLOB NOT NULL,
+  "text" TEXT NOT NULL,
+  "inlined" INTEGER NOT NULL,
+  "archived" TEXT NOT NULL DEFAULT(datetime()),
+  FOREIGN KEY("bookmark_id") REFERENCES "bookmarks"("id") ON DELETE CASCADE,
+  CHECK ("inlined" IN (0, 1))
+) STRICT;
+
+-- FTS5 tables can't have columns added so the search index is rebuilt with a column for archived text.
+DROP TRIGGER "after_bookmarks_insert";
+
+DROP TRIGGER "after_bookmarks_update";
+
+DROP TRIGGER "after_bookmarks_delete";
+
+DROP TABLE "bookmarks_fts";
+
+CREATE VIRTUAL TABLE "bookmarks_fts"
+USING fts5("id" UNINDEXED, "name", "url", "description", "archive", tokenize="trigram");
+
+INSERT INTO "bookmarks_fts" ("id", "name", "url", "description")
+SELECT "id", "name", "url", "description"
+FROM "bookmarks";
+
+CREATE TRIGGER "after_bookmarks_insert" AFTER INSERT ON "bookmarks" BEGIN
+  INSERT INTO bookmarks_fts (
+    "id",
+    "name",
+    "url",
+    "description"
+  )
+  VALUES(
+    new."id",
+    new."name",
+    new."url",
+    new."description"
+  );
+END;
+
+CREATE TRIGGER "after_bookmarks_update" UPDATE ON "bookmarks" BEGIN
+  UPDATE "bookmarks_fts"
+   SET "name" = new."name",
+   "url" = new."url",
+   "description" = new."description"
+  WHERE "id" = old."id";
+END;
+
+CREATE TRIGGER "after_bookmarks_delete" AFTER DELETE ON "bookmarks" BEGIN
+  DELETE FROM "bookmarks_fts"
+  WHERE "id" = old."id";
+END;
+
+CREATE TRIGGER "after_archives_insert" AFTER INSERT ON "archives" BEGIN
+  UPDATE "bookmarks_fts"
+   SET "archive" = new."text"
+  WHERE "id" = new."bookmark_id";
+END;
+
+CREATE TRIGGER "after_archives_update" UPDATE ON "archives" BEGIN
+  UPDATE "bookmarks_fts"
+   SET "archive" = new."text"
+  WHERE "id" = new."bookmark_id";
+END;
+
+CREATE TRIGGER "after_archives_delete" AFTER DELETE ON "archives" BEGIN
+  UPDATE "bookmarks_fts"
+   SET "archive" = NULL
+  WHERE "id" = old."bookmark_id";
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER "after_archives_insert";
+
+DROP TRIGGER "after_archives_update";
+
+DROP TRIGGER "after_archives_delete";
+
+DROP TRIGGER "after_bookmarks_insert";
+
+DROP TRIGGER "after_bookmarks_update";
+
+DROP TRIGGER "after_bookmarks_delete";
+
+DROP TABLE "bookmarks_fts";
+
+CREATE VIRTUAL TABLE "bookmarks_fts"
+USING fts5("id" UNINDEXED, "name", "url", "description", tokenize="trigram");
+
+INSERT INTO "bookmarks_fts" ("id", "name", "url", "description")
+SELECT "id", "name", "url", "description"
+FROM "bookmarks";
+
+CREATE TRIGGER "after_bookmarks_insert" AFTER INSERT ON "bookmarks" BEGIN
+  INSERT INTO bookmarks_fts (
+    "id",
+    "name",
+    "url",
+    "description"
+  )
+  VALUES(
+    new."id",
+    new."name",
+    new."url",
+    new."description"
+  );
+END;
+
+CREATE TRIGGER "after_bookmarks_update" UPDATE ON "bookmarks" BEGIN
+  UPDATE "bookmarks_fts"
+   SET "name" = new."name",
+   "url" = new."url",
+   "description" = new."description"
+  WHERE "id" = old."id";
+END;
+
+CREATE TRIGGER "after_bookmarks_delete" AFTER DELETE ON "bookmarks" BEGIN
+  DELETE FROM "bookmarks_fts"
+  WHERE "id" = old."id";
+END;
+
+DROP TABLE "archives";
+-- +goose StatementEnd
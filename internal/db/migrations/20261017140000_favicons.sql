@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "favicons" (
+  "hash" TEXT PRIMARY KEY,
+  "content_type" TEXT NOT NULL,
+  "content" BLOB NOT NULL
+) STRICT;
+
+CREATE TABLE "bookmarks_favicons" (
+  "bookmark_id" TEXT PRIMARY KEY,
+  "hash" TEXT NOT NULL,
+  "url" TEXT NOT NULL,
+  "fetched" TEXT NOT NULL DEFAULT(datetime()),
+  FOREIGN KEY("bookmark_id") REFERENCES "bookmarks"("id") ON DELETE CASCADE,
+  FOREIGN KEY("hash") REFERENCES "favicons"("hash")
+) STRICT;
+
+CREATE INDEX "ix_bookmarks_favicons_hash"
+ON "bookmarks_favicons"("hash");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "ix_bookmarks_favicons_hash";
+
+DROP TABLE "bookmarks_favicons";
+
+DROP TABLE "favicons";
+-- +goose StatementEnd
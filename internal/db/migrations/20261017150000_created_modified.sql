@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Columns added to an existing table can't default to datetime() so created is filled in by a trigger instead.
+ALTER TABLE "bookmarks" ADD COLUMN "created" TEXT NULL;
+
+-- The modified timestamp was never updated so until now it has been the time each row was created.
+UPDATE "bookmarks" SET "created" = "modified";
+
+CREATE TRIGGER "after_bookmarks_insert_created" AFTER INSERT ON "bookmarks"
+WHEN new."created" IS NULL
+BEGIN
+  UPDATE "bookmarks"
+  SET "created" = new."modified"
+  WHERE "id" = new."id";
+END;
+
+-- The original index was on a misspelled column so it never indexed anything useful.
+DROP INDEX "ix_bookmarks_modified";
+
+CREATE INDEX "ix_bookmarks_modified"
+ON "bookmarks"("modified");
+
+CREATE INDEX "ix_bookmarks_created"
+ON "bookmarks"("created");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "ix_bookmarks_created";
+
+DROP TRIGGER "after_bookmarks_insert_created";
+
+ALTER TABLE "bookmarks" DROP COLUMN "created";
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE "journal" (
+  "id" INTEGER PRIMARY KEY,
+  "operation" TEXT NOT NULL,
+  "description" TEXT NOT NULL,
+  "before" TEXT NOT NULL,
+  "after" TEXT NOT NULL,
+  "undone" INTEGER NOT NULL DEFAULT(0),
+  "created" TEXT NOT NULL DEFAULT(datetime())
+) STRICT;
+
+CREATE INDEX "ix_journal_undone"
+ON "journal"("undone");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "ix_journal_undone";
+
+DROP TABLE "journal";
+-- +goose StatementEnd
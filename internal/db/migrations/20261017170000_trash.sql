@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Bookmarks/folders in the trash have the time they were removed; everything else is NULL.
+ALTER TABLE "bookmarks" ADD COLUMN "deleted_at" TEXT NULL;
+
+-- Bookmarks/folders moved to the trash together share a batch ID; everything else is NULL.
+-- deleted_at only has a resolution of one second so it can't be used to tell separate removals apart.
+ALTER TABLE "bookmarks" ADD COLUMN "deleted_batch" TEXT NULL;
+
+CREATE INDEX "ix_bookmarks_deleted_at"
+ON "bookmarks"("deleted_at");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX "ix_bookmarks_deleted_at";
+
+ALTER TABLE "bookmarks" DROP COLUMN "deleted_batch";
+
+ALTER TABLE "bookmarks" DROP COLUMN "deleted_at";
+-- +goose StatementEnd
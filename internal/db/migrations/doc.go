@@ -0,0 +1,4 @@
+// migrations contains the migrations for the bookmarks database that can't be written in SQL.
+// The SQL migrations live alongside them and are embedded by the db package.
+// Importing this package registers the migrations with goose.
+package migrations
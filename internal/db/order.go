@@ -7,4 +7,7 @@ const (
 	OrderModified Order = "modified"
 	OrderName     Order = "name"
 	OrderManual   Order = "manual"
+	// OrderRelevance orders by how well results match a search.
+	// It only applies when searching; otherwise results are ordered manually.
+	OrderRelevance Order = "relevance"
 )
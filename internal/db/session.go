@@ -0,0 +1,90 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Connecting to the bookmarks database is expensive.
+// It has to be opened, configured, and checked for missing migrations.
+// A session keeps connections open so long lived processes only pay for that once.
+// When there is no session every operation opens and closes its own connection.
+
+// session is the set of connections being kept open.
+// It is nil when there is no session.
+var session map[string]*sql.DB
+
+// sessionMutex guards session.
+var sessionMutex sync.Mutex
+
+// StartSession keeps connections to the bookmarks database open until EndSession is called.
+func StartSession() {
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	if session == nil {
+		session = make(map[string]*sql.DB)
+	}
+}
+
+// EndSession closes any connections that were kept open by StartSession.
+func EndSession() error {
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	var errs []error
+	for _, db := range session {
+		if err := db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	session = nil
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("error closing database while ending session: %w", err)
+	}
+
+	return nil
+}
+
+// sessionDB gets the connection for a database location.
+// If there is a session the connection is reused between calls.
+func sessionDB(location string, connectFn func(location string) (*sql.DB, error)) (*sql.DB, error) {
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	if session == nil {
+		return connectFn(location)
+	}
+
+	if db, ok := session[location]; ok {
+		return db, nil
+	}
+
+	db, err := connectFn(location)
+	if err != nil {
+		return nil, err
+	}
+
+	// PRAGMAs are set per connection so a kept connection is the only connection.
+	db.SetMaxOpenConns(1)
+	session[location] = db
+
+	return db, nil
+}
+
+// releaseDB closes a connection unless it's being kept open by a session.
+func releaseDB(db *sql.DB) error {
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+
+	for _, kept := range session {
+		if kept == db {
+			return nil
+		}
+	}
+
+	return db.Close()
+}
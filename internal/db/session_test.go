@@ -0,0 +1,65 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestReleaseDBClosesWithoutSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	mock.ExpectClose()
+
+	if err := releaseDB(db); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expections: %s", err)
+	}
+}
+
+func TestSessionKeepsDBOpen(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	connects := 0
+	connectFn := func(location string) (*sql.DB, error) {
+		connects++
+		return db, nil
+	}
+
+	StartSession()
+
+	for i := 0; i < 2; i++ {
+		got, err := sessionDB("bookmarks.db", connectFn)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if err := releaseDB(got); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if connects != 1 {
+		t.Errorf("got %+v connects; want %+v", connects, 1)
+	}
+
+	mock.ExpectClose()
+
+	if err := EndSession(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expections: %s", err)
+	}
+}
@@ -0,0 +1,7 @@
+package db
+
+// TagCountDTO is a DTO for a tag and how many bookmarks it's applied to.
+type TagCountDTO struct {
+	Tag   string `db:"tag"`
+	Count int64  `db:"count"`
+}
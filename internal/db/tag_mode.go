@@ -0,0 +1,9 @@
+package db
+
+// TagMode is how bookmarks are matched against a set of tags.
+type TagMode string
+
+const (
+	TagModeAny TagMode = "any" // bookmarks with at least one of the tags
+	TagModeAll TagMode = "all" // bookmarks with every one of the tags
+)
@@ -35,7 +35,7 @@ func queryWithTransactionInternal[T any](inputPath null.NullString, configPath s
 		err = fmt.Errorf("error connecting to database while querying with a transaction: %w", err)
 		return
 	}
-	defer db.Close()
+	defer releaseDB(db)
 
 	tx, err := db.Begin()
 	if err != nil {
@@ -87,7 +87,7 @@ func execWithTransactionInternal(inputPath null.NullString, configPath string, c
 		err = fmt.Errorf("error connecting to database while executing with a transaction: %w", err)
 		return
 	}
-	defer db.Close()
+	defer releaseDB(db)
 
 	tx, err := db.Begin()
 	if err != nil {
@@ -137,7 +137,7 @@ func queryWithDBInternal[T any](inputPath null.NullString, configPath string, co
 	if err != nil {
 		return val, fmt.Errorf("error connecting to database while querying with database: %w", err)
 	}
-	defer db.Close()
+	defer releaseDB(db)
 
 	val, err = queryFn(db)
 	if err != nil {
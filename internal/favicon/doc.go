@@ -0,0 +1,5 @@
+// favicon contains the logic to find and download the icon of a web site.
+// Icons declared with <link rel="icon"> in the <head> of a page are tried first, in the order they appear.
+// The well known /favicon.ico at the root of the site is used as a fallback.
+// Icons are identified by a hash of their content so sites that share an icon only need it stored once.
+package favicon
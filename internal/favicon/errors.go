@@ -0,0 +1,15 @@
+package favicon
+
+import "errors"
+
+// ErrBadStatus is returned when an icon responds with an error status.
+var ErrBadStatus = errors.New("bad status")
+
+// ErrNotImage is returned when an icon URL doesn't point to an image.
+var ErrNotImage = errors.New("not an image")
+
+// ErrTooLarge is returned when an icon is larger than the max size.
+var ErrTooLarge = errors.New("icon too large")
+
+// ErrNotFound is returned when none of the places an icon could be have one.
+var ErrNotFound = errors.New("favicon not found")
@@ -0,0 +1,213 @@
+package favicon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/web"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// maxPage is how much of a page is read before giving up on finding the end of the <head>.
+const maxPage = 1024 * 1024
+
+// iconRels are the rel values that declare an icon, in order of preference.
+var iconRels = []string{"icon", "apple-touch-icon"}
+
+// Icon is the downloaded icon of a web site.
+type Icon struct {
+	URL         string // the URL the icon was downloaded from after following redirects
+	ContentType string // the media type of the icon
+	Content     []byte // the icon itself
+	Hash        string // hex encoded SHA-256 of the content
+}
+
+// Fetch finds and downloads the icon for a page.
+// Icons larger than maxSize are skipped.
+func Fetch(ctx context.Context, client *http.Client, pageURL string, maxSize int64) (Icon, error) {
+	candidates, err := Resolve(ctx, client, pageURL)
+	if err != nil {
+		return Icon{}, err
+	}
+
+	for _, candidate := range candidates {
+		icon, err := download(ctx, client, candidate, maxSize)
+		if err == nil {
+			return icon, nil
+		}
+	}
+
+	return Icon{}, ErrNotFound
+}
+
+// Resolve works out the URLs an icon for a page could be at, in the order they should be tried.
+// If the page can't be read only /favicon.ico is returned.
+func Resolve(ctx context.Context, client *http.Client, pageURL string) ([]string, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, final, err := fetchPage(ctx, client, pageURL)
+	if err != nil {
+		final = base
+	}
+
+	root := final.ResolveReference(&url.URL{Path: "/favicon.ico"}).String()
+	for _, candidate := range candidates {
+		if candidate == root {
+			return candidates, nil
+		}
+	}
+
+	return append(candidates, root), nil
+}
+
+// Hash identifies an icon by its content.
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// fetchPage downloads a page and finds the icons it declares.
+// The URL of the page after following redirects is also returned.
+func fetchPage(ctx context.Context, client *http.Client, pageURL string) ([]string, *url.URL, error) {
+	res, err := get(ctx, client, pageURL, "text/html,application/xhtml+xml")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	contentType := res.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, res.Request.URL, nil
+	}
+
+	reader, err := charset.NewReader(io.LimitReader(res.Body, maxPage), contentType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates, err := parse(reader, res.Request.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return candidates, res.Request.URL, nil
+}
+
+// parse finds the icons declared in the <head> of an HTML page.
+func parse(reader io.Reader, base *url.URL) ([]string, error) {
+	found := make(map[string][]string)
+	tokenizer := html.NewTokenizer(reader)
+
+loop:
+	for {
+		switch tokenizer.Next() {
+
+		case html.ErrorToken:
+			if !errors.Is(tokenizer.Err(), io.EOF) {
+				return nil, tokenizer.Err()
+			}
+			break loop
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+
+			switch token.Data {
+			case "link":
+				href, ok := web.Resolve(base, web.Attr(token, "href"))
+				if !ok {
+					continue
+				}
+
+				for _, rel := range iconRels {
+					if web.HasRel(web.Attr(token, "rel"), rel) {
+						found[rel] = append(found[rel], href.String())
+						break
+					}
+				}
+			case "body":
+				break loop
+			}
+
+		case html.EndTagToken:
+			if tokenizer.Token().Data == "head" {
+				break loop
+			}
+		}
+	}
+
+	candidates := make([]string, 0)
+	for _, rel := range iconRels {
+		candidates = append(candidates, found[rel]...)
+	}
+
+	return candidates, nil
+}
+
+// download gets an icon and checks that it's an image.
+func download(ctx context.Context, client *http.Client, iconURL string, maxSize int64) (Icon, error) {
+	res, err := get(ctx, client, iconURL, "image/*")
+	if err != nil {
+		return Icon{}, err
+	}
+	defer res.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return Icon{}, err
+	}
+
+	if int64(len(content)) > maxSize {
+		return Icon{}, ErrTooLarge
+	}
+
+	// Servers often send icons with a generic content type so the content is sniffed when that happens.
+	mediaType, _, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "image/") {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(content))
+	}
+
+	if !strings.HasPrefix(mediaType, "image/") {
+		return Icon{}, ErrNotImage
+	}
+
+	return Icon{
+		URL:         res.Request.URL.String(),
+		ContentType: mediaType,
+		Content:     content,
+		Hash:        Hash(content),
+	}, nil
+}
+
+// get makes a GET request and checks the status of the response.
+func get(ctx context.Context, client *http.Client, rawURL string, accept string) (*http.Response, error) {
+	req, err := web.NewRequest(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 400 {
+		res.Body.Close()
+		return nil, fmt.Errorf("%w: %d", ErrBadStatus, res.StatusCode)
+	}
+
+	return res, nil
+}
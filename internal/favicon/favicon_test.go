@@ -0,0 +1,130 @@
+package favicon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// png is the signature of a PNG which is enough for it to be sniffed as one.
+var png = []byte("\x89PNG\r\n\x1a\n")
+
+func TestParse(t *testing.T) {
+	type test struct {
+		name  string
+		input string
+		want  []string
+	}
+
+	tests := []test{
+		{
+			name: "icons in order of preference",
+			input: `<head>
+				<link rel="apple-touch-icon" href="/touch.png">
+				<link rel="shortcut icon" href="/shortcut.ico">
+				<link rel="icon" href="icon.png">
+				</head>`,
+			want: []string{"https://jho.pe/shortcut.ico", "https://jho.pe/posts/icon.png", "https://jho.pe/touch.png"},
+		},
+		{
+			name:  "no icons",
+			input: `<head><title>Blog</title></head><body><link rel="icon" href="/late.png"></body>`,
+			want:  []string{},
+		},
+		{
+			name:  "data URLs are ignored",
+			input: `<link rel="icon" href="data:image/png;base64,AAAA">`,
+			want:  []string{},
+		},
+	}
+
+	base, _ := url.Parse("https://jho.pe/posts/")
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parse(strings.NewReader(tc.input), base)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			diff := cmp.Diff(tc.want, got)
+			if diff != "" {
+				t.Errorf("Expected and actual icons different:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/declared", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		//nolint:errcheck
+		w.Write([]byte(`<head><link rel="icon" href="/icon.png"></head>`))
+	})
+	mux.HandleFunc("/icon.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		//nolint:errcheck
+		w.Write(png)
+	})
+	mux.HandleFunc("/favicon.ico", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/x-icon")
+		//nolint:errcheck
+		w.Write([]byte("\x00\x00\x01\x00"))
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		//nolint:errcheck
+		w.Write([]byte(`<head><link rel="icon" href="/missing.png"></head>`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("declared icon", func(t *testing.T) {
+		icon, err := Fetch(context.Background(), server.Client(), server.URL+"/declared", 1024)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := Icon{URL: server.URL + "/icon.png", ContentType: "image/png", Content: png, Hash: Hash(png)}
+		diff := cmp.Diff(want, icon)
+		if diff != "" {
+			t.Errorf("Expected and actual icon different:\n%s", diff)
+		}
+	})
+
+	t.Run("falls back to favicon.ico", func(t *testing.T) {
+		icon, err := Fetch(context.Background(), server.Client(), server.URL+"/broken", 1024)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if icon.URL != server.URL+"/favicon.ico" || icon.ContentType != "image/x-icon" {
+			t.Errorf("unexpected icon: %s %s", icon.URL, icon.ContentType)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := Fetch(context.Background(), server.Client(), server.URL+"/declared", 2)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected %s, got %v", ErrNotFound, err)
+		}
+	})
+}
+
+func TestHash(t *testing.T) {
+	if Hash(png) != Hash([]byte("\x89PNG\r\n\x1a\n")) {
+		t.Errorf("expected the same content to have the same hash")
+	}
+
+	if Hash(png) == Hash([]byte("GIF89a")) {
+		t.Errorf("expected different content to have different hashes")
+	}
+}
@@ -0,0 +1,117 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/jonathanhope/armaria/internal/web"
+)
+
+// maxRedirects is how many redirects are followed before giving up.
+const maxRedirects = 10
+
+// maxBody is how much of a response body is read before the connection is closed.
+const maxBody = 64 * 1024
+
+// ErrTooManyRedirects is returned when a URL redirects too many times.
+var ErrTooManyRedirects = errors.New("too many redirects")
+
+// Options control how URLs are checked.
+type Options struct {
+	Concurrency  int           // how many URLs are checked at once
+	Timeout      time.Duration // how long to wait for a URL before giving up
+	HostInterval time.Duration // how long to wait between requests to the same host
+}
+
+// Result is the outcome of checking a URL.
+type Result struct {
+	Status   int    // the final HTTP status; 0 if the request failed
+	Redirect string // the final URL if it's different than the checked URL
+	Err      error  // why the request failed
+}
+
+// Check checks a set of URLs.
+// The results are in the same order as the URLs.
+func Check(ctx context.Context, urls []string, options Options) []Result {
+	client := &http.Client{
+		Timeout: options.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return ErrTooManyRedirects
+			}
+			return nil
+		},
+	}
+
+	limiter := newHostLimiter(options.HostInterval)
+	results := make([]Result, len(urls))
+
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results[job] = checkURL(ctx, client, limiter, urls[job])
+			}
+		}()
+	}
+
+	for i := range urls {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// checkURL checks a single URL.
+func checkURL(ctx context.Context, client *http.Client, limiter *hostLimiter, rawURL string) Result {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	if err := limiter.wait(ctx, parsed.Host); err != nil {
+		return Result{Err: err}
+	}
+
+	req, err := web.NewRequest(ctx, rawURL)
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		// The URL is already known to the caller so only the underlying error is kept.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return Result{Err: err}
+	}
+	defer res.Body.Close()
+
+	// Reading some of the body lets servers that stream responses finish cleanly.
+	//nolint:errcheck
+	io.CopyN(io.Discard, res.Body, maxBody)
+
+	result := Result{Status: res.StatusCode}
+	if final := res.Request.URL.String(); final != rawURL {
+		result.Redirect = final
+	}
+
+	return result
+}
@@ -0,0 +1,147 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	urls := []string{
+		server.URL + "/ok",
+		server.URL + "/missing",
+		server.URL + "/moved",
+		server.URL + "/loop",
+		server.URL + "/slow",
+	}
+
+	results := Check(context.Background(), urls, Options{
+		Concurrency: 2,
+		Timeout:     100 * time.Millisecond,
+	})
+
+	if len(results) != len(urls) {
+		t.Fatalf("got %d results; want %d", len(results), len(urls))
+	}
+
+	if results[0].Status != http.StatusOK || results[0].Redirect != "" || results[0].Err != nil {
+		t.Errorf("ok: got %+v", results[0])
+	}
+
+	if results[1].Status != http.StatusNotFound || results[1].Err != nil {
+		t.Errorf("missing: got %+v", results[1])
+	}
+
+	if results[2].Status != http.StatusOK || results[2].Redirect != server.URL+"/ok" {
+		t.Errorf("moved: got %+v", results[2])
+	}
+
+	if !errors.Is(results[3].Err, ErrTooManyRedirects) {
+		t.Errorf("loop: got %+v; want %+v", results[3].Err, ErrTooManyRedirects)
+	}
+
+	if results[4].Status != 0 || results[4].Err == nil {
+		t.Errorf("slow: got %+v; want a timeout", results[4])
+	}
+}
+
+func TestCheckInvalidURL(t *testing.T) {
+	results := Check(context.Background(), []string{"://nope"}, Options{
+		Concurrency: 1,
+		Timeout:     time.Second,
+	})
+
+	if results[0].Err == nil {
+		t.Errorf("got %+v; want an error", results[0])
+	}
+}
+
+func TestCheckConcurrency(t *testing.T) {
+	var current atomic.Int32
+	var max atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+
+		for {
+			seen := max.Load()
+			if n <= seen || max.CompareAndSwap(seen, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	urls := make([]string, 12)
+	for i := range urls {
+		urls[i] = server.URL
+	}
+
+	Check(context.Background(), urls, Options{
+		Concurrency: 3,
+		Timeout:     time.Second,
+	})
+
+	if got := max.Load(); got > 3 {
+		t.Errorf("got %d concurrent requests; want at most 3", got)
+	}
+}
+
+func TestCheckHostInterval(t *testing.T) {
+	var mutex sync.Mutex
+	times := make([]time.Time, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		times = append(times, time.Now())
+		mutex.Unlock()
+	}))
+	defer server.Close()
+
+	interval := 50 * time.Millisecond
+	Check(context.Background(), []string{server.URL, server.URL, server.URL}, Options{
+		Concurrency:  3,
+		Timeout:      time.Second,
+		HostInterval: interval,
+	})
+
+	if len(times) != 3 {
+		t.Fatalf("got %d requests; want 3", len(times))
+	}
+
+	// Allow a little slack for timer resolution.
+	if elapsed := times[2].Sub(times[0]); elapsed < 2*interval-10*time.Millisecond {
+		t.Errorf("got requests %s apart; want at least %s", elapsed, 2*interval)
+	}
+}
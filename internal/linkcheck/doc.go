@@ -0,0 +1,5 @@
+// linkcheck contains the logic to check if URLs still resolve.
+// URLs are fetched concurrently by a bounded pool of workers.
+// Requests to the same host are spaced out so a single site isn't hammered.
+// Redirects are followed and the final URL is reported along with the final status.
+package linkcheck
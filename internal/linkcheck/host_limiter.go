@@ -0,0 +1,50 @@
+package linkcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// hostLimiter spaces out requests to the same host.
+type hostLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	next     map[string]time.Time
+}
+
+// newHostLimiter creates a hostLimiter that allows one request per interval for each host.
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// wait blocks until a request can be made to a host.
+// Each call reserves the next slot for the host so callers are served in order.
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	l.mutex.Lock()
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(l.interval)
+	l.mutex.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,5 @@
+// metadata contains the logic to fetch a web page and extract metadata about it.
+// The title, description, and canonical URL are read from the <head> of the page.
+// Open Graph tags (og:title, og:description) are used as fallbacks since many pages only set one or the other.
+// Only the <head> is parsed so large pages don't need to be read in full.
+package metadata
@@ -0,0 +1,9 @@
+package metadata
+
+import "errors"
+
+// ErrBadStatus is returned when a page responds with an error status.
+var ErrBadStatus = errors.New("bad status")
+
+// ErrNotHTML is returned when a URL doesn't point to an HTML page.
+var ErrNotHTML = errors.New("not an HTML page")
@@ -0,0 +1,147 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/web"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// maxPage is how much of a page is read before giving up on finding the end of the <head>.
+const maxPage = 1024 * 1024
+
+// Metadata is what was learned about a page.
+// Any of the fields can be empty if the page didn't provide them.
+type Metadata struct {
+	Title       string // <title> of the page, falling back to og:title
+	Description string // meta description of the page, falling back to og:description
+	Canonical   string // absolute canonical URL of the page
+}
+
+// Fetch downloads a page and extracts its metadata.
+func Fetch(ctx context.Context, client *http.Client, rawURL string) (Metadata, error) {
+	req, err := web.NewRequest(ctx, rawURL)
+	if err != nil {
+		return Metadata{}, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return Metadata{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return Metadata{}, fmt.Errorf("%w: %d", ErrBadStatus, res.StatusCode)
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+			return Metadata{}, ErrNotHTML
+		}
+	}
+
+	reader, err := charset.NewReader(io.LimitReader(res.Body, maxPage), contentType)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	return Parse(reader, res.Request.URL)
+}
+
+// Parse extracts the metadata from the <head> of an HTML page.
+// The base URL is used to resolve a relative canonical URL.
+func Parse(reader io.Reader, base *url.URL) (Metadata, error) {
+	var title, ogTitle, description, ogDescription, canonical string
+	var inTitle bool
+	var text strings.Builder
+
+	tokenizer := html.NewTokenizer(reader)
+
+loop:
+	for {
+		switch tokenizer.Next() {
+
+		case html.ErrorToken:
+			if !errors.Is(tokenizer.Err(), io.EOF) {
+				return Metadata{}, tokenizer.Err()
+			}
+			break loop
+
+		case html.TextToken:
+			if inTitle {
+				text.Write(tokenizer.Text())
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+
+			switch token.Data {
+			case "title":
+				inTitle = title == ""
+			case "meta":
+				content := web.Attr(token, "content")
+				switch {
+				case strings.EqualFold(web.Attr(token, "name"), "description"):
+					description = content
+				case web.Attr(token, "property") == "og:title":
+					ogTitle = content
+				case web.Attr(token, "property") == "og:description":
+					ogDescription = content
+				}
+			case "link":
+				if web.HasRel(web.Attr(token, "rel"), "canonical") {
+					if resolved, ok := web.Resolve(base, web.Attr(token, "href")); ok {
+						canonical = resolved.String()
+					}
+				}
+			case "body":
+				break loop
+			}
+
+		case html.EndTagToken:
+			switch tokenizer.Token().Data {
+			case "title":
+				if inTitle {
+					title = text.String()
+					inTitle = false
+				}
+			case "head":
+				break loop
+			}
+		}
+	}
+
+	return Metadata{
+		Title:       firstNonEmpty(clean(title), clean(ogTitle)),
+		Description: firstNonEmpty(clean(description), clean(ogDescription)),
+		Canonical:   canonical,
+	}, nil
+}
+
+// clean collapses runs of whitespace into single spaces.
+func clean(str string) string {
+	return strings.Join(strings.Fields(str), " ")
+}
+
+// firstNonEmpty returns the first string that isn't empty.
+func firstNonEmpty(strs ...string) string {
+	for _, str := range strs {
+		if str != "" {
+			return str
+		}
+	}
+
+	return ""
+}
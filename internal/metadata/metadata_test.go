@@ -0,0 +1,125 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	type test struct {
+		name  string
+		input string
+		want  Metadata
+	}
+
+	tests := []test{
+		{
+			name: "title and description",
+			input: `<html><head>
+				<title>  Flat
+				Field  </title>
+				<meta name="description" content="A blog about code.">
+				<link rel="canonical" href="/posts/">
+				</head><body></body></html>`,
+			want: Metadata{Title: "Flat Field", Description: "A blog about code.", Canonical: "https://jho.pe/posts/"},
+		},
+		{
+			name: "open graph fallback",
+			input: `<head>
+				<meta property="og:title" content="OG Title">
+				<meta property="og:description" content="OG Description">
+				</head>`,
+			want: Metadata{Title: "OG Title", Description: "OG Description"},
+		},
+		{
+			name: "prefers title over open graph",
+			input: `<head>
+				<meta property="og:title" content="OG Title">
+				<title>Title</title>
+				<meta property="og:description" content="OG Description">
+				<meta name="Description" content="Description">
+				</head>`,
+			want: Metadata{Title: "Title", Description: "Description"},
+		},
+		{
+			name:  "entities",
+			input: `<title>Tom &amp; Jerry</title>`,
+			want:  Metadata{Title: "Tom & Jerry"},
+		},
+		{
+			name:  "absolute canonical",
+			input: `<link rel="alternate canonical" href="https://example.com/page">`,
+			want:  Metadata{Canonical: "https://example.com/page"},
+		},
+		{
+			name:  "ignores body",
+			input: `<head></head><body><title>Not the title</title></body>`,
+			want:  Metadata{},
+		},
+	}
+
+	base, _ := url.Parse("https://jho.pe/posts/index.html")
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tc.input), base)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			diff := cmp.Diff(got, tc.want)
+			if diff != "" {
+				t.Errorf("Expected and actual metadata different:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		//nolint:errcheck
+		w.Write([]byte("<title>Caf\xe9</title><link rel=canonical href=/canonical>"))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	got, err := Fetch(context.Background(), server.Client(), server.URL+"/moved")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := Metadata{Title: "Café", Canonical: server.URL + "/canonical"}
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual metadata different:\n%s", diff)
+	}
+
+	_, err = Fetch(context.Background(), server.Client(), server.URL+"/missing")
+	if !errors.Is(err, ErrBadStatus) {
+		t.Errorf("got %+v; want %+v", err, ErrBadStatus)
+	}
+
+	_, err = Fetch(context.Background(), server.Client(), server.URL+"/image")
+	if !errors.Is(err, ErrNotHTML) {
+		t.Errorf("got %+v; want %+v", err, ErrNotHTML)
+	}
+}
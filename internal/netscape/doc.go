@@ -0,0 +1,6 @@
+// netscape contains the logic to read and write bookmarks stored in the Netscape bookmark file format.
+// Every major browser can export its bookmarks to this format (usually as bookmarks.html).
+// The format is loosely structured HTML: folders are <DT><H3> elements followed by a <DL> of children.
+// Bookmarks are <DT><A> elements, and an optional <DD> element after a bookmark holds its description.
+// Browsers are lenient about the markup so the parser here is too.
+package netscape
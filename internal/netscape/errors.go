@@ -0,0 +1,6 @@
+package netscape
+
+import "errors"
+
+// ErrInvalidFile is returned when a file is not a Netscape bookmark file.
+var ErrInvalidFile = errors.New("invalid bookmark file")
@@ -0,0 +1,11 @@
+package netscape
+
+// Node is a folder or bookmark in a Netscape bookmark file.
+type Node struct {
+	IsFolder    bool     // true if folder, and false otherwise
+	Name        string   // name of the folder/bookmark
+	URL         string   // address of a bookmark; not used for folders
+	Description string   // description of a bookmark; not used for folders
+	Tags        []string // tags applied to a bookmark; not used for folders
+	Children    []*Node  // the children of a folder; not used for bookmarks
+}
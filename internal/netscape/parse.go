@@ -0,0 +1,190 @@
+package netscape
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// capture is which text is currently being captured.
+type capture int
+
+const (
+	captureNone        capture = iota // not capturing text
+	captureName                       // capturing the name of a folder/bookmark
+	captureDescription                // capturing the description of a bookmark
+)
+
+// parser holds the state needed while walking the tokens of a Netscape bookmark file.
+type parser struct {
+	root      *Node           // holds the top level folders/bookmarks
+	stack     []*Node         // folders whose <DL> is currently open
+	pending   *Node           // folder that is waiting on its <DL>
+	last      *Node           // most recently parsed folder/bookmark
+	capture   capture         // which text is currently being captured
+	text      strings.Builder // text captured so far
+	foundList bool            // whether a <DL> has been seen
+}
+
+// Parse parses a Netscape bookmark file.
+// The top level folders and bookmarks are returned in the order they appear in the file.
+func Parse(reader io.Reader) ([]*Node, error) {
+	p := parser{root: &Node{IsFolder: true}}
+	tokenizer := html.NewTokenizer(reader)
+
+	for {
+		switch tokenizer.Next() {
+
+		case html.ErrorToken:
+			if !errors.Is(tokenizer.Err(), io.EOF) {
+				return nil, fmt.Errorf("error reading tokens while parsing bookmark file: %w", tokenizer.Err())
+			}
+
+			p.finishCapture()
+			if !p.foundList {
+				return nil, ErrInvalidFile
+			}
+
+			return p.root.Children, nil
+
+		case html.TextToken:
+			if p.capture != captureNone {
+				p.text.Write(tokenizer.Text())
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			p.startTag(tokenizer.Token())
+
+		case html.EndTagToken:
+			p.endTag(tokenizer.Token())
+		}
+	}
+}
+
+// startTag handles an opening tag.
+func (p *parser) startTag(token html.Token) {
+	switch token.Data {
+
+	case "dl":
+		p.finishCapture()
+		p.foundList = true
+
+		// A <DL> without a preceding <H3> is flattened into the current folder.
+		parent := p.pending
+		if parent == nil {
+			parent = p.current()
+		}
+
+		p.stack = append(p.stack, parent)
+		p.pending = nil
+		p.last = nil
+
+	case "dt":
+		p.finishCapture()
+		p.pending = nil
+		p.last = nil
+
+	case "h3":
+		p.finishCapture()
+
+		folder := &Node{IsFolder: true}
+		p.current().Children = append(p.current().Children, folder)
+		p.pending = folder
+		p.last = folder
+		p.capture = captureName
+
+	case "a":
+		p.finishCapture()
+
+		book := &Node{
+			URL:  attr(token, "href"),
+			Tags: parseTags(attr(token, "tags")),
+		}
+
+		// Firefox exports its smart bookmarks with place: URLs.
+		// They only make sense inside of Firefox so they are skipped.
+		if !strings.HasPrefix(book.URL, "place:") {
+			p.current().Children = append(p.current().Children, book)
+		}
+
+		p.last = book
+		p.capture = captureName
+
+	case "dd":
+		p.finishCapture()
+		p.capture = captureDescription
+	}
+}
+
+// endTag handles a closing tag.
+func (p *parser) endTag(token html.Token) {
+	switch token.Data {
+
+	case "dl":
+		p.finishCapture()
+		if len(p.stack) > 0 {
+			p.stack = p.stack[:len(p.stack)-1]
+		}
+		p.pending = nil
+		p.last = nil
+
+	case "h3", "a":
+		if p.capture == captureName {
+			p.finishCapture()
+		}
+	}
+}
+
+// current returns the folder that is currently having children added to it.
+func (p *parser) current() *Node {
+	if len(p.stack) == 0 {
+		return p.root
+	}
+
+	return p.stack[len(p.stack)-1]
+}
+
+// finishCapture stores any text that has been captured.
+func (p *parser) finishCapture() {
+	value := strings.TrimSpace(p.text.String())
+
+	switch p.capture {
+	case captureName:
+		p.last.Name = value
+	case captureDescription:
+		if p.last != nil && !p.last.IsFolder && value != "" {
+			p.last.Description = value
+		}
+	}
+
+	p.capture = captureNone
+	p.text.Reset()
+}
+
+// attr gets the value of an attribute on a tag.
+// An empty string is returned if the attribute isn't present.
+func attr(token html.Token, key string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+
+	return ""
+}
+
+// parseTags parses the comma separated TAGS attribute of a bookmark.
+func parseTags(tags string) []string {
+	parsed := make([]string, 0)
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			parsed = append(parsed, tag)
+		}
+	}
+
+	return parsed
+}
@@ -0,0 +1,162 @@
+package netscape
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	type test struct {
+		name  string
+		input string
+		want  []*Node
+	}
+
+	tests := []test{
+		{
+			name: "bookmarks and folders",
+			input: `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3 ADD_DATE="1700000000" PERSONAL_TOOLBAR_FOLDER="true">Blogs</H3>
+    <DL><p>
+        <DT><A HREF="https://jho.pe" ADD_DATE="1700000000" TAGS="blog,programming">The Flat Field</A>
+        <DD>The blog of Jonathan Hope.
+        <DT><H3>Empty</H3>
+        <DL><p>
+        </DL><p>
+    </DL><p>
+    <DT><A HREF="https://armaria.net">Armaria &amp; Friends</A>
+</DL><p>`,
+			want: []*Node{
+				{
+					IsFolder: true,
+					Name:     "Blogs",
+					Children: []*Node{
+						{
+							Name:        "The Flat Field",
+							URL:         "https://jho.pe",
+							Description: "The blog of Jonathan Hope.",
+							Tags:        []string{"blog", "programming"},
+						},
+						{
+							IsFolder: true,
+							Name:     "Empty",
+						},
+					},
+				},
+				{
+					Name: "Armaria & Friends",
+					URL:  "https://armaria.net",
+					Tags: []string{},
+				},
+			},
+		},
+		{
+			name: "unclosed tags",
+			input: `<DL>
+<DT><A HREF="https://jho.pe">jho.pe
+<DT><H3>Blogs
+<DL>
+<DT><A HREF="https://armaria.net">armaria.net
+</DL>`,
+			want: []*Node{
+				{
+					Name: "jho.pe",
+					URL:  "https://jho.pe",
+					Tags: []string{},
+				},
+				{
+					IsFolder: true,
+					Name:     "Blogs",
+					Children: []*Node{
+						{
+							Name: "armaria.net",
+							URL:  "https://armaria.net",
+							Tags: []string{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "place URLs are skipped",
+			input: `<DL><p>
+    <DT><A HREF="place:sort=8&maxResults=10">Recent Tags</A>
+    <DT><A HREF="https://jho.pe">jho.pe</A>
+</DL><p>`,
+			want: []*Node{
+				{
+					Name: "jho.pe",
+					URL:  "https://jho.pe",
+					Tags: []string{},
+				},
+			},
+		},
+		{
+			name: "folder descriptions are ignored",
+			input: `<DL><p>
+    <DT><H3>Blogs</H3>
+    <DD>Some blogs.
+    <DL><p>
+    </DL><p>
+</DL><p>`,
+			want: []*Node{
+				{
+					IsFolder: true,
+					Name:     "Blogs",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tc.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			diff := cmp.Diff(got, tc.want)
+			if diff != "" {
+				t.Errorf("Expected and actual nodes different:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseInvalidFile(t *testing.T) {
+	_, err := Parse(strings.NewReader("not a bookmark file"))
+	if !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("got %+v; want %+v", err, ErrInvalidFile)
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	type test struct {
+		input string
+		want  []string
+	}
+
+	tests := []test{
+		{input: "", want: []string{}},
+		{input: "one", want: []string{"one"}},
+		{input: "one, two ,,three", want: []string{"one", "two", "three"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got := parseTags(tc.input)
+
+			diff := cmp.Diff(got, tc.want)
+			if diff != "" {
+				t.Errorf("Expected and actual tags different:\n%s", diff)
+			}
+		})
+	}
+}
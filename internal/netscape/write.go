@@ -0,0 +1,84 @@
+package netscape
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// header is written at the start of every Netscape bookmark file.
+// Browsers look for the doctype to recognize the format.
+const header = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+`
+
+// indent is the indentation used for each level of the tree.
+const indent = "    "
+
+// Write writes folders and bookmarks as a Netscape bookmark file.
+func Write(writer io.Writer, nodes []*Node) error {
+	if _, err := io.WriteString(writer, header); err != nil {
+		return fmt.Errorf("error writing header while writing bookmark file: %w", err)
+	}
+
+	return writeList(writer, nodes, 0)
+}
+
+// writeList writes a level of the tree as a <DL>.
+func writeList(writer io.Writer, nodes []*Node, depth int) error {
+	prefix := strings.Repeat(indent, depth)
+
+	if _, err := fmt.Fprintf(writer, "%s<DL><p>\n", prefix); err != nil {
+		return fmt.Errorf("error writing list while writing bookmark file: %w", err)
+	}
+
+	for _, node := range nodes {
+		if err := writeNode(writer, node, depth+1); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(writer, "%s</DL><p>\n", prefix); err != nil {
+		return fmt.Errorf("error writing list while writing bookmark file: %w", err)
+	}
+
+	return nil
+}
+
+// writeNode writes a single folder or bookmark.
+// Folders will also have their children written.
+func writeNode(writer io.Writer, node *Node, depth int) error {
+	prefix := strings.Repeat(indent, depth)
+
+	if node.IsFolder {
+		if _, err := fmt.Fprintf(writer, "%s<DT><H3>%s</H3>\n", prefix, html.EscapeString(node.Name)); err != nil {
+			return fmt.Errorf("error writing folder while writing bookmark file: %w", err)
+		}
+
+		return writeList(writer, node.Children, depth)
+	}
+
+	var attrs strings.Builder
+	fmt.Fprintf(&attrs, ` HREF="%s"`, html.EscapeString(node.URL))
+	if len(node.Tags) > 0 {
+		fmt.Fprintf(&attrs, ` TAGS="%s"`, html.EscapeString(strings.Join(node.Tags, ",")))
+	}
+
+	if _, err := fmt.Fprintf(writer, "%s<DT><A%s>%s</A>\n", prefix, attrs.String(), html.EscapeString(node.Name)); err != nil {
+		return fmt.Errorf("error writing bookmark while writing bookmark file: %w", err)
+	}
+
+	if node.Description != "" {
+		if _, err := fmt.Fprintf(writer, "%s<DD>%s\n", prefix, html.EscapeString(node.Description)); err != nil {
+			return fmt.Errorf("error writing description while writing bookmark file: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package netscape
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrite(t *testing.T) {
+	nodes := []*Node{
+		{
+			IsFolder: true,
+			Name:     "Blogs & More",
+			Children: []*Node{
+				{
+					Name:        "The Flat Field",
+					URL:         "https://jho.pe",
+					Description: "The blog of Jonathan Hope.",
+					Tags:        []string{"blog", "programming"},
+				},
+			},
+		},
+		{
+			Name: "Armaria",
+			URL:  "https://armaria.net?a=1&b=2",
+		},
+	}
+
+	want := header + `<DL><p>
+    <DT><H3>Blogs &amp; More</H3>
+    <DL><p>
+        <DT><A HREF="https://jho.pe" TAGS="blog,programming">The Flat Field</A>
+        <DD>The blog of Jonathan Hope.
+    </DL><p>
+    <DT><A HREF="https://armaria.net?a=1&amp;b=2">Armaria</A>
+</DL><p>
+`
+
+	buffer := bytes.NewBuffer(nil)
+	if err := Write(buffer, nodes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(buffer.String(), want)
+	if diff != "" {
+		t.Errorf("Expected and actual output different:\n%s", diff)
+	}
+}
+
+func TestWriteParseLoop(t *testing.T) {
+	want := []*Node{
+		{
+			IsFolder: true,
+			Name:     "Blogs",
+			Children: []*Node{
+				{
+					Name:        "The Flat Field",
+					URL:         "https://jho.pe",
+					Description: "The blog of <Jonathan Hope>.",
+					Tags:        []string{"blog", "programming"},
+				},
+				{
+					IsFolder: true,
+					Name:     "Empty",
+				},
+			},
+		},
+		{
+			Name: "Armaria",
+			URL:  "https://armaria.net",
+			Tags: []string{},
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	if err := Write(buffer, want); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := Parse(buffer)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual nodes different:\n%s", diff)
+	}
+}
@@ -0,0 +1,3 @@
+// normalize puts values into a canonical form so that different ways of writing the same thing compare equal.
+// Bookmarks store their normalized URL so duplicates can be found with an index instead of by comparing every URL.
+package normalize
@@ -0,0 +1,57 @@
+package normalize
+
+import (
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query params that only exist to track where a visit came from.
+// Params starting with "utm_" are also removed.
+var trackingParams = []string{"fbclid", "gclid", "dclid", "msclkid", "mc_cid", "mc_eid", "igshid", "yclid"}
+
+// defaultPorts are the ports that are implied by a scheme.
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// URL normalizes a URL so that different ways of writing the same page compare equal.
+// The scheme and host are lower cased, default ports and trailing slashes are dropped,
+// tracking params (such as utm_*) are removed, and the remaining params are sorted.
+// URLs that can't be parsed are returned trimmed but otherwise unchanged.
+func URL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return rawURL
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	if port, ok := defaultPorts[parsed.Scheme]; ok {
+		parsed.Host = strings.TrimSuffix(parsed.Host, ":"+port)
+	}
+
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = strings.TrimRight(parsed.RawPath, "/")
+
+	query := parsed.Query()
+	for param := range query {
+		if strings.HasPrefix(strings.ToLower(param), "utm_") || containsFold(trackingParams, param) {
+			query.Del(param)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	parsed.ForceQuery = false
+
+	return parsed.String()
+}
+
+// containsFold checks if a slice contains a string ignoring case.
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+package normalize
+
+import "testing"
+
+func TestURL(t *testing.T) {
+	type test struct {
+		input string
+		want  string
+	}
+
+	tests := []test{
+		{input: "https://jho.pe", want: "https://jho.pe"},
+		{input: "HTTPS://JHO.PE/About", want: "https://jho.pe/About"},
+		{input: "https://jho.pe/", want: "https://jho.pe"},
+		{input: "https://jho.pe/posts/", want: "https://jho.pe/posts"},
+		{input: "https://jho.pe:443/posts", want: "https://jho.pe/posts"},
+		{input: "http://jho.pe:80/posts", want: "http://jho.pe/posts"},
+		{input: "http://jho.pe:8080/posts", want: "http://jho.pe:8080/posts"},
+		{input: "https://jho.pe/?utm_source=rss&utm_medium=feed", want: "https://jho.pe"},
+		{input: "https://jho.pe/?b=2&fbclid=abc&a=1", want: "https://jho.pe?a=1&b=2"},
+		{input: "https://jho.pe/?", want: "https://jho.pe"},
+		{input: "https://jho.pe/#about", want: "https://jho.pe#about"},
+		{input: "  https://jho.pe  ", want: "https://jho.pe"},
+		{input: "jho.pe", want: "jho.pe"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got := URL(tc.input)
+			if got != tc.want {
+				t.Errorf("got %s; want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,8 @@
 // Armaria stores its bookmarks in a SQLite DB and its config in a TOML file.
 // Both of these files need to be stored somewhere.
 // This file contains the logic to figure out where to store those files.
-// It also keeps track of where manifest files need to be installed for browser extensions.
+// It also keeps track of where manifest files need to be installed for browser extensions,
+// and where browsers keep the profiles bookmarks can be imported from.
 package paths
 
 import (
@@ -268,6 +269,67 @@ func chromiumManifestInternal(goos string, getenv getenvFn, userHome userHomeFn,
 	return join(folder, manifestFilename), nil
 }
 
+// FirefoxProfiles gets the path to the folder Firefox keeps its profiles in.
+// The folder contains a profiles.ini file that lists the profiles.
+// The path is different per platform and maps to the following:
+// - Linux: ~/.mozilla/firefox
+// - Windows: ~/AppData/Roaming/Mozilla/Firefox
+// - Mac: ~/Library/Application Support/Firefox
+func FirefoxProfiles() (string, error) {
+	return firefoxProfilesInternal(runtime.GOOS, os.Getenv, os.UserHomeDir, filepath.Join)
+}
+
+// firefoxProfilesInternal allows DI for FirefoxProfiles.
+func firefoxProfilesInternal(goos string, getenv getenvFn, userHome userHomeFn, join joinFn) (string, error) {
+	home, err := realHome(getenv, userHome)
+	if err != nil {
+		return "", fmt.Errorf("error getting real home dir while getting firefox profiles path: %w", err)
+	}
+
+	var folder string
+	if goos == "linux" {
+		folder = join(home, ".mozilla", "firefox")
+	} else if goos == "windows" {
+		folder = join(home, "AppData", "Roaming", "Mozilla", "Firefox")
+	} else if goos == "darwin" {
+		folder = join(home, "Library", "Application Support", "Firefox")
+	} else {
+		panic("Unsupported operating system")
+	}
+
+	return folder, nil
+}
+
+// ChromeProfile gets the path to the default Chrome profile.
+// The path is different per platform and maps to the following:
+// - Linux: ~/.config/google-chrome/Default
+// - Windows: ~/AppData/Local/Google/Chrome/User Data/Default
+// - Mac: ~/Library/Application Support/Google/Chrome/Default
+func ChromeProfile() (string, error) {
+	return chromeProfileInternal(runtime.GOOS, os.Getenv, os.UserHomeDir, filepath.Join)
+}
+
+// chromeProfileInternal allows DI for ChromeProfile.
+func chromeProfileInternal(goos string, getenv getenvFn, userHome userHomeFn, join joinFn) (string, error) {
+	home, err := realHome(getenv, userHome)
+	if err != nil {
+		return "", fmt.Errorf("error getting real home dir while getting chrome profile path: %w", err)
+	}
+
+	var folder string
+	if goos == "linux" {
+		folder = join(home, ".config", "google-chrome", "Default")
+	} else if goos == "windows" {
+		folder = join(home, "AppData", "Local", "Google", "Chrome", "User Data", "Default")
+	} else if goos == "darwin" {
+		folder = join(home, "Library", "Application Support", "Google", "Chrome", "Default")
+	} else {
+		panic("Unsupported operating system")
+	}
+
+	return folder, nil
+}
+
 // realHome returns the true home directory of the current user.
 // Snap will replace the $HOME env var with a sandboxed directory.
 func realHome(getenv getenvFn, userHome userHomeFn) (string, error) {
@@ -498,3 +498,101 @@ func TestChromiumManifestPath(t *testing.T) {
 		})
 	}
 }
+
+func TestFirefoxProfilesPath(t *testing.T) {
+	type test struct {
+		goos         string
+		snapRealHome string
+		profilesPath string
+	}
+
+	tests := []test{
+		{
+			goos:         "windows",
+			profilesPath: "~/AppData/Roaming/Mozilla/Firefox",
+		},
+		{
+			goos:         "linux",
+			profilesPath: "~/.mozilla/firefox",
+		},
+		{
+			goos:         "linux",
+			snapRealHome: "~/snap",
+			profilesPath: "~/snap/.mozilla/firefox",
+		},
+		{
+			goos:         "darwin",
+			profilesPath: "~/Library/Application Support/Firefox",
+		},
+	}
+
+	userHome := func() (string, error) {
+		return "~", nil
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("GOOS: %s, SNAP_REAL_HOME: %s", tc.goos, tc.snapRealHome), func(t *testing.T) {
+			getenv := func(key string) string {
+				return tc.snapRealHome
+			}
+
+			got, err := firefoxProfilesInternal(tc.goos, getenv, userHome, path.Join)
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+
+			if got != tc.profilesPath {
+				t.Errorf("profilesPath: got %+v; want %+v", got, tc.profilesPath)
+			}
+		})
+	}
+}
+
+func TestChromeProfilePath(t *testing.T) {
+	type test struct {
+		goos         string
+		snapRealHome string
+		profilePath  string
+	}
+
+	tests := []test{
+		{
+			goos:        "windows",
+			profilePath: "~/AppData/Local/Google/Chrome/User Data/Default",
+		},
+		{
+			goos:        "linux",
+			profilePath: "~/.config/google-chrome/Default",
+		},
+		{
+			goos:         "linux",
+			snapRealHome: "~/snap",
+			profilePath:  "~/snap/.config/google-chrome/Default",
+		},
+		{
+			goos:        "darwin",
+			profilePath: "~/Library/Application Support/Google/Chrome/Default",
+		},
+	}
+
+	userHome := func() (string, error) {
+		return "~", nil
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("GOOS: %s, SNAP_REAL_HOME: %s", tc.goos, tc.snapRealHome), func(t *testing.T) {
+			getenv := func(key string) string {
+				return tc.snapRealHome
+			}
+
+			got, err := chromeProfileInternal(tc.goos, getenv, userHome, path.Join)
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+
+			if got != tc.profilePath {
+				t.Errorf("profilePath: got %+v; want %+v", got, tc.profilePath)
+			}
+		})
+	}
+}
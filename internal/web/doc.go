@@ -0,0 +1,4 @@
+// web contains the helpers shared by the packages that fetch and parse web pages.
+// Every request is sent with the same user agent since some sites reject requests that don't have one.
+// Pages are read with the golang.org/x/net/html tokenizer so attributes and references are handled the same way everywhere.
+package web
@@ -0,0 +1,56 @@
+package web
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Attr gets the value of an attribute of a token.
+// An empty string is returned if the attribute isn't present.
+func Attr(token html.Token, name string) string {
+	for _, a := range token.Attr {
+		if strings.EqualFold(a.Key, name) {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+// HasRel checks if a space separated rel attribute contains a value.
+func HasRel(rel string, value string) bool {
+	for _, r := range strings.Fields(rel) {
+		if strings.EqualFold(r, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Resolve makes a reference absolute using the URL of the page it was found on.
+// If there is no base URL only absolute references can be resolved.
+// Invalid references and references that can't be downloaded over HTTP are ignored.
+func Resolve(base *url.URL, ref string) (*url.URL, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, false
+	}
+
+	resolved, err := url.Parse(ref)
+	if err != nil {
+		return nil, false
+	}
+
+	if base != nil {
+		resolved = base.ResolveReference(resolved)
+	}
+
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return nil, false
+	}
+
+	return resolved, true
+}
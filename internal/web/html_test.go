@@ -0,0 +1,69 @@
+package web
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestHasRel(t *testing.T) {
+	type test struct {
+		rel   string
+		value string
+		want  bool
+	}
+
+	tests := []test{
+		{rel: "icon", value: "icon", want: true},
+		{rel: "shortcut ICON", value: "icon", want: true},
+		{rel: "alternate canonical", value: "canonical", want: true},
+		{rel: "apple-touch-icon", value: "icon", want: false},
+		{rel: "", value: "icon", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.rel, func(t *testing.T) {
+			got := HasRel(tc.rel, tc.value)
+			if got != tc.want {
+				t.Errorf("got %t; want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	base, err := url.Parse("https://jho.pe/posts/")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	type test struct {
+		name string
+		base *url.URL
+		ref  string
+		want string
+	}
+
+	tests := []test{
+		{name: "relative", base: base, ref: "favicon.ico", want: "https://jho.pe/posts/favicon.ico"},
+		{name: "root relative", base: base, ref: " /favicon.ico ", want: "https://jho.pe/favicon.ico"},
+		{name: "absolute", base: base, ref: "http://armaria.net/", want: "http://armaria.net/"},
+		{name: "no base", base: nil, ref: "https://armaria.net/", want: "https://armaria.net/"},
+		{name: "no base relative", base: nil, ref: "/favicon.ico", want: ""},
+		{name: "not http", base: base, ref: "data:image/png;base64,AA==", want: ""},
+		{name: "empty", base: base, ref: "", want: ""},
+		{name: "invalid", base: base, ref: "%zz", want: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ""
+			if resolved, ok := Resolve(tc.base, tc.ref); ok {
+				got = resolved.String()
+			}
+
+			if got != tc.want {
+				t.Errorf("got %s; want %s", got, tc.want)
+			}
+		})
+	}
+}
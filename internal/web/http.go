@@ -0,0 +1,21 @@
+package web
+
+import (
+	"context"
+	"net/http"
+)
+
+// UserAgent is sent with every request.
+// Some sites reject requests that don't have one.
+const UserAgent = "Armaria"
+
+// NewRequest makes a GET request for a URL with the user agent set.
+func NewRequest(ctx context.Context, rawURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	return req, nil
+}
@@ -1,9 +1,10 @@
 package armaria
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
-	"errors"
 	"github.com/jonathanhope/armaria/internal/db"
 	"github.com/jonathanhope/armaria/internal/null"
 	"github.com/jonathanhope/armaria/internal/order"
@@ -12,11 +13,15 @@ import (
 
 // addBookOptions are the optional arguments for AddBook.
 type addBookOptions struct {
-	DB          null.NullString
-	Name        null.NullString
-	Description null.NullString
-	ParentID    null.NullString
-	Tags        []string
+	DB               null.NullString
+	Name             null.NullString
+	Description      null.NullString
+	ParentID         null.NullString
+	Tags             []string
+	RejectDuplicates bool
+	FetchMetadata    bool
+	UseCanonical     bool
+	HTTPClient       *http.Client
 }
 
 // DefaultAddBookOptions are the default options for AddBook.
@@ -54,6 +59,33 @@ func (o *addBookOptions) WithTags(tags []string) *addBookOptions {
 	return o
 }
 
+// WithRejectDuplicates rejects the bookmark if one with the same URL already exists.
+// URLs are compared after they have been normalized with NormalizeURL.
+func (o *addBookOptions) WithRejectDuplicates(rejectDuplicates bool) *addBookOptions {
+	o.RejectDuplicates = rejectDuplicates
+	return o
+}
+
+// WithFetchMetadata downloads the page to fill in the name and description if they aren't provided.
+// The bookmark is still added if the page can't be fetched.
+func (o *addBookOptions) WithFetchMetadata(fetchMetadata bool) *addBookOptions {
+	o.FetchMetadata = fetchMetadata
+	return o
+}
+
+// WithUseCanonical replaces the URL with the canonical URL of the page if it has one.
+// It only has an effect when fetching metadata.
+func (o *addBookOptions) WithUseCanonical(useCanonical bool) *addBookOptions {
+	o.UseCanonical = useCanonical
+	return o
+}
+
+// WithHTTPClient sets the HTTP client used to fetch metadata.
+func (o *addBookOptions) WithHTTPClient(client *http.Client) *addBookOptions {
+	o.HTTPClient = client
+	return o
+}
+
 // AddBook adds a bookmark to the bookmarks database.
 func AddBook(url string, options *addBookOptions) (Book, error) {
 	config, err := GetConfig()
@@ -61,79 +93,133 @@ func AddBook(url string, options *addBookOptions) (Book, error) {
 		return Book{}, fmt.Errorf("error getting config while adding bookmark: %w", err)
 	}
 
+	// The page is fetched before the transaction starts so the database isn't held open on the network.
+	url = applyMetadata(url, options)
+
 	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
-		// Default name to URL if not provided.
-		if !options.Name.Valid {
-			options.Name = null.NullStringFrom(url)
+		book, err := addBook(tx, url, options)
+		if err != nil {
+			return Book{}, err
 		}
 
-		if err := validateURL(null.NullStringFrom(url)); err != nil {
-			return Book{}, fmt.Errorf("URL validation failed while adding bookmark: %w", err)
+		description := fmt.Sprintf("Added bookmark %q", book.Name)
+		if err := record(tx, OperationAddBook, description, []string{book.ID}, journalState{}); err != nil {
+			return Book{}, fmt.Errorf("error recording operation while adding bookmark: %w", err)
 		}
 
-		if err := validateName(options.Name); err != nil {
-			return Book{}, fmt.Errorf("name validation failed while adding bookmark: %w", err)
-		}
+		return book, nil
+	})
+}
 
-		if err := validateDescription(options.Description); err != nil {
-			return Book{}, fmt.Errorf("description validation failed while adding bookmark: %w", err)
-		}
+// applyMetadata fills in the name and description of a bookmark from its page if it was asked to.
+// The URL to add the bookmark with is returned since it can be replaced by the page's canonical URL.
+func applyMetadata(url string, options *addBookOptions) string {
+	if !options.FetchMetadata || validateURL(null.NullStringFrom(url)) != nil {
+		return url
+	}
 
-		if err := validateParentID(tx, options.ParentID); err != nil {
-			return Book{}, fmt.Errorf("parent ID validation failed while adding bookmark: %w", err)
-		}
+	meta, err := fetchMetadata(options.HTTPClient, url)
+	if err != nil {
+		return url
+	}
 
-		if err := validateTags(options.Tags, make([]string, 0)); err != nil {
-			return Book{}, fmt.Errorf("tags validation failed while adding bookmark: %w", err)
-		}
+	if !options.Name.Valid && meta.Title != "" {
+		options.Name = null.NullStringFrom(meta.Title)
+	}
+	if !options.Description.Valid && meta.Description != "" {
+		options.Description = null.NullStringFrom(meta.Description)
+	}
+	if options.UseCanonical && validateURL(null.NullStringFrom(meta.Canonical)) == nil {
+		url = meta.Canonical
+	}
+
+	return url
+}
 
-		previous, err := db.MaxOrder(tx, options.ParentID)
+// addBook adds a bookmark to the bookmarks database using an existing transaction.
+func addBook(tx db.Transaction, url string, options *addBookOptions) (Book, error) {
+	// Default name to URL if not provided.
+	if !options.Name.Valid {
+		options.Name = null.NullStringFrom(url)
+	}
+
+	if err := validateURL(null.NullStringFrom(url)); err != nil {
+		return Book{}, fmt.Errorf("URL validation failed while adding bookmark: %w", err)
+	}
+
+	if err := validateName(options.Name); err != nil {
+		return Book{}, fmt.Errorf("name validation failed while adding bookmark: %w", err)
+	}
+
+	if err := validateDescription(options.Description); err != nil {
+		return Book{}, fmt.Errorf("description validation failed while adding bookmark: %w", err)
+	}
+
+	if err := validateParentID(tx, options.ParentID); err != nil {
+		return Book{}, fmt.Errorf("parent ID validation failed while adding bookmark: %w", err)
+	}
+
+	if err := validateTags(options.Tags, make([]string, 0)); err != nil {
+		return Book{}, fmt.Errorf("tags validation failed while adding bookmark: %w", err)
+	}
+
+	if options.RejectDuplicates {
+		duplicate, err := db.URLExists(tx, url)
 		if err != nil {
-			return Book{}, fmt.Errorf("error getting max order while adding bookmark: %w", err)
+			return Book{}, fmt.Errorf("error checking for duplicates while adding bookmark: %w", err)
 		}
 
-		var current string
-		if previous == "" {
-			current, err = order.Initial()
-			if err != nil {
-				return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
-			}
-		} else {
-			current, err = order.End(previous)
-			if err != nil {
-				return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
-			}
+		if duplicate {
+			return Book{}, fmt.Errorf("URL validation failed while adding bookmark: %w", ErrDuplicateURL)
 		}
+	}
+
+	previous, err := db.MaxOrder(tx, options.ParentID)
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting max order while adding bookmark: %w", err)
+	}
 
-		id, err := db.AddBook(tx, url, options.Name.String, options.Description, options.ParentID, current)
+	var current string
+	if previous == "" {
+		current, err = order.Initial()
 		if err != nil {
-			return Book{}, fmt.Errorf("error while adding bookmark: %w", err)
+			return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
 		}
-
-		existingTags, err := db.GetTags(tx, db.GetTagsArgs{
-			TagsFilter: options.Tags,
-		})
+	} else {
+		current, err = order.End(previous)
 		if err != nil {
-			return Book{}, fmt.Errorf("error getting tags while adding bookmark: %w", err)
+			return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
 		}
+	}
 
-		tagsToAdd, _ := lo.Difference(options.Tags, existingTags)
-		if err = db.AddTags(tx, tagsToAdd); err != nil {
-			return Book{}, fmt.Errorf("error adding tags while adding bookmark: %w", err)
-		}
+	id, err := db.AddBook(tx, url, options.Name.String, options.Description, options.ParentID, current)
+	if err != nil {
+		return Book{}, fmt.Errorf("error while adding bookmark: %w", err)
+	}
 
-		if err = db.LinkTags(tx, id, options.Tags); err != nil {
-			return Book{}, fmt.Errorf("error linking tags while adding bookmark: %w", err)
-		}
+	existingTags, err := db.GetTags(tx, db.GetTagsArgs{
+		TagsFilter: options.Tags,
+	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting tags while adding bookmark: %w", err)
+	}
 
-		books, err := db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:     id,
-			IncludeBooks: true,
-		})
-		if err != nil {
-			return Book{}, fmt.Errorf("error getting bookmarks while adding bookmark: %w", err)
-		}
+	tagsToAdd, _ := lo.Difference(options.Tags, existingTags)
+	if err = db.AddTags(tx, tagsToAdd); err != nil {
+		return Book{}, fmt.Errorf("error adding tags while adding bookmark: %w", err)
+	}
 
-		return toBook(books[0]), nil
+	if err = db.LinkTags(tx, id, options.Tags); err != nil {
+		return Book{}, fmt.Errorf("error linking tags while adding bookmark: %w", err)
+	}
+
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:     id,
+		IncludeBooks: true,
 	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting bookmarks while adding bookmark: %w", err)
+	}
+
+	return toBook(books[0]), nil
 }
@@ -40,45 +40,60 @@ func AddFolder(name string, options *addFolderOptions) (Book, error) {
 	}
 
 	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
-		if err := validateName(null.NullStringFrom(name)); err != nil {
-			return Book{}, fmt.Errorf("name validation failed while adding folder: %w", err)
+		book, err := addFolder(tx, name, options)
+		if err != nil {
+			return Book{}, err
 		}
 
-		if err := validateParentID(tx, options.ParentID); err != nil {
-			return Book{}, fmt.Errorf("parent ID validation failed while adding folder: %w", err)
+		description := fmt.Sprintf("Added folder %q", book.Name)
+		if err := record(tx, OperationAddFolder, description, []string{book.ID}, journalState{}); err != nil {
+			return Book{}, fmt.Errorf("error recording operation while adding folder: %w", err)
 		}
 
-		previous, err := db.MaxOrder(tx, options.ParentID)
-		if err != nil {
-			return Book{}, fmt.Errorf("error getting max order while adding bookmark: %w", err)
-		}
+		return book, nil
+	})
+}
 
-		var current string
-		if previous == "" {
-			current, err = order.Initial()
-			if err != nil {
-				return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
-			}
-		} else {
-			current, err = order.End(previous)
-			if err != nil {
-				return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
-			}
-		}
+// addFolder adds a folder to the bookmarks database using an existing transaction.
+func addFolder(tx db.Transaction, name string, options *addFolderOptions) (Book, error) {
+	if err := validateName(null.NullStringFrom(name)); err != nil {
+		return Book{}, fmt.Errorf("name validation failed while adding folder: %w", err)
+	}
+
+	if err := validateParentID(tx, options.ParentID); err != nil {
+		return Book{}, fmt.Errorf("parent ID validation failed while adding folder: %w", err)
+	}
 
-		id, err := db.AddFolder(tx, name, options.ParentID, current)
+	previous, err := db.MaxOrder(tx, options.ParentID)
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting max order while adding bookmark: %w", err)
+	}
+
+	var current string
+	if previous == "" {
+		current, err = order.Initial()
 		if err != nil {
-			return Book{}, fmt.Errorf("error while adding folder: %w", err)
+			return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
 		}
-
-		books, err := db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:       id,
-			IncludeFolders: true,
-		})
+	} else {
+		current, err = order.End(previous)
 		if err != nil {
-			return Book{}, fmt.Errorf("error getting folders while adding folder: %w", err)
+			return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
 		}
+	}
 
-		return toBook(books[0]), nil
+	id, err := db.AddFolder(tx, name, options.ParentID, current)
+	if err != nil {
+		return Book{}, fmt.Errorf("error while adding folder: %w", err)
+	}
+
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:       id,
+		IncludeFolders: true,
 	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting folders while adding folder: %w", err)
+	}
+
+	return toBook(books[0]), nil
 }
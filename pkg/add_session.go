@@ -0,0 +1,94 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// addSessionOptions are the optional arguments for AddSession.
+type addSessionOptions struct {
+	DB       null.NullString
+	ParentID null.NullString
+	Tags     []string
+}
+
+// DefaultAddSessionOptions are the default options for AddSession.
+func DefaultAddSessionOptions() *addSessionOptions {
+	return &addSessionOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *addSessionOptions) WithDB(db string) *addSessionOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID sets the session folder's parent ID.
+func (o *addSessionOptions) WithParentID(parentID string) *addSessionOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// WithTags sets the tags applied to every bookmark in the session.
+func (o *addSessionOptions) WithTags(tags []string) *addSessionOptions {
+	o.Tags = tags
+	return o
+}
+
+// AddSession adds a folder with a bookmark for each URL in it.
+// The bookmarks are kept in the same order as the URLs and all have the same tags.
+// The folder is returned first followed by the bookmarks.
+// Either the folder and all of the bookmarks are added or none of them are.
+func AddSession(name string, urls []string, options *addSessionOptions) ([]Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while adding session: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		return addSession(tx, name, urls, options)
+	})
+}
+
+// addSession adds a folder with a bookmark for each URL in it using an existing transaction.
+func addSession(tx db.Transaction, name string, urls []string, options *addSessionOptions) ([]Book, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("URLs validation failed while adding session: %w", ErrNoURLs)
+	}
+
+	folderOptions := DefaultAddFolderOptions()
+	if options.ParentID.Valid {
+		folderOptions.WithParentID(options.ParentID.String)
+	}
+
+	folder, err := addFolder(tx, name, folderOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error adding folder while adding session: %w", err)
+	}
+
+	books := []Book{folder}
+	for _, url := range urls {
+		bookOptions := DefaultAddBookOptions()
+		bookOptions.WithParentID(folder.ID)
+		if options.Tags != nil {
+			bookOptions.WithTags(options.Tags)
+		}
+
+		book, err := addBook(tx, url, bookOptions)
+		if err != nil {
+			return nil, fmt.Errorf("error adding bookmark while adding session: %w", err)
+		}
+
+		books = append(books, book)
+	}
+
+	description := fmt.Sprintf("Added session %q", folder.Name)
+	if err := record(tx, OperationAddSession, description, bookIDs(books), journalState{}); err != nil {
+		return nil, fmt.Errorf("error recording operation while adding session: %w", err)
+	}
+
+	return books, nil
+}
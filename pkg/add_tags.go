@@ -3,6 +3,7 @@ package armaria
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jonathanhope/armaria/internal/db"
 	"github.com/jonathanhope/armaria/internal/null"
@@ -33,48 +34,65 @@ func AddTags(id string, tags []string, options *addTagsOptions) (Book, error) {
 	}
 
 	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
-		books, err := db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:     id,
-			IncludeBooks: true,
-		})
-		if err != nil {
-			return Book{}, fmt.Errorf("error getting tags while adding tags: %w", err)
-		}
-
-		if len(books) != 1 || books[0].IsFolder {
-			return Book{}, ErrBookNotFound
-		}
-
-		book := toBook(books[0])
-
-		if err := validateTags(tags, book.Tags); err != nil {
-			return Book{}, fmt.Errorf("tags validation failed while adding tags: %w", err)
-		}
-
-		existingTags, err := db.GetTags(tx, db.GetTagsArgs{
-			TagsFilter: tags,
-		})
-		if err != nil {
-			return Book{}, err
-		}
-
-		tagsToAdd, _ := lo.Difference(tags, existingTags)
-		if err = db.AddTags(tx, tagsToAdd); err != nil {
-			return Book{}, fmt.Errorf("error while adding tags: %w", err)
-		}
-
-		if err = db.LinkTags(tx, id, tags); err != nil {
-			return Book{}, fmt.Errorf("error linking tags while adding tags: %w", err)
-		}
-
-		books, err = db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:     id,
-			IncludeBooks: true,
-		})
-		if err != nil {
-			return Book{}, fmt.Errorf("error getting bookmarks while adding tags: %w", err)
-		}
-
-		return toBook(books[0]), nil
+		return addTags(tx, id, tags)
 	})
 }
+
+// addTags adds tags to a bookmark using an existing transaction.
+func addTags(tx db.Transaction, id string, tags []string) (Book, error) {
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:     id,
+		IncludeBooks: true,
+	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting tags while adding tags: %w", err)
+	}
+
+	if len(books) != 1 || books[0].IsFolder {
+		return Book{}, ErrBookNotFound
+	}
+
+	book := toBook(books[0])
+
+	before, err := snapshot(tx, []string{id})
+	if err != nil {
+		return Book{}, fmt.Errorf("error taking snapshot while adding tags: %w", err)
+	}
+
+	if err := validateTags(tags, book.Tags); err != nil {
+		return Book{}, fmt.Errorf("tags validation failed while adding tags: %w", err)
+	}
+
+	existingTags, err := db.GetTags(tx, db.GetTagsArgs{
+		TagsFilter: tags,
+	})
+	if err != nil {
+		return Book{}, err
+	}
+
+	tagsToAdd, _ := lo.Difference(tags, existingTags)
+	if err = db.AddTags(tx, tagsToAdd); err != nil {
+		return Book{}, fmt.Errorf("error while adding tags: %w", err)
+	}
+
+	if err = db.LinkTags(tx, id, tags); err != nil {
+		return Book{}, fmt.Errorf("error linking tags while adding tags: %w", err)
+	}
+
+	books, err = db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:     id,
+		IncludeBooks: true,
+	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting bookmarks while adding tags: %w", err)
+	}
+
+	book = toBook(books[0])
+
+	description := fmt.Sprintf("Added tags %s to %q", strings.Join(tags, ", "), book.Name)
+	if err := record(tx, OperationAddTags, description, []string{id}, before); err != nil {
+		return Book{}, fmt.Errorf("error recording operation while adding tags: %w", err)
+	}
+
+	return book, nil
+}
@@ -0,0 +1,71 @@
+package armaria
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jonathanhope/armaria/internal/archive"
+	"github.com/jonathanhope/armaria/internal/db"
+)
+
+// defaultArchiveTimeout is how long to wait for a page or resource when no HTTP client is provided.
+const defaultArchiveTimeout = 30 * time.Second
+
+// maxArchiveSize is the max size of an archived page, and of each resource inlined into it.
+const maxArchiveSize = 10 * 1024 * 1024
+
+// maxArchiveInlined is the max total size of the resources inlined into an archived page.
+const maxArchiveInlined = 20 * 1024 * 1024
+
+// Archive is an offline copy of a bookmark's page.
+type Archive struct {
+	BookID      string // the bookmark the page belongs to
+	URL         string // the URL the page was downloaded from after following redirects
+	ContentType string // the media type of the page
+	Size        int64  // the size of the page in bytes
+	Inlined     bool   // whether stylesheets and images were inlined into the page
+	Archived    string // when the page was archived (UTC)
+	Content     []byte // the page itself; only populated by GetArchive
+}
+
+// fetchArchive downloads an offline copy of a bookmark's page.
+// If no HTTP client is provided one with a default timeout is used.
+func fetchArchive(client *http.Client, bookID string, url string, inline bool) (db.ArchiveDTO, error) {
+	if client == nil {
+		client = &http.Client{Timeout: defaultArchiveTimeout}
+	}
+
+	page, err := archive.Fetch(context.Background(), client, url, archive.Options{
+		Inline:     inline,
+		MaxSize:    maxArchiveSize,
+		MaxInlined: maxArchiveInlined,
+	})
+	if err != nil {
+		return db.ArchiveDTO{}, err
+	}
+
+	return db.ArchiveDTO{
+		BookmarkID:  bookID,
+		URL:         page.URL,
+		ContentType: page.ContentType,
+		Content:     page.Content,
+		Text:        page.Text,
+		Inlined:     page.Inlined,
+		Archived:    time.Now().UTC().Format(time.DateTime),
+		Size:        int64(len(page.Content)),
+	}, nil
+}
+
+// toArchive converts an ArchiveDTO to an Archive.
+func toArchive(dto db.ArchiveDTO) Archive {
+	return Archive{
+		BookID:      dto.BookmarkID,
+		URL:         dto.URL,
+		ContentType: dto.ContentType,
+		Size:        dto.Size,
+		Inlined:     dto.Inlined,
+		Archived:    dto.Archived,
+		Content:     dto.Content,
+	}
+}
@@ -0,0 +1,87 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// archiveBookOptions are the optional arguments for ArchiveBook.
+type archiveBookOptions struct {
+	DB         null.NullString
+	Inline     bool
+	HTTPClient *http.Client
+}
+
+// DefaultArchiveBookOptions are the default options for ArchiveBook.
+func DefaultArchiveBookOptions() *archiveBookOptions {
+	return &archiveBookOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *archiveBookOptions) WithDB(db string) *archiveBookOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithInline sets whether stylesheets and images are inlined so the page is a single file.
+func (o *archiveBookOptions) WithInline(inline bool) *archiveBookOptions {
+	o.Inline = inline
+	return o
+}
+
+// WithHTTPClient sets the HTTP client used to download the page.
+func (o *archiveBookOptions) WithHTTPClient(client *http.Client) *archiveBookOptions {
+	o.HTTPClient = client
+	return o
+}
+
+// ArchiveBook downloads an offline copy of a bookmark's page.
+// If the bookmark was already archived the old copy is replaced.
+// The text of the page is included when searching bookmarks.
+func ArchiveBook(id string, options *archiveBookOptions) (Archive, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return Archive{}, fmt.Errorf("error getting config while archiving bookmark: %w", err)
+	}
+
+	book, err := db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
+		if err := validateBookID(tx, id); err != nil {
+			return Book{}, fmt.Errorf("bookmark ID validation failed while archiving bookmark: %w", err)
+		}
+
+		return getBook(tx, id)
+	})
+	if err != nil {
+		return Archive{}, err
+	}
+
+	// The page is downloaded before the transaction starts so the database isn't held open on the network.
+	dto, err := fetchArchive(options.HTTPClient, book.ID, *book.URL, options.Inline)
+	if err != nil {
+		return Archive{}, fmt.Errorf("error downloading page while archiving bookmark: %w", err)
+	}
+
+	err = db.ExecWithTransaction(options.DB, config.DB, func(tx db.Transaction) error {
+		if err := validateBookID(tx, id); err != nil {
+			return fmt.Errorf("bookmark ID validation failed while archiving bookmark: %w", err)
+		}
+
+		if err := db.UpsertArchive(tx, dto); err != nil {
+			return fmt.Errorf("error storing archive while archiving bookmark: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Archive{}, err
+	}
+
+	archive := toArchive(dto)
+	archive.Content = nil
+
+	return archive, nil
+}
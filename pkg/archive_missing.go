@@ -0,0 +1,110 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// archiveMissingOptions are the optional arguments for ArchiveMissing.
+type archiveMissingOptions struct {
+	DB         null.NullString
+	Inline     bool
+	HTTPClient *http.Client
+}
+
+// DefaultArchiveMissingOptions are the default options for ArchiveMissing.
+func DefaultArchiveMissingOptions() *archiveMissingOptions {
+	return &archiveMissingOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *archiveMissingOptions) WithDB(db string) *archiveMissingOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithInline sets whether stylesheets and images are inlined so each page is a single file.
+func (o *archiveMissingOptions) WithInline(inline bool) *archiveMissingOptions {
+	o.Inline = inline
+	return o
+}
+
+// WithHTTPClient sets the HTTP client used to download the pages.
+func (o *archiveMissingOptions) WithHTTPClient(client *http.Client) *archiveMissingOptions {
+	o.HTTPClient = client
+	return o
+}
+
+// ArchiveMissing downloads an offline copy of the page of every bookmark that hasn't been archived yet.
+// Bookmarks whose pages can't be downloaded are skipped.
+// The archives that were made are returned.
+func ArchiveMissing(options *archiveMissingOptions) ([]Archive, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while archiving bookmarks: %w", err)
+	}
+
+	books, err := db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		books, err := getBookmarksIn(tx, null.NullString{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting bookmarks while archiving bookmarks: %w", err)
+		}
+
+		archives, err := db.GetArchives(tx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting archives while archiving bookmarks: %w", err)
+		}
+
+		archived := lo.KeyBy(archives, func(archive db.ArchiveDTO) string {
+			return archive.BookmarkID
+		})
+
+		return lo.Filter(books, func(book Book, _ int) bool {
+			_, ok := archived[book.ID]
+			return !ok
+		}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The pages are downloaded before the transaction starts so the database isn't held open on the network.
+	dtos := make([]db.ArchiveDTO, 0)
+	for _, book := range books {
+		dto, err := fetchArchive(options.HTTPClient, book.ID, *book.URL, options.Inline)
+		if err != nil {
+			continue
+		}
+		dtos = append(dtos, dto)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Archive, error) {
+		archives := make([]Archive, 0)
+
+		for _, dto := range dtos {
+			// The bookmark may have been removed while its page was being downloaded.
+			exists, err := db.BookFolderExists(tx, dto.BookmarkID, false)
+			if err != nil {
+				return nil, fmt.Errorf("error checking if bookmark exists while archiving bookmarks: %w", err)
+			}
+			if !exists {
+				continue
+			}
+
+			if err := db.UpsertArchive(tx, dto); err != nil {
+				return nil, fmt.Errorf("error storing archive while archiving bookmarks: %w", err)
+			}
+
+			archive := toArchive(dto)
+			archive.Content = nil
+			archives = append(archives, archive)
+		}
+
+		return archives, nil
+	})
+}
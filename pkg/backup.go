@@ -0,0 +1,148 @@
+package armaria
+
+import (
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// BackupVersion is the current version of the backup format.
+// It's incremented whenever the format changes in a way older versions can't read.
+const BackupVersion = 1
+
+// Backup is a lossless snapshot of the bookmarks database.
+type Backup struct {
+	Version       int                 `json:"version"`       // version of the backup format
+	Bookmarks     []BackupBookmark    `json:"bookmarks"`     // every row of the bookmarks table
+	Tags          []BackupTag         `json:"tags"`          // every row of the tags table
+	BookmarksTags []BackupBookmarkTag `json:"bookmarksTags"` // every row of the bookmarks_tags table
+}
+
+// BackupBookmark is a bookmark or folder in a backup.
+type BackupBookmark struct {
+	ID           string  `json:"id"`           // unique identifier of a bookmark/folder
+	ParentID     *string `json:"parentId"`     // optional ID of the parent folder for a bookmark/folder
+	IsFolder     bool    `json:"isFolder"`     // true if folder, and false otherwise
+	Name         string  `json:"name"`         // name of a bookmark/folder
+	URL          *string `json:"url"`          // address of a bookmark; not used for folders
+	Description  *string `json:"description"`  // description of a bookmark/folder
+	Created      string  `json:"created"`      // when the bookmark/folder was created; missing from older backups
+	Modified     string  `json:"modified"`     // when the bookmark/folder was last modified
+	Order        string  `json:"order"`        // user managed order of the bookmark/folder
+	DeletedAt    *string `json:"deletedAt"`    // when the bookmark/folder was moved to the trash; nil if it isn't in the trash
+	DeletedBatch *string `json:"deletedBatch"` // shared by everything moved to the trash together; nil if it isn't in the trash
+}
+
+// BackupTag is a tag in a backup.
+type BackupTag struct {
+	Tag      string `json:"tag"`      // the tag itself
+	Modified string `json:"modified"` // when the tag was last modified
+}
+
+// BackupBookmarkTag is a tag applied to a bookmark in a backup.
+type BackupBookmarkTag struct {
+	BookmarkID string `json:"bookmarkId"` // ID of the bookmark the tag is applied to
+	Tag        string `json:"tag"`        // the tag applied to the bookmark
+	Modified   string `json:"modified"`   // when the tag was applied to the bookmark
+}
+
+// RestoreMode is how a backup is restored into the bookmarks database.
+type RestoreMode string
+
+const (
+	RestoreModeReplace RestoreMode = "replace" // everything in the database is replaced by the backup
+	RestoreModeMerge   RestoreMode = "merge"   // the backup is reconciled with the database by ID
+)
+
+// toBackupBookmark converts a BookmarkRowDTO to a BackupBookmark.
+func toBackupBookmark(row db.BookmarkRowDTO, _ int) BackupBookmark {
+	return BackupBookmark{
+		ID:           row.ID,
+		ParentID:     null.PtrFromNullString(row.ParentID),
+		IsFolder:     row.IsFolder,
+		Name:         row.Name,
+		URL:          null.PtrFromNullString(row.URL),
+		Description:  null.PtrFromNullString(row.Description),
+		Created:      row.Created,
+		Modified:     row.Modified,
+		Order:        row.Order,
+		DeletedAt:    null.PtrFromNullString(row.DeletedAt),
+		DeletedBatch: null.PtrFromNullString(row.DeletedBatch),
+	}
+}
+
+// toBackupTag converts a TagRowDTO to a BackupTag.
+func toBackupTag(row db.TagRowDTO, _ int) BackupTag {
+	return BackupTag{
+		Tag:      row.Tag,
+		Modified: row.Modified,
+	}
+}
+
+// toBackupBookmarkTag converts a BookmarkTagRowDTO to a BackupBookmarkTag.
+func toBackupBookmarkTag(row db.BookmarkTagRowDTO, _ int) BackupBookmarkTag {
+	return BackupBookmarkTag{
+		BookmarkID: row.BookmarkID,
+		Tag:        row.Tag,
+		Modified:   row.Modified,
+	}
+}
+
+// toBackup converts the rows of the bookmarks database to a Backup.
+func toBackup(bookmarks []db.BookmarkRowDTO, tags []db.TagRowDTO, bookmarksTags []db.BookmarkTagRowDTO) Backup {
+	return Backup{
+		Version:       BackupVersion,
+		Bookmarks:     append(make([]BackupBookmark, 0), lo.Map(bookmarks, toBackupBookmark)...),
+		Tags:          append(make([]BackupTag, 0), lo.Map(tags, toBackupTag)...),
+		BookmarksTags: append(make([]BackupBookmarkTag, 0), lo.Map(bookmarksTags, toBackupBookmarkTag)...),
+	}
+}
+
+// fromBackupBookmark converts a BackupBookmark to a BookmarkRowDTO.
+// Backups made before bookmarks tracked when they were created use the modified timestamp instead.
+func fromBackupBookmark(bookmark BackupBookmark) db.BookmarkRowDTO {
+	created := bookmark.Created
+	if created == "" {
+		created = bookmark.Modified
+	}
+
+	return db.BookmarkRowDTO{
+		ID:           bookmark.ID,
+		ParentID:     null.NullStringFromPtr(bookmark.ParentID),
+		IsFolder:     bookmark.IsFolder,
+		Name:         bookmark.Name,
+		URL:          null.NullStringFromPtr(bookmark.URL),
+		Description:  null.NullStringFromPtr(bookmark.Description),
+		Created:      created,
+		Modified:     bookmark.Modified,
+		Order:        bookmark.Order,
+		DeletedAt:    null.NullStringFromPtr(bookmark.DeletedAt),
+		DeletedBatch: null.NullStringFromPtr(bookmark.DeletedBatch),
+	}
+}
+
+// sortParentsFirst orders the bookmarks/folders of a backup so parents come before their children.
+// Bookmarks/folders whose parent isn't in the backup come first.
+// Bookmarks/folders that are part of a cycle can't be ordered and are left out.
+func sortParentsFirst(bookmarks []BackupBookmark) []BackupBookmark {
+	ids := make(map[string]bool)
+	for _, bookmark := range bookmarks {
+		ids[bookmark.ID] = true
+	}
+
+	children := make(map[string][]BackupBookmark)
+	sorted := make([]BackupBookmark, 0)
+	for _, bookmark := range bookmarks {
+		if bookmark.ParentID == nil || !ids[*bookmark.ParentID] {
+			sorted = append(sorted, bookmark)
+		} else {
+			children[*bookmark.ParentID] = append(children[*bookmark.ParentID], bookmark)
+		}
+	}
+
+	for i := 0; i < len(sorted); i++ {
+		sorted = append(sorted, children[sorted[i].ID]...)
+	}
+
+	return sorted
+}
@@ -0,0 +1,155 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// batchOptions are the optional arguments for RunBatch.
+type batchOptions struct {
+	DB null.NullString
+}
+
+// DefaultBatchOptions are the default options for RunBatch.
+func DefaultBatchOptions() *batchOptions {
+	return &batchOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *batchOptions) WithDB(db string) *batchOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// Batch is a transaction that several operations share.
+// It's only valid inside of the function passed to RunBatch.
+// Operations in a batch use the batch's database so the DB in their options is ignored.
+type Batch struct {
+	tx db.Transaction
+}
+
+// RunBatch runs several operations in a single transaction.
+// If the function returns an error none of the operations are committed.
+func RunBatch(batchFn func(batch *Batch) error, options *batchOptions) error {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return fmt.Errorf("error getting config while running batch: %w", err)
+	}
+
+	return db.ExecWithTransaction(options.DB, config.DB, func(tx db.Transaction) error {
+		return batchFn(&Batch{tx: tx})
+	})
+}
+
+// AddBook adds a bookmark as part of the batch.
+func (b *Batch) AddBook(url string, options *addBookOptions) (Book, error) {
+	book, err := addBook(b.tx, applyMetadata(url, options), options)
+	if err != nil {
+		return Book{}, err
+	}
+
+	description := fmt.Sprintf("Added bookmark %q", book.Name)
+	if err := record(b.tx, OperationAddBook, description, []string{book.ID}, journalState{}); err != nil {
+		return Book{}, fmt.Errorf("error recording operation while adding bookmark: %w", err)
+	}
+
+	return book, nil
+}
+
+// AddFolder adds a folder as part of the batch.
+func (b *Batch) AddFolder(name string, options *addFolderOptions) (Book, error) {
+	book, err := addFolder(b.tx, name, options)
+	if err != nil {
+		return Book{}, err
+	}
+
+	description := fmt.Sprintf("Added folder %q", book.Name)
+	if err := record(b.tx, OperationAddFolder, description, []string{book.ID}, journalState{}); err != nil {
+		return Book{}, fmt.Errorf("error recording operation while adding folder: %w", err)
+	}
+
+	return book, nil
+}
+
+// AddTags adds tags to a bookmark as part of the batch.
+func (b *Batch) AddTags(id string, tags []string, options *addTagsOptions) (Book, error) {
+	return addTags(b.tx, id, tags)
+}
+
+// AddSession adds a folder with a bookmark for each URL in it as part of the batch.
+func (b *Batch) AddSession(name string, urls []string, options *addSessionOptions) ([]Book, error) {
+	return addSession(b.tx, name, urls, options)
+}
+
+// GetBook gets a bookmark or folder as part of the batch.
+func (b *Batch) GetBook(id string, options *getBookOptions) (Book, error) {
+	return getBook(b.tx, id)
+}
+
+// GetParentNames gets the parent names of a bookmark or folder as part of the batch.
+func (b *Batch) GetParentNames(ID string, options *getParentNameOptions) ([]string, error) {
+	return getParentNames(b.tx, ID)
+}
+
+// ListBooks lists bookmarks and folders as part of the batch.
+func (b *Batch) ListBooks(options *listBookOptions) ([]Book, error) {
+	return listBooks(b.tx, options)
+}
+
+// ListTags lists tags as part of the batch.
+func (b *Batch) ListTags(options *listTagsOptions) ([]string, error) {
+	return listTags(b.tx, options)
+}
+
+// ListTagCounts lists tags along with how many bookmarks they are applied to as part of the batch.
+func (b *Batch) ListTagCounts(options *listTagsOptions) ([]TagCount, error) {
+	return listTagCounts(b.tx, options)
+}
+
+// ListTagTree lists tags as a tree as part of the batch.
+func (b *Batch) ListTagTree(options *listTagsOptions) ([]TagNode, error) {
+	return listTagTree(b.tx, options)
+}
+
+// RemoveBook moves a bookmark to the trash as part of the batch.
+func (b *Batch) RemoveBook(id string, options *removeBookOptions) error {
+	return removeBook(b.tx, id)
+}
+
+// RemoveFolder moves a folder and everything in it to the trash as part of the batch.
+func (b *Batch) RemoveFolder(id string, options *removeFolderOptions) error {
+	return removeFolder(b.tx, id)
+}
+
+// RemoveTags removes tags from a bookmark as part of the batch.
+func (b *Batch) RemoveTags(id string, tags []string, options *removeTagsOptions) (Book, error) {
+	return removeTags(b.tx, id, tags)
+}
+
+// RenameTag renames a tag and its descendants as part of the batch.
+func (b *Batch) RenameTag(from string, to string, options *renameTagOptions) (TagCount, error) {
+	return renameTag(b.tx, from, to)
+}
+
+// MergeTags merges tags into another tag as part of the batch.
+func (b *Batch) MergeTags(tags []string, into string, options *mergeTagsOptions) (TagCount, error) {
+	return mergeTags(b.tx, tags, into)
+}
+
+// DeleteTag deletes a tag from every bookmark as part of the batch.
+func (b *Batch) DeleteTag(tag string, options *deleteTagOptions) error {
+	return deleteTag(b.tx, tag)
+}
+
+// UpdateBook updates a bookmark as part of the batch.
+func (b *Batch) UpdateBook(id string, options *updateBookOptions) (Book, error) {
+	return updateBook(b.tx, id, options)
+}
+
+// UpdateFolder updates a folder as part of the batch.
+func (b *Batch) UpdateFolder(id string, options *updateFolderOptions) (Book, error) {
+	return updateFolder(b.tx, id, options)
+}
@@ -1,5 +1,7 @@
 package armaria
 
+import "github.com/jonathanhope/armaria/internal/db"
+
 // Book is a bookmark or folder.
 type Book struct {
 	ID          string   // unique identifier of a bookmark/folder
@@ -11,4 +13,13 @@ type Book struct {
 	ParentName  *string  // name of parent folder if bookmark/folder has one
 	Tags        []string // tags applied to the bookmark
 	Order       string   // user managed order of the bookmark
+	Created     string   // when the bookmark/folder was created (UTC)
+	Modified    string   // when the bookmark/folder, or its tags, were last changed (UTC)
+	DeletedAt   *string  // when the bookmark/folder was moved to the trash (UTC); nil if it isn't in the trash
+	Snippet     *string  // matched text when searching by relevance; matches are surrounded by HighlightStart and HighlightEnd
+	Depth       *int64   // how far below the listed folder a bookmark/folder is when listing recursively
+	Path        []string // names of the folders leading to a bookmark/folder, and its own name, when listing recursively
 }
+
+const HighlightStart = db.HighlightStart
+const HighlightEnd = db.HighlightEnd
@@ -0,0 +1,104 @@
+package armaria
+
+import (
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// bulkOptions are the optional arguments for the Bulk operations.
+// They select which bookmarks/folders an operation applies to.
+type bulkOptions struct {
+	DB     null.NullString
+	IDs    []string
+	Filter *listBookOptions
+	DryRun bool
+}
+
+// DefaultBulkOptions are the default options for the Bulk operations.
+func DefaultBulkOptions() *bulkOptions {
+	return &bulkOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *bulkOptions) WithDB(db string) *bulkOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithIDs selects bookmarks/folders by ID.
+func (o *bulkOptions) WithIDs(IDs []string) *bulkOptions {
+	o.IDs = IDs
+	return o
+}
+
+// WithFilter selects the bookmarks/folders ListBooks would return for the same options.
+// The location of the bookmarks database in the filter is ignored.
+func (o *bulkOptions) WithFilter(filter *listBookOptions) *bulkOptions {
+	o.Filter = filter
+	return o
+}
+
+// WithDryRun returns the bookmarks/folders that would be changed without changing them.
+func (o *bulkOptions) WithDryRun(dryRun bool) *bulkOptions {
+	o.DryRun = dryRun
+	return o
+}
+
+// selectBooks gets the bookmarks/folders selected for a Bulk operation.
+// If both IDs and a filter are provided only the IDs that match the filter are selected.
+func selectBooks(tx db.Transaction, options *bulkOptions) ([]Book, error) {
+	if len(options.IDs) == 0 && options.Filter == nil {
+		return nil, ErrNoSelection
+	}
+
+	if options.Filter == nil {
+		return getBooks(tx, options.IDs)
+	}
+
+	books, err := listBooks(tx, options.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(options.IDs) > 0 {
+		books = lo.Filter(books, func(book Book, _ int) bool {
+			return lo.Contains(options.IDs, book.ID)
+		})
+	}
+
+	return books, nil
+}
+
+// getBooks gets bookmarks/folders by ID in the same order as the IDs.
+func getBooks(tx db.Transaction, IDs []string) ([]Book, error) {
+	books := make([]Book, 0)
+
+	for _, ID := range lo.Uniq(IDs) {
+		dtos, err := db.GetBooks(tx, db.GetBooksArgs{
+			IDFilter:       ID,
+			IncludeBooks:   true,
+			IncludeFolders: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting bookmarks while getting bookmarks by ID: %w", err)
+		}
+
+		if len(dtos) != 1 {
+			return nil, ErrNotFound
+		}
+
+		books = append(books, toBook(dtos[0]))
+	}
+
+	return books, nil
+}
+
+// onlyBookmarks removes the folders from a set of bookmarks/folders.
+func onlyBookmarks(books []Book) []Book {
+	return lo.Filter(books, func(book Book, _ int) bool {
+		return !book.IsFolder
+	})
+}
@@ -0,0 +1,73 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/samber/lo"
+)
+
+// BulkAddTags adds tags to every selected bookmark in a single transaction.
+// Folders are skipped and bookmarks that already have a tag keep it.
+// The bookmarks are returned as they are after the change (or would be changed on a dry run).
+func BulkAddTags(tags []string, options *bulkOptions) ([]Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while bulk adding tags: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		if err := validateTags(tags, nil); err != nil {
+			return nil, fmt.Errorf("tags validation failed while bulk adding tags: %w", err)
+		}
+
+		books, err := selectBooks(tx, options)
+		if err != nil {
+			return nil, fmt.Errorf("error selecting bookmarks while bulk adding tags: %w", err)
+		}
+
+		books = onlyBookmarks(books)
+		if options.DryRun || len(books) == 0 {
+			return books, nil
+		}
+
+		ids := bookIDs(books)
+
+		before, err := snapshot(tx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("error taking snapshot while bulk adding tags: %w", err)
+		}
+
+		existingTags, err := db.GetTags(tx, db.GetTagsArgs{
+			TagsFilter: tags,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting tags while bulk adding tags: %w", err)
+		}
+
+		tagsToAdd, _ := lo.Difference(tags, existingTags)
+		if err = db.AddTags(tx, tagsToAdd); err != nil {
+			return nil, fmt.Errorf("error while bulk adding tags: %w", err)
+		}
+
+		for _, book := range books {
+			tagsToLink := lo.Without(tags, book.Tags...)
+			if err := validateTags(tagsToLink, book.Tags); err != nil {
+				return nil, fmt.Errorf("tags validation failed while bulk adding tags: %w", err)
+			}
+
+			if err = db.LinkTags(tx, book.ID, tagsToLink); err != nil {
+				return nil, fmt.Errorf("error linking tags while bulk adding tags: %w", err)
+			}
+		}
+
+		description := fmt.Sprintf("Added tags %s to %d bookmarks", strings.Join(tags, ", "), len(books))
+		if err := record(tx, OperationBulkAddTags, description, ids, before); err != nil {
+			return nil, fmt.Errorf("error recording operation while bulk adding tags: %w", err)
+		}
+
+		return getBooks(tx, ids)
+	})
+}
@@ -0,0 +1,89 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/internal/order"
+)
+
+// BulkMove moves every selected bookmark/folder to a folder in a single transaction.
+// A nil parent ID moves them to the top level instead.
+// They are added to the end of the folder in the order they were selected.
+// The bookmarks/folders are returned as they are after the change (or would be changed on a dry run).
+func BulkMove(parentID *string, options *bulkOptions) ([]Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while bulk moving: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		parent := null.NullStringFromPtr(parentID)
+		if err := validateParentID(tx, parent); err != nil {
+			return nil, fmt.Errorf("parent ID validation failed while bulk moving: %w", err)
+		}
+
+		books, err := selectBooks(tx, options)
+		if err != nil {
+			return nil, fmt.Errorf("error selecting bookmarks while bulk moving: %w", err)
+		}
+
+		for _, book := range books {
+			if !book.IsFolder {
+				continue
+			}
+
+			if err := validateMove(tx, book.ID, parent); err != nil {
+				return nil, fmt.Errorf("move validation failed while bulk moving: %w", err)
+			}
+		}
+
+		if options.DryRun || len(books) == 0 {
+			return books, nil
+		}
+
+		ids := bookIDs(books)
+
+		before, err := snapshot(tx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("error taking snapshot while bulk moving: %w", err)
+		}
+
+		previous, err := db.MaxOrder(tx, parent)
+		if err != nil {
+			return nil, fmt.Errorf("error getting max order while bulk moving: %w", err)
+		}
+
+		for _, book := range books {
+			var current string
+			if previous == "" {
+				current, err = order.Initial()
+			} else {
+				current, err = order.End(previous)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error getting current order while bulk moving: %w", err)
+			}
+
+			if book.IsFolder {
+				err = db.UpdateFolder(tx, book.ID, db.UpdateFolderArgs{ParentID: parent, Order: current})
+			} else {
+				err = db.UpdateBook(tx, book.ID, db.UpdateBookArgs{ParentID: parent, Order: current})
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error while bulk moving: %w", err)
+			}
+
+			previous = current
+		}
+
+		description := fmt.Sprintf("Moved %d bookmarks/folders", len(books))
+		if err := record(tx, OperationBulkMove, description, ids, before); err != nil {
+			return nil, fmt.Errorf("error recording operation while bulk moving: %w", err)
+		}
+
+		return getBooks(tx, ids)
+	})
+}
@@ -0,0 +1,66 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/samber/lo"
+)
+
+// BulkRemove moves every selected bookmark/folder to the trash in a single transaction.
+// Folders are removed along with everything in them.
+// The bookmarks/folders are returned as they were before they were removed.
+func BulkRemove(options *bulkOptions) ([]Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while bulk removing: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		books, err := selectBooks(tx, options)
+		if err != nil {
+			return nil, fmt.Errorf("error selecting bookmarks while bulk removing: %w", err)
+		}
+
+		if options.DryRun || len(books) == 0 {
+			return books, nil
+		}
+
+		ids := bookIDs(books)
+		for _, book := range books {
+			if !book.IsFolder {
+				continue
+			}
+
+			children, err := db.GetParentAndChildren(tx, book.ID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting folder and children while bulk removing: %w", err)
+			}
+
+			// Anything that was already in the trash stays there on its own.
+			for _, child := range children {
+				if !child.DeletedAt.Valid {
+					ids = append(ids, child.ID)
+				}
+			}
+		}
+		ids = lo.Uniq(ids)
+
+		before, err := snapshot(tx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("error taking snapshot while bulk removing: %w", err)
+		}
+
+		if err = db.TrashBooks(tx, ids); err != nil {
+			return nil, fmt.Errorf("error while bulk removing: %w", err)
+		}
+
+		description := fmt.Sprintf("Removed %d bookmarks/folders", len(ids))
+		if err = record(tx, OperationBulkRemove, description, ids, before); err != nil {
+			return nil, fmt.Errorf("error recording operation while bulk removing: %w", err)
+		}
+
+		return books, nil
+	})
+}
@@ -0,0 +1,62 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/samber/lo"
+)
+
+// BulkRemoveTags removes tags from every selected bookmark in a single transaction.
+// Only bookmarks that have at least one of the tags are changed.
+// The bookmarks are returned as they are after the change (or would be changed on a dry run).
+func BulkRemoveTags(tags []string, options *bulkOptions) ([]Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while bulk removing tags: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		if err := validateTags(tags, nil); err != nil {
+			return nil, fmt.Errorf("tags validation failed while bulk removing tags: %w", err)
+		}
+
+		books, err := selectBooks(tx, options)
+		if err != nil {
+			return nil, fmt.Errorf("error selecting bookmarks while bulk removing tags: %w", err)
+		}
+
+		books = lo.Filter(onlyBookmarks(books), func(book Book, _ int) bool {
+			return lo.Some(book.Tags, tags)
+		})
+		if options.DryRun || len(books) == 0 {
+			return books, nil
+		}
+
+		ids := bookIDs(books)
+
+		before, err := snapshot(tx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("error taking snapshot while bulk removing tags: %w", err)
+		}
+
+		for _, ID := range ids {
+			if err := db.UnlinkTags(tx, ID, tags); err != nil {
+				return nil, fmt.Errorf("error unlinking tags while bulk removing tags: %w", err)
+			}
+		}
+
+		if err := db.CleanOrphanedTags(tx, tags); err != nil {
+			return nil, fmt.Errorf("error cleaning orphaned tags while bulk removing tags: %w", err)
+		}
+
+		description := fmt.Sprintf("Removed tags %s from %d bookmarks", strings.Join(tags, ", "), len(books))
+		if err := record(tx, OperationBulkRemoveTags, description, ids, before); err != nil {
+			return nil, fmt.Errorf("error recording operation while bulk removing tags: %w", err)
+		}
+
+		return getBooks(tx, ids)
+	})
+}
@@ -0,0 +1,57 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// BulkUpdateDescription sets the description of every selected bookmark in a single transaction.
+// A nil description removes the description instead.
+// Folders are skipped.
+// The bookmarks are returned as they are after the change (or would be changed on a dry run).
+func BulkUpdateDescription(description *string, options *bulkOptions) ([]Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while bulk updating description: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		value := null.NullStringFromPtr(description)
+		if err := validateDescription(value); err != nil {
+			return nil, fmt.Errorf("description validation failed while bulk updating description: %w", err)
+		}
+
+		books, err := selectBooks(tx, options)
+		if err != nil {
+			return nil, fmt.Errorf("error selecting bookmarks while bulk updating description: %w", err)
+		}
+
+		books = onlyBookmarks(books)
+		if options.DryRun || len(books) == 0 {
+			return books, nil
+		}
+
+		ids := bookIDs(books)
+
+		before, err := snapshot(tx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("error taking snapshot while bulk updating description: %w", err)
+		}
+
+		for _, ID := range ids {
+			if err := db.UpdateBook(tx, ID, db.UpdateBookArgs{Description: value}); err != nil {
+				return nil, fmt.Errorf("error while bulk updating description: %w", err)
+			}
+		}
+
+		operation := fmt.Sprintf("Updated description of %d bookmarks", len(books))
+		if err := record(tx, OperationBulkUpdateDescription, operation, ids, before); err != nil {
+			return nil, fmt.Errorf("error recording operation while bulk updating description: %w", err)
+		}
+
+		return getBooks(tx, ids)
+	})
+}
@@ -0,0 +1,268 @@
+package armaria
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/linkcheck"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// LinkCheck is the outcome of checking whether a bookmark's URL still resolves.
+type LinkCheck struct {
+	Book     Book    // the bookmark that was checked; Path shows where the bookmark lives
+	Status   *int64  // HTTP status of the final response; nil if the request failed
+	Redirect *string // where the URL ended up if it was redirected
+	Error    *string // why the request failed if it did
+	Checked  string  // when the URL was checked (UTC)
+}
+
+// Broken is true if the URL couldn't be fetched or returned an error status.
+func (c LinkCheck) Broken() bool {
+	return c.Error != nil || c.Status == nil || *c.Status >= 400
+}
+
+// checkLinksOptions are the optional arguments for CheckLinks.
+type checkLinksOptions struct {
+	DB           null.NullString
+	ParentID     null.NullString
+	Concurrency  int
+	Timeout      time.Duration
+	HostInterval time.Duration
+}
+
+// DefaultCheckLinksOptions are the default options for CheckLinks.
+func DefaultCheckLinksOptions() *checkLinksOptions {
+	return &checkLinksOptions{
+		Concurrency:  8,
+		Timeout:      10 * time.Second,
+		HostInterval: time.Second,
+	}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *checkLinksOptions) WithDB(db string) *checkLinksOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID only checks the bookmarks anywhere inside of a folder.
+func (o *checkLinksOptions) WithParentID(parentID string) *checkLinksOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// WithConcurrency sets how many URLs are checked at once.
+func (o *checkLinksOptions) WithConcurrency(concurrency int) *checkLinksOptions {
+	o.Concurrency = concurrency
+	return o
+}
+
+// WithTimeout sets how long to wait for a URL before giving up on it.
+func (o *checkLinksOptions) WithTimeout(timeout time.Duration) *checkLinksOptions {
+	o.Timeout = timeout
+	return o
+}
+
+// WithHostInterval sets how long to wait between requests to the same host.
+func (o *checkLinksOptions) WithHostInterval(interval time.Duration) *checkLinksOptions {
+	o.HostInterval = interval
+	return o
+}
+
+// CheckLinks fetches the URLs of bookmarks to see if they still resolve.
+// The outcome of each check is recorded so it can be listed later with ListLinkChecks.
+// Bookmarks that are removed while their URLs are being checked are skipped.
+// The database isn't held open while the URLs are being fetched.
+func CheckLinks(options *checkLinksOptions) ([]LinkCheck, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while checking links: %w", err)
+	}
+
+	if err := validateConcurrency(options.Concurrency); err != nil {
+		return nil, fmt.Errorf("concurrency validation failed while checking links: %w", err)
+	}
+
+	if err := validateTimeout(options.Timeout); err != nil {
+		return nil, fmt.Errorf("timeout validation failed while checking links: %w", err)
+	}
+
+	if err := validateHostInterval(options.HostInterval); err != nil {
+		return nil, fmt.Errorf("host interval validation failed while checking links: %w", err)
+	}
+
+	books, err := db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return nil, fmt.Errorf("parent ID validation failed while checking links: %w", err)
+		}
+
+		return getBookmarksIn(tx, options.ParentID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	urls := lo.Map(books, func(book Book, _ int) string {
+		return *book.URL
+	})
+
+	results := linkcheck.Check(context.Background(), urls, linkcheck.Options{
+		Concurrency:  options.Concurrency,
+		Timeout:      options.Timeout,
+		HostInterval: options.HostInterval,
+	})
+
+	checked := time.Now().UTC().Format(time.DateTime)
+	dtos := make([]db.LinkCheckDTO, len(books))
+	for i, book := range books {
+		dtos[i] = toLinkCheckDTO(book.ID, results[i], checked)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]LinkCheck, error) {
+		checks := make([]LinkCheck, 0)
+
+		for i, book := range books {
+			// The bookmark may have been removed while its URL was being checked.
+			exists, err := db.BookFolderExists(tx, book.ID, false)
+			if err != nil {
+				return nil, fmt.Errorf("error checking if bookmark exists while checking links: %w", err)
+			}
+			if !exists {
+				continue
+			}
+
+			if err := db.AddLinkCheck(tx, dtos[i]); err != nil {
+				return nil, fmt.Errorf("error recording link check while checking links: %w", err)
+			}
+
+			checks = append(checks, toLinkCheck(book, dtos[i]))
+		}
+
+		return checks, nil
+	})
+}
+
+// listLinkChecksOptions are the optional arguments for ListLinkChecks.
+type listLinkChecksOptions struct {
+	DB         null.NullString
+	ParentID   null.NullString
+	BrokenOnly bool
+}
+
+// DefaultListLinkChecksOptions are the default options for ListLinkChecks.
+func DefaultListLinkChecksOptions() *listLinkChecksOptions {
+	return &listLinkChecksOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *listLinkChecksOptions) WithDB(db string) *listLinkChecksOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID only lists the bookmarks anywhere inside of a folder.
+func (o *listLinkChecksOptions) WithParentID(parentID string) *listLinkChecksOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// WithBrokenOnly sets whether to only list bookmarks whose last check found them broken.
+func (o *listLinkChecksOptions) WithBrokenOnly(brokenOnly bool) *listLinkChecksOptions {
+	o.BrokenOnly = brokenOnly
+	return o
+}
+
+// ListLinkChecks lists the most recent link check of each bookmark.
+// Bookmarks that have never been checked aren't listed.
+func ListLinkChecks(options *listLinkChecksOptions) ([]LinkCheck, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while listing link checks: %w", err)
+	}
+
+	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]LinkCheck, error) {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return nil, fmt.Errorf("parent ID validation failed while listing link checks: %w", err)
+		}
+
+		dtos, err := db.GetLinkChecks(tx, db.GetLinkChecksArgs{
+			BrokenOnly: options.BrokenOnly,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting link checks while listing link checks: %w", err)
+		}
+
+		books, err := getBookmarksIn(tx, options.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting bookmarks while listing link checks: %w", err)
+		}
+
+		latest := lo.KeyBy(dtos, func(dto db.LinkCheckDTO) string {
+			return dto.BookmarkID
+		})
+
+		checks := make([]LinkCheck, 0)
+		for _, book := range books {
+			if dto, ok := latest[book.ID]; ok {
+				checks = append(checks, toLinkCheck(book, dto))
+			}
+		}
+
+		return checks, nil
+	})
+}
+
+// getBookmarksIn gets every bookmark (with its path) in depth first order.
+// If a parent ID is provided only the bookmarks anywhere inside of that folder are included.
+func getBookmarksIn(tx db.Transaction, parentID null.NullString) ([]Book, error) {
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IncludeBooks: true,
+		ParentID:     parentID,
+		Recursive:    true,
+		Order:        db.OrderManual,
+		Direction:    db.DirectionAsc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toBooks(books), nil
+}
+
+// toLinkCheckDTO converts the result of checking a bookmark's URL to a LinkCheckDTO.
+func toLinkCheckDTO(bookmarkID string, result linkcheck.Result, checked string) db.LinkCheckDTO {
+	dto := db.LinkCheckDTO{
+		BookmarkID: bookmarkID,
+		Checked:    checked,
+	}
+
+	if result.Status != 0 {
+		dto.Status = null.NullInt64From(int64(result.Status))
+	}
+
+	if result.Redirect != "" {
+		dto.Redirect = null.NullStringFrom(result.Redirect)
+	}
+
+	if result.Err != nil {
+		dto.Error = null.NullStringFrom(result.Err.Error())
+	}
+
+	return dto
+}
+
+// toLinkCheck converts a LinkCheckDTO to a LinkCheck.
+func toLinkCheck(book Book, check db.LinkCheckDTO) LinkCheck {
+	return LinkCheck{
+		Book:     book,
+		Status:   null.PtrFromNullInt64(check.Status),
+		Redirect: null.PtrFromNullString(check.Redirect),
+		Error:    null.PtrFromNullString(check.Error),
+		Checked:  check.Checked,
+	}
+}
@@ -0,0 +1,39 @@
+package armaria
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckLinksSkipsRemovedBookmarks(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "bookmarks.db")
+
+	var id string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The bookmark is removed for good while its URL is being checked.
+		if err := RemoveBook(id, DefaultRemoveBookOptions().WithDB(dbPath)); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		if _, err := EmptyTrash(DefaultEmptyTrashOptions().WithDB(dbPath)); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}))
+	defer server.Close()
+
+	book, err := AddBook(server.URL, DefaultAddBookOptions().WithDB(dbPath))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id = book.ID
+
+	checks, err := CheckLinks(DefaultCheckLinksOptions().WithDB(dbPath))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(checks) != 0 {
+		t.Errorf("got %d checks; want 0", len(checks))
+	}
+}
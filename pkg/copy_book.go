@@ -0,0 +1,135 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/internal/order"
+)
+
+// copyBookOptions are the optional arguments for CopyBook.
+type copyBookOptions struct {
+	DB       null.NullString
+	ParentID null.NullString
+}
+
+// DefaultCopyBookOptions are the default options for CopyBook.
+func DefaultCopyBookOptions() *copyBookOptions {
+	return &copyBookOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *copyBookOptions) WithDB(db string) *copyBookOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID sets the folder the copy is added to.
+func (o *copyBookOptions) WithParentID(parentID string) *copyBookOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// WithoutParentID adds the copy to the top level.
+func (o *copyBookOptions) WithoutParentID() *copyBookOptions {
+	o.ParentID = null.NullStringFromPtr(nil)
+	return o
+}
+
+// CopyBook copies a bookmark in the bookmarks database.
+// The copy has a new ID but the same URL, name, description, and tags.
+// It's added to the end of its folder which is the same folder as the original unless a parent ID is provided.
+func CopyBook(id string, options *copyBookOptions) (Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return Book{}, fmt.Errorf("error getting config while copying bookmark: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
+		if err := validateBookID(tx, id); err != nil {
+			return Book{}, fmt.Errorf("bookmark ID validation failed while copying bookmark: %w", err)
+		}
+
+		books, err := db.GetBooks(tx, db.GetBooksArgs{
+			IDFilter:     id,
+			IncludeBooks: true,
+		})
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting bookmarks while copying bookmark: %w", err)
+		}
+
+		original := books[0]
+
+		parentID := original.ParentID
+		if options.ParentID.Dirty {
+			if err := validateParentID(tx, options.ParentID); err != nil {
+				return Book{}, fmt.Errorf("parent ID validation failed while copying bookmark: %w", err)
+			}
+
+			parentID = options.ParentID
+		}
+
+		previous, err := db.MaxOrder(tx, parentID)
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting max order while copying bookmark: %w", err)
+		}
+
+		current, err := nextOrder(previous)
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting current order while copying bookmark: %w", err)
+		}
+
+		copyID, err := copyBook(tx, original, parentID, current)
+		if err != nil {
+			return Book{}, fmt.Errorf("error while copying bookmark: %w", err)
+		}
+
+		books, err = db.GetBooks(tx, db.GetBooksArgs{
+			IDFilter:     copyID,
+			IncludeBooks: true,
+		})
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting bookmarks while copying bookmark: %w", err)
+		}
+
+		book := toBook(books[0])
+
+		description := fmt.Sprintf("Copied bookmark %q", book.Name)
+		if err := record(tx, OperationCopyBook, description, []string{copyID}, journalState{}); err != nil {
+			return Book{}, fmt.Errorf("error recording operation while copying bookmark: %w", err)
+		}
+
+		return book, nil
+	})
+}
+
+// copyBook adds a copy of a bookmark or an empty copy of a folder to the bookmarks database.
+// Tags are copied as well; they already exist since the original has them.
+func copyBook(tx db.Transaction, original db.BookDTO, parentID null.NullString, order string) (string, error) {
+	if original.IsFolder {
+		return db.AddFolder(tx, original.Name, parentID, order)
+	}
+
+	id, err := db.AddBook(tx, original.URL.String, original.Name, original.Description, parentID, order)
+	if err != nil {
+		return "", err
+	}
+
+	if err := db.LinkTags(tx, id, parseTags(original.Tags)); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// nextOrder gets the order that sorts after the provided order.
+// An empty order means there's nothing to sort after.
+func nextOrder(previous string) (string, error) {
+	if previous == "" {
+		return order.Initial()
+	}
+
+	return order.End(previous)
+}
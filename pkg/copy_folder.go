@@ -0,0 +1,156 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// copyFolderOptions are the optional arguments for CopyFolder.
+type copyFolderOptions struct {
+	DB       null.NullString
+	ParentID null.NullString
+}
+
+// DefaultCopyFolderOptions are the default options for CopyFolder.
+func DefaultCopyFolderOptions() *copyFolderOptions {
+	return &copyFolderOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *copyFolderOptions) WithDB(db string) *copyFolderOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID sets the folder the copy is added to.
+func (o *copyFolderOptions) WithParentID(parentID string) *copyFolderOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// WithoutParentID adds the copy to the top level.
+func (o *copyFolderOptions) WithoutParentID() *copyFolderOptions {
+	o.ParentID = null.NullStringFromPtr(nil)
+	return o
+}
+
+// CopyFolder copies a folder and everything in it in the bookmarks database.
+// Every copied bookmark/folder has a new ID but keeps its name, URL, description, tags, and position in its folder.
+// The copy is added to the end of its folder which is the same folder as the original unless a parent ID is provided.
+// Bookmarks/folders in the trash aren't copied.
+func CopyFolder(id string, options *copyFolderOptions) (Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return Book{}, fmt.Errorf("error getting config while copying folder: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
+		if err := validateParentID(tx, null.NullStringFrom(id)); err != nil {
+			return Book{}, fmt.Errorf("folder ID validation failed while copying folder: %w", err)
+		}
+
+		// The subtree is read before anything is added so copying a folder into itself copies it once.
+		books, err := db.GetParentAndChildren(tx, id)
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting folder and children while copying folder: %w", err)
+		}
+
+		books = lo.Filter(books, func(book db.BookDTO, _ int) bool {
+			return !book.DeletedAt.Valid
+		})
+
+		original, _ := lo.Find(books, func(book db.BookDTO) bool { return book.ID == id })
+
+		parentID := original.ParentID
+		if options.ParentID.Dirty {
+			if err := validateParentID(tx, options.ParentID); err != nil {
+				return Book{}, fmt.Errorf("parent ID validation failed while copying folder: %w", err)
+			}
+
+			parentID = options.ParentID
+		}
+
+		previous, err := db.MaxOrder(tx, parentID)
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting max order while copying folder: %w", err)
+		}
+
+		current, err := nextOrder(previous)
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting current order while copying folder: %w", err)
+		}
+
+		copyID, err := copyBook(tx, original, parentID, current)
+		if err != nil {
+			return Book{}, fmt.Errorf("error while copying folder: %w", err)
+		}
+
+		children := lo.GroupBy(books, func(book db.BookDTO) string { return book.ParentID.String })
+
+		copyIDs, err := copyChildren(tx, children, id, copyID)
+		if err != nil {
+			return Book{}, fmt.Errorf("error copying children while copying folder: %w", err)
+		}
+
+		folders, err := db.GetBooks(tx, db.GetBooksArgs{
+			IDFilter:       copyID,
+			IncludeFolders: true,
+		})
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting folders while copying folder: %w", err)
+		}
+
+		book := toBook(folders[0])
+
+		description := fmt.Sprintf("Copied folder %q", book.Name)
+		if err := record(tx, OperationCopyFolder, description, append([]string{copyID}, copyIDs...), journalState{}); err != nil {
+			return Book{}, fmt.Errorf("error recording operation while copying folder: %w", err)
+		}
+
+		return book, nil
+	})
+}
+
+// copyChildren copies the children of a folder into its copy and then does the same for every child folder.
+// Children are grouped by the ID of their parent.
+// The IDs of every copy are returned.
+func copyChildren(tx db.Transaction, children map[string][]db.BookDTO, originalID string, copyID string) ([]string, error) {
+	books := children[originalID]
+	sort.Slice(books, func(i, j int) bool {
+		return books[i].Order < books[j].Order
+	})
+
+	IDs := make([]string, 0)
+	previous := ""
+
+	for _, book := range books {
+		current, err := nextOrder(previous)
+		if err != nil {
+			return nil, fmt.Errorf("error getting current order while copying children: %w", err)
+		}
+
+		id, err := copyBook(tx, book, null.NullStringFrom(copyID), current)
+		if err != nil {
+			return nil, fmt.Errorf("error while copying children: %w", err)
+		}
+
+		IDs = append(IDs, id)
+		previous = current
+
+		if book.IsFolder {
+			descendantIDs, err := copyChildren(tx, children, book.ID, id)
+			if err != nil {
+				return nil, err
+			}
+
+			IDs = append(IDs, descendantIDs...)
+		}
+	}
+
+	return IDs, nil
+}
@@ -0,0 +1,77 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// deleteTagOptions are the optional arguments for DeleteTag.
+type deleteTagOptions struct {
+	DB null.NullString
+}
+
+// DefaultDeleteTagOptions are the default options for DeleteTag.
+func DefaultDeleteTagOptions() *deleteTagOptions {
+	return &deleteTagOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *deleteTagOptions) WithDB(db string) *deleteTagOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// DeleteTag removes a tag from every bookmark it's applied to.
+func DeleteTag(tag string, options *deleteTagOptions) error {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return fmt.Errorf("error getting config while deleting tag: %w", err)
+	}
+
+	return db.ExecWithTransaction(options.DB, config.DB, func(tx db.Transaction) error {
+		return deleteTag(tx, tag)
+	})
+}
+
+// deleteTag deletes a tag from every bookmark using an existing transaction.
+func deleteTag(tx db.Transaction, tag string) error {
+	// The tag is matched with a GLOB so it can't have any wildcards in it.
+	if err := validateTags([]string{tag}, nil); err != nil {
+		return fmt.Errorf("tags validation failed while deleting tag: %w", err)
+	}
+
+	existingTags, err := db.GetTags(tx, db.GetTagsArgs{
+		TagsFilter: []string{tag},
+	})
+	if err != nil {
+		return fmt.Errorf("error getting tags while deleting tag: %w", err)
+	}
+
+	if len(existingTags) == 0 {
+		return ErrTagNotFound
+	}
+
+	ids, err := db.GetTaggedBookIDs(tx, []string{tag})
+	if err != nil {
+		return fmt.Errorf("error getting bookmarks while deleting tag: %w", err)
+	}
+
+	before, err := snapshot(tx, ids)
+	if err != nil {
+		return fmt.Errorf("error taking snapshot while deleting tag: %w", err)
+	}
+
+	if err := retag(tx, ids, []string{tag}, ""); err != nil {
+		return fmt.Errorf("error while deleting tag: %w", err)
+	}
+
+	description := fmt.Sprintf("Deleted tag %q", tag)
+	if err := record(tx, OperationDeleteTag, description, ids, before); err != nil {
+		return fmt.Errorf("error recording operation while deleting tag: %w", err)
+	}
+
+	return nil
+}
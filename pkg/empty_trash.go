@@ -0,0 +1,114 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// emptyTrashOptions are the optional arguments for EmptyTrash.
+type emptyTrashOptions struct {
+	DB        null.NullString
+	OlderThan time.Duration
+}
+
+// DefaultEmptyTrashOptions are the default options for EmptyTrash.
+func DefaultEmptyTrashOptions() *emptyTrashOptions {
+	return &emptyTrashOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *emptyTrashOptions) WithDB(db string) *emptyTrashOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithOlderThan only purges bookmarks/folders that have been in the trash for longer than the provided duration.
+func (o *emptyTrashOptions) WithOlderThan(olderThan time.Duration) *emptyTrashOptions {
+	o.OlderThan = olderThan
+	return o
+}
+
+// EmptyTrash permanently deletes bookmarks/folders in the trash.
+// Purging a folder also purges everything in it.
+// Tags that are no longer applied to any bookmark are removed too.
+// Purged bookmarks/folders can't be restored and the purge can't be undone.
+func EmptyTrash(options *emptyTrashOptions) ([]Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while emptying trash: %w", err)
+	}
+
+	if err := validateOlderThan(options.OlderThan); err != nil {
+		return nil, fmt.Errorf("older than validation failed while emptying trash: %w", err)
+	}
+
+	cutoff := time.Now().UTC().Add(-options.OlderThan).Format(time.DateTime)
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		trash, err := db.GetBooks(tx, db.GetBooksArgs{
+			IncludeBooks:   true,
+			IncludeFolders: true,
+			DeletedOnly:    true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting trash while emptying trash: %w", err)
+		}
+
+		expired := lo.Filter(trash, func(book db.BookDTO, _ int) bool {
+			return book.DeletedAt.String <= cutoff
+		})
+
+		// Children are purged before their parents so no folder is purged while it still has children.
+		purged := make([]db.BookDTO, 0)
+		seen := make(map[string]bool)
+		for _, book := range expired {
+			bookOrFolders, err := db.GetParentAndChildren(tx, book.ID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting folder and children while emptying trash: %w", err)
+			}
+
+			for _, bookOrFolder := range lo.Reverse(bookOrFolders) {
+				if !seen[bookOrFolder.ID] {
+					seen[bookOrFolder.ID] = true
+					purged = append(purged, bookOrFolder)
+				}
+			}
+		}
+
+		ids := lo.Map(purged, func(book db.BookDTO, _ int) string {
+			return book.ID
+		})
+
+		if err := db.UnlinkAllTags(tx, ids); err != nil {
+			return nil, fmt.Errorf("error unlinking tags while emptying trash: %w", err)
+		}
+
+		for _, book := range purged {
+			remove := db.RemoveBook
+			if book.IsFolder {
+				remove = db.RemoveFolder
+			}
+
+			if err := remove(tx, book.ID); err != nil {
+				return nil, fmt.Errorf("error while emptying trash: %w", err)
+			}
+		}
+
+		if err := db.CleanAllOrphanedTags(tx); err != nil {
+			return nil, fmt.Errorf("error cleaning orphaned tags while emptying trash: %w", err)
+		}
+
+		if err := db.CleanOrphanedFavicons(tx); err != nil {
+			return nil, fmt.Errorf("error cleaning orphaned favicons while emptying trash: %w", err)
+		}
+
+		return append(make([]Book, 0), toBooks(lo.Filter(trash, func(book db.BookDTO, _ int) bool {
+			return seen[book.ID]
+		}))...), nil
+	})
+}
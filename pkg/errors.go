@@ -3,7 +3,9 @@ package armaria
 import (
 	"errors"
 
+	"github.com/jonathanhope/armaria/internal/browser"
 	"github.com/jonathanhope/armaria/internal/config"
+	"github.com/jonathanhope/armaria/internal/netscape"
 )
 
 var (
@@ -15,8 +17,18 @@ var (
 	ErrFolderNotFound = errors.New("folder not found")
 	// ErrTagNotFound is returned when a target tag was not found.
 	ErrTagNotFound = errors.New("tag not found")
+	// ErrArchiveNotFound is returned when a bookmark hasn't been archived.
+	ErrArchiveNotFound = errors.New("archive not found")
 	// ErrNotFound is returned when a target bookmark or folder was not found.
 	ErrNotFound = errors.New("bookmark or folder not found")
+	// ErrNoURLs is returned when no URLs are provided.
+	ErrNoURLs = errors.New("no URLs")
+	// ErrDuplicateURL is returned when a bookmark with the same URL already exists.
+	ErrDuplicateURL = errors.New("duplicate URL")
+	// ErrMergeIntoSelf is returned when a bookmark is merged into itself.
+	ErrMergeIntoSelf = errors.New("can't merge a bookmark into itself")
+	// ErrNotDuplicate is returned when a bookmark is merged into a bookmark with a different URL.
+	ErrNotDuplicate = errors.New("bookmarks don't have the same URL")
 	// ErrURLTooShort is returned when a provided URL is too short.
 	ErrURLTooShort = errors.New("URL too short")
 	// ErrURLTooLong is too long when a provided URL is too long.
@@ -39,16 +51,60 @@ var (
 	ErrTooManyTags = errors.New("too many tags")
 	// ErrTagInvalidChar is returned when a provided tag has an invalid character.
 	ErrTagInvalidChar = errors.New("tag had invalid chars")
+	// ErrTagEmptyNamespace is returned when a provided tag has an empty namespace.
+	ErrTagEmptyNamespace = errors.New("tag had empty namespace")
 	// ErrFirstTooSmall is returned when a provided first is too small.
 	ErrFirstTooSmall = errors.New("first too small")
+	// ErrDepthTooSmall is returned when a provided depth is too small.
+	ErrDepthTooSmall = errors.New("depth too small")
+	// ErrConcurrencyTooSmall is returned when a provided concurrency is too small.
+	ErrConcurrencyTooSmall = errors.New("concurrency too small")
+	// ErrTimeoutTooSmall is returned when a provided timeout is too small.
+	ErrTimeoutTooSmall = errors.New("timeout too small")
+	// ErrHostIntervalTooSmall is returned when a provided host interval is too small.
+	ErrHostIntervalTooSmall = errors.New("host interval too small")
 	// ErrInvalidOrder is returned when a provided order is invalid.
 	ErrInvalidOrder = errors.New("invalid order")
 	// ErrInvalidDirection is returned when a provided direction is invalid.
 	ErrInvalidDirection = errors.New("invalid direction")
+	// ErrInvalidTagMode is returned when a provided tag mode is invalid.
+	ErrInvalidTagMode = errors.New("invalid tag mode")
 	// ErrQueryTooShort is returned when a provided query is too short.
 	ErrQueryTooShort = errors.New("query too short")
+	// ErrInvalidQuery is returned when a provided search query can't be parsed.
+	ErrInvalidQuery = errors.New("invalid query")
 	// ErrInvalidOrdering is returned previous book >= next book for manual ordering.
 	ErrInvalidOrdering = errors.New("invalid ordering")
+	// ErrInvalidRestoreMode is returned when a provided restore mode is invalid.
+	ErrInvalidRestoreMode = errors.New("invalid restore mode")
+	// ErrInvalidBackup is returned when a backup can't be restored.
+	ErrInvalidBackup = errors.New("invalid backup")
+	// ErrUnsupportedBackupVersion is returned when a backup was written in a format that isn't supported.
+	ErrUnsupportedBackupVersion = errors.New("unsupported backup version")
+	// ErrNothingToUndo is returned when there are no operations left to undo.
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNothingToRedo is returned when there are no undone operations to redo.
+	ErrNothingToRedo = errors.New("nothing to redo")
+	// ErrNotInTrash is returned when a bookmark or folder to restore isn't in the trash.
+	ErrNotInTrash = errors.New("not in trash")
+	// ErrOlderThanTooSmall is returned when a provided trash retention period is too small.
+	ErrOlderThanTooSmall = errors.New("older than too small")
+	// ErrTagExists is returned when a tag is renamed to a tag that already exists.
+	ErrTagExists = errors.New("tag already exists")
+	// ErrNothingToMerge is returned when there are no tags to merge into another tag.
+	ErrNothingToMerge = errors.New("nothing to merge")
+	// ErrNoSelection is returned when a bulk operation isn't given any IDs or a filter to select bookmarks/folders with.
+	ErrNoSelection = errors.New("no selection")
+	// ErrMoveIntoSelf is returned when a folder is moved into itself or one of its descendants.
+	ErrMoveIntoSelf = errors.New("can't move a folder into itself")
+	// ErrFolderHasChildren is returned when a folder that still has children would become a bookmark.
+	ErrFolderHasChildren = errors.New("folder has children")
 )
 
 var ErrConfigMissing = config.ErrConfigMissing
+
+var ErrInvalidBookmarkFile = netscape.ErrInvalidFile
+
+var ErrProfileNotFound = browser.ErrProfileNotFound
+
+var ErrProfileLocked = browser.ErrProfileLocked
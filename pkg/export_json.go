@@ -0,0 +1,67 @@
+package armaria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// exportJSONOptions are the optional arguments for ExportJSON.
+type exportJSONOptions struct {
+	DB null.NullString
+}
+
+// DefaultExportJSONOptions are the default options for ExportJSON.
+func DefaultExportJSONOptions() *exportJSONOptions {
+	return &exportJSONOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *exportJSONOptions) WithDB(db string) *exportJSONOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// ExportJSON writes a lossless backup of the bookmarks database as JSON.
+// Rows are written in a stable order so backups can be diffed.
+func ExportJSON(writer io.Writer, options *exportJSONOptions) error {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return fmt.Errorf("error getting config while exporting backup: %w", err)
+	}
+
+	// A transaction is used so the tables are read from the same snapshot.
+	backup, err := db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Backup, error) {
+		bookmarks, err := db.GetBookmarkRows(tx, db.GetBookmarkRowsArgs{})
+		if err != nil {
+			return Backup{}, fmt.Errorf("error getting bookmarks while exporting backup: %w", err)
+		}
+
+		tags, err := db.GetTagRows(tx)
+		if err != nil {
+			return Backup{}, fmt.Errorf("error getting tags while exporting backup: %w", err)
+		}
+
+		bookmarksTags, err := db.GetBookmarkTagRows(tx, db.GetBookmarkTagRowsArgs{})
+		if err != nil {
+			return Backup{}, fmt.Errorf("error getting bookmark tags while exporting backup: %w", err)
+		}
+
+		return toBackup(bookmarks, tags, bookmarksTags), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(backup); err != nil {
+		return fmt.Errorf("error writing backup while exporting backup: %w", err)
+	}
+
+	return nil
+}
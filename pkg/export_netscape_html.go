@@ -0,0 +1,95 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/netscape"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// exportNetscapeHTMLOptions are the optional arguments for ExportNetscapeHTML.
+type exportNetscapeHTMLOptions struct {
+	DB       null.NullString
+	ParentID null.NullString
+}
+
+// DefaultExportNetscapeHTMLOptions are the default options for ExportNetscapeHTML.
+func DefaultExportNetscapeHTMLOptions() *exportNetscapeHTMLOptions {
+	return &exportNetscapeHTMLOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *exportNetscapeHTMLOptions) WithDB(db string) *exportNetscapeHTMLOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID sets the folder to export the bookmarks from.
+func (o *exportNetscapeHTMLOptions) WithParentID(parentID string) *exportNetscapeHTMLOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// ExportNetscapeHTML exports the bookmarks database as a Netscape bookmark file.
+// The folder structure and manual order of the bookmarks are preserved.
+func ExportNetscapeHTML(writer io.Writer, options *exportNetscapeHTMLOptions) error {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return fmt.Errorf("error getting config while exporting bookmarks: %w", err)
+	}
+
+	books, err := db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return nil, fmt.Errorf("parent ID validation failed while exporting bookmarks: %w", err)
+		}
+
+		books, err := db.GetDescendants(tx, options.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting bookmarks while exporting bookmarks: %w", err)
+		}
+
+		return toBooks(books), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	nodes := toNetscapeNodes(groupChildren(books), options.ParentID.String)
+	if err := netscape.Write(writer, nodes); err != nil {
+		return fmt.Errorf("error writing bookmark file while exporting bookmarks: %w", err)
+	}
+
+	return nil
+}
+
+// toNetscapeNodes converts the children of a folder to Netscape bookmark file nodes.
+func toNetscapeNodes(children map[string][]Book, parentID string) []*netscape.Node {
+	nodes := make([]*netscape.Node, 0)
+
+	for _, book := range children[parentID] {
+		node := &netscape.Node{
+			IsFolder: book.IsFolder,
+			Name:     book.Name,
+			Tags:     book.Tags,
+		}
+
+		if book.IsFolder {
+			node.Children = toNetscapeNodes(children, book.ID)
+		}
+
+		if book.URL != nil {
+			node.URL = *book.URL
+		}
+
+		if book.Description != nil {
+			node.Description = *book.Description
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
@@ -0,0 +1,64 @@
+package armaria
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/favicon"
+)
+
+// defaultFaviconTimeout is how long to wait for a page or icon when no HTTP client is provided.
+const defaultFaviconTimeout = 10 * time.Second
+
+// maxFaviconSize is the max size of an icon.
+// Icons are meant to be small so anything larger is skipped.
+const maxFaviconSize = 100 * 1024
+
+// Favicon is the icon of a bookmark's site.
+type Favicon struct {
+	BookID      string // the bookmark the icon belongs to
+	Hash        string // identifies the icon; bookmarks with the same icon share a hash
+	URL         string // the URL the icon was downloaded from
+	ContentType string // the media type of the icon
+	Size        int64  // the size of the icon in bytes
+	Fetched     string // when the icon was downloaded (UTC)
+	Content     []byte // the icon itself; only populated by GetFavicons
+}
+
+// fetchFavicon finds and downloads the icon for a bookmark.
+// If no HTTP client is provided one with a default timeout is used.
+func fetchFavicon(client *http.Client, bookID string, url string) (db.FaviconDTO, error) {
+	if client == nil {
+		client = &http.Client{Timeout: defaultFaviconTimeout}
+	}
+
+	icon, err := favicon.Fetch(context.Background(), client, url, maxFaviconSize)
+	if err != nil {
+		return db.FaviconDTO{}, err
+	}
+
+	return db.FaviconDTO{
+		BookmarkID:  bookID,
+		Hash:        icon.Hash,
+		URL:         icon.URL,
+		ContentType: icon.ContentType,
+		Content:     icon.Content,
+		Fetched:     time.Now().UTC().Format(time.DateTime),
+		Size:        int64(len(icon.Content)),
+	}, nil
+}
+
+// toFavicon converts a FaviconDTO to a Favicon.
+func toFavicon(dto db.FaviconDTO) Favicon {
+	return Favicon{
+		BookID:      dto.BookmarkID,
+		Hash:        dto.Hash,
+		URL:         dto.URL,
+		ContentType: dto.ContentType,
+		Size:        dto.Size,
+		Fetched:     dto.Fetched,
+		Content:     dto.Content,
+	}
+}
@@ -0,0 +1,86 @@
+package armaria
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jonathanhope/armaria/internal/metadata"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// defaultMetadataTimeout is how long to wait for a page when no HTTP client is provided.
+const defaultMetadataTimeout = 10 * time.Second
+
+// Metadata is what was learned about a web page.
+// Any of the fields can be empty if the page didn't provide them.
+type Metadata struct {
+	Title       string // title of the page; truncated to fit in a bookmark name
+	Description string // description of the page; truncated to fit in a bookmark description
+	Canonical   string // canonical URL of the page
+}
+
+// fetchMetadataOptions are the optional arguments for FetchMetadata.
+type fetchMetadataOptions struct {
+	HTTPClient *http.Client
+}
+
+// DefaultFetchMetadataOptions are the default options for FetchMetadata.
+func DefaultFetchMetadataOptions() *fetchMetadataOptions {
+	return &fetchMetadataOptions{}
+}
+
+// WithHTTPClient sets the HTTP client used to download the page.
+func (o *fetchMetadataOptions) WithHTTPClient(client *http.Client) *fetchMetadataOptions {
+	o.HTTPClient = client
+	return o
+}
+
+// FetchMetadata downloads a web page and extracts its title, description, and canonical URL.
+func FetchMetadata(url string, options *fetchMetadataOptions) (Metadata, error) {
+	if err := validateURL(null.NullStringFrom(url)); err != nil {
+		return Metadata{}, fmt.Errorf("URL validation failed while fetching metadata: %w", err)
+	}
+
+	meta, err := fetchMetadata(options.HTTPClient, url)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("error while fetching metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+// fetchMetadata downloads a web page and extracts its metadata.
+// If no HTTP client is provided one with a default timeout is used.
+func fetchMetadata(client *http.Client, url string) (Metadata, error) {
+	if client == nil {
+		client = &http.Client{Timeout: defaultMetadataTimeout}
+	}
+
+	meta, err := metadata.Fetch(context.Background(), client, url)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	return Metadata{
+		Title:       truncate(meta.Title, maxNameLength),
+		Description: truncate(meta.Description, maxDescriptionLength),
+		Canonical:   meta.Canonical,
+	}, nil
+}
+
+// truncate shortens a string to at most max bytes without splitting a character.
+func truncate(str string, max int) string {
+	if len(str) <= max {
+		return str
+	}
+
+	// Back up to the start of the character that crosses the limit.
+	for max > 0 && !utf8.RuneStart(str[max]) {
+		max--
+	}
+
+	return str[:max]
+}
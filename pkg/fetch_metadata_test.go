@@ -0,0 +1,30 @@
+package armaria
+
+import (
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	type test struct {
+		input string
+		max   int
+		want  string
+	}
+
+	tests := []test{
+		{input: "blog", max: 4, want: "blog"},
+		{input: "blog", max: 3, want: "blo"},
+		{input: "café", max: 4, want: "caf"},
+		{input: "café", max: 5, want: "café"},
+		{input: "", max: 3, want: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got := truncate(tc.input, tc.max)
+			if got != tc.want {
+				t.Errorf("got %s; want %s", got, tc.want)
+			}
+		})
+	}
+}
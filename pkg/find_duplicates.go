@@ -0,0 +1,87 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// Duplicates are bookmarks that have the same URL once it's normalized.
+type Duplicates struct {
+	URL   string // the normalized URL the bookmarks share
+	Books []Book // the bookmarks (oldest first); each Path shows where the bookmark lives
+}
+
+// findDuplicatesOptions are the optional arguments for FindDuplicates.
+type findDuplicatesOptions struct {
+	DB null.NullString
+}
+
+// DefaultFindDuplicatesOptions are the default options for FindDuplicates.
+func DefaultFindDuplicatesOptions() *findDuplicatesOptions {
+	return &findDuplicatesOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *findDuplicatesOptions) WithDB(db string) *findDuplicatesOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// FindDuplicates finds bookmarks that have the same URL.
+// URLs are compared after they have been normalized with NormalizeURL.
+func FindDuplicates(options *findDuplicatesOptions) ([]Duplicates, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while finding duplicates: %w", err)
+	}
+
+	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]Duplicates, error) {
+		books, err := db.GetBooks(tx, db.GetBooksArgs{
+			IncludeBooks: true,
+			Order:        db.OrderModified,
+			Direction:    db.DirectionAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting bookmarks while finding duplicates: %w", err)
+		}
+
+		// Groups are kept in the order their first bookmark was seen.
+		urls := make([]string, 0)
+		groups := make(map[string][]db.BookDTO)
+		for _, book := range books {
+			url := NormalizeURL(book.URL.String)
+			if _, ok := groups[url]; !ok {
+				urls = append(urls, url)
+			}
+			groups[url] = append(groups[url], book)
+		}
+
+		duplicates := make([]Duplicates, 0)
+		for _, url := range urls {
+			if len(groups[url]) < 2 {
+				continue
+			}
+
+			group := Duplicates{URL: url}
+			for _, dto := range groups[url] {
+				book := toBook(dto)
+
+				names, err := db.GetBookFolderParents(tx, book.ID)
+				if err != nil {
+					return nil, fmt.Errorf("error getting parent names while finding duplicates: %w", err)
+				}
+				book.Path = lo.Reverse(names)
+
+				group.Books = append(group.Books, book)
+			}
+
+			duplicates = append(duplicates, group)
+		}
+
+		return duplicates, nil
+	})
+}
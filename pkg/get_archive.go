@@ -0,0 +1,50 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// getArchiveOptions are the optional arguments for GetArchive.
+type getArchiveOptions struct {
+	DB null.NullString
+}
+
+// DefaultGetArchiveOptions are the default options for GetArchive.
+func DefaultGetArchiveOptions() *getArchiveOptions {
+	return &getArchiveOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *getArchiveOptions) WithDB(db string) *getArchiveOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// GetArchive gets the offline copy of a bookmark's page including its content.
+func GetArchive(id string, options *getArchiveOptions) (Archive, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return Archive{}, fmt.Errorf("error getting config while getting archive: %w", err)
+	}
+
+	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) (Archive, error) {
+		if err := validateBookID(tx, id); err != nil {
+			return Archive{}, fmt.Errorf("bookmark ID validation failed while getting archive: %w", err)
+		}
+
+		archives, err := db.GetArchive(tx, id)
+		if err != nil {
+			return Archive{}, fmt.Errorf("error while getting archive: %w", err)
+		}
+
+		if len(archives) == 0 {
+			return Archive{}, ErrArchiveNotFound
+		}
+
+		return toArchive(archives[0]), nil
+	})
+}
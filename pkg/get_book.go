@@ -32,19 +32,24 @@ func GetBook(id string, options *getBookOptions) (book Book, err error) {
 	}
 
 	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
-		books, err := db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:       id,
-			IncludeBooks:   true,
-			IncludeFolders: true,
-		})
-		if err != nil {
-			return Book{}, fmt.Errorf("error getting bookmarks while getting bookmark: %w", err)
-		}
-
-		if len(books) == 0 {
-			return Book{}, ErrNotFound
-		}
-
-		return toBook(books[0]), nil
+		return getBook(tx, id)
 	})
 }
+
+// getBook gets a bookmark or folder using an existing transaction.
+func getBook(tx db.Transaction, id string) (Book, error) {
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:       id,
+		IncludeBooks:   true,
+		IncludeFolders: true,
+	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting bookmarks while getting bookmark: %w", err)
+	}
+
+	if len(books) == 0 {
+		return Book{}, ErrNotFound
+	}
+
+	return toBook(books[0]), nil
+}
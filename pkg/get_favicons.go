@@ -0,0 +1,50 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// getFaviconsOptions are the optional arguments for GetFavicons.
+type getFaviconsOptions struct {
+	DB null.NullString
+}
+
+// DefaultGetFaviconsOptions are the default options for GetFavicons.
+func DefaultGetFaviconsOptions() *getFaviconsOptions {
+	return &getFaviconsOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *getFaviconsOptions) WithDB(db string) *getFaviconsOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// GetFavicons gets the stored icons of bookmarks including their content.
+// The network is never touched; bookmarks without a stored icon are left out.
+// If no IDs are provided every stored icon is returned.
+func GetFavicons(IDs []string, options *getFaviconsOptions) ([]Favicon, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while getting favicons: %w", err)
+	}
+
+	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]Favicon, error) {
+		dtos, err := db.GetFavicons(tx, db.GetFaviconsArgs{
+			BookmarkIDs:    IDs,
+			IncludeContent: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error while getting favicons: %w", err)
+		}
+
+		return lo.Map(dtos, func(dto db.FaviconDTO, _ int) Favicon {
+			return toFavicon(dto)
+		}), nil
+	})
+}
@@ -33,16 +33,21 @@ func GetParentNames(ID string, options *getParentNameOptions) ([]string, error)
 	}
 
 	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]string, error) {
-		names, err := db.GetBookFolderParents(tx, ID)
-		if err != nil {
-			return nil, fmt.Errorf("error getting parent: %w", err)
-		}
+		return getParentNames(tx, ID)
+	})
+}
+
+// getParentNames gets the parent names of a bookmark or folder using an existing transaction.
+func getParentNames(tx db.Transaction, ID string) ([]string, error) {
+	names, err := db.GetBookFolderParents(tx, ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting parent: %w", err)
+	}
 
-		if len(names) == 0 {
-			return nil, ErrNotFound
-		}
+	if len(names) == 0 {
+		return nil, ErrNotFound
+	}
 
-		names = lo.Reverse(names)
-		return names, nil
-	})
+	names = lo.Reverse(names)
+	return names, nil
 }
@@ -0,0 +1,33 @@
+package armaria
+
+import (
+	"cmp"
+	"slices"
+)
+
+// groupChildren groups bookmarks/folders by their parent folder.
+// Top level bookmarks/folders are grouped under an empty ID.
+// Each group is sorted by its manual order.
+func groupChildren(books []Book) map[string][]Book {
+	children := make(map[string][]Book)
+	for _, book := range books {
+		parentID := ""
+		if book.ParentID != nil {
+			parentID = *book.ParentID
+		}
+
+		children[parentID] = append(children[parentID], book)
+	}
+
+	for _, group := range children {
+		slices.SortFunc(group, func(a, b Book) int {
+			if n := cmp.Compare(a.Order, b.Order); n != 0 {
+				return n
+			}
+
+			return cmp.Compare(a.ID, b.ID)
+		})
+	}
+
+	return children
+}
@@ -0,0 +1,38 @@
+package armaria
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGroupChildren(t *testing.T) {
+	folder := "folder"
+
+	books := []Book{
+		{ID: "4", ParentID: &folder, Order: "b"},
+		{ID: "folder", IsFolder: true, Order: "c"},
+		{ID: "3", ParentID: &folder, Order: "a"},
+		{ID: "2", Order: "b"},
+		{ID: "1", Order: "b"},
+	}
+
+	want := map[string][]Book{
+		"": {
+			{ID: "1", Order: "b"},
+			{ID: "2", Order: "b"},
+			{ID: "folder", IsFolder: true, Order: "c"},
+		},
+		"folder": {
+			{ID: "3", ParentID: &folder, Order: "a"},
+			{ID: "4", ParentID: &folder, Order: "b"},
+		},
+	}
+
+	got := groupChildren(books)
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual groups different:\n%s", diff)
+	}
+}
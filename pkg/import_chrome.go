@@ -0,0 +1,73 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/browser"
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/internal/paths"
+)
+
+// importChromeOptions are the optional arguments for ImportChrome.
+type importChromeOptions struct {
+	DB       null.NullString
+	ParentID null.NullString
+	Profile  null.NullString
+}
+
+// DefaultImportChromeOptions are the default options for ImportChrome.
+func DefaultImportChromeOptions() *importChromeOptions {
+	return &importChromeOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *importChromeOptions) WithDB(db string) *importChromeOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID sets the folder to import the bookmarks into.
+func (o *importChromeOptions) WithParentID(parentID string) *importChromeOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// WithProfile sets the profile folder to import the bookmarks from.
+func (o *importChromeOptions) WithProfile(profile string) *importChromeOptions {
+	o.Profile = null.NullStringFrom(profile)
+	return o
+}
+
+// ImportChrome imports the bookmarks in a Chrome or Chromium profile into the bookmarks database.
+// The default Chrome profile is used if a profile is not provided.
+// The bookmarks bar, other, and mobile folders are imported as folders.
+// Either every bookmark/folder in the profile is imported or none of them are.
+func ImportChrome(options *importChromeOptions) (ImportResult, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return ImportResult{}, fmt.Errorf("error getting config while importing chrome bookmarks: %w", err)
+	}
+
+	profile := options.Profile.String
+	if !options.Profile.Valid {
+		profile, err = paths.ChromeProfile()
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("error getting default profile while importing chrome bookmarks: %w", err)
+		}
+	}
+
+	nodes, err := browser.ReadChrome(profile)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("error reading profile while importing chrome bookmarks: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (ImportResult, error) {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return ImportResult{}, fmt.Errorf("parent ID validation failed while importing chrome bookmarks: %w", err)
+		}
+
+		return importNetscapeNodes(tx, nodes, options.ParentID)
+	})
+}
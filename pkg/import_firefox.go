@@ -0,0 +1,79 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/browser"
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/internal/paths"
+)
+
+// importFirefoxOptions are the optional arguments for ImportFirefox.
+type importFirefoxOptions struct {
+	DB       null.NullString
+	ParentID null.NullString
+	Profile  null.NullString
+}
+
+// DefaultImportFirefoxOptions are the default options for ImportFirefox.
+func DefaultImportFirefoxOptions() *importFirefoxOptions {
+	return &importFirefoxOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *importFirefoxOptions) WithDB(db string) *importFirefoxOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID sets the folder to import the bookmarks into.
+func (o *importFirefoxOptions) WithParentID(parentID string) *importFirefoxOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// WithProfile sets the profile folder to import the bookmarks from.
+func (o *importFirefoxOptions) WithProfile(profile string) *importFirefoxOptions {
+	o.Profile = null.NullStringFrom(profile)
+	return o
+}
+
+// ImportFirefox imports the bookmarks in a Firefox profile into the bookmarks database.
+// The default profile is used if a profile is not provided.
+// The toolbar, menu, other, and mobile folders are imported as folders.
+// Firefox tags and keywords are imported as tags.
+// Either every bookmark/folder in the profile is imported or none of them are.
+func ImportFirefox(options *importFirefoxOptions) (ImportResult, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return ImportResult{}, fmt.Errorf("error getting config while importing firefox bookmarks: %w", err)
+	}
+
+	profile := options.Profile.String
+	if !options.Profile.Valid {
+		folder, err := paths.FirefoxProfiles()
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("error getting profiles folder while importing firefox bookmarks: %w", err)
+		}
+
+		profile, err = browser.FirefoxDefaultProfile(folder)
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("error getting default profile while importing firefox bookmarks: %w", err)
+		}
+	}
+
+	nodes, err := browser.ReadFirefox(profile)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("error reading profile while importing firefox bookmarks: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (ImportResult, error) {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return ImportResult{}, fmt.Errorf("parent ID validation failed while importing firefox bookmarks: %w", err)
+		}
+
+		return importNetscapeNodes(tx, nodes, options.ParentID)
+	})
+}
@@ -0,0 +1,151 @@
+package armaria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// importJSONOptions are the optional arguments for ImportJSON.
+type importJSONOptions struct {
+	DB   null.NullString
+	Mode RestoreMode
+}
+
+// DefaultImportJSONOptions are the default options for ImportJSON.
+func DefaultImportJSONOptions() *importJSONOptions {
+	return &importJSONOptions{
+		Mode: RestoreModeMerge,
+	}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *importJSONOptions) WithDB(db string) *importJSONOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithMode sets how the backup is restored.
+func (o *importJSONOptions) WithMode(mode RestoreMode) *importJSONOptions {
+	o.Mode = mode
+	return o
+}
+
+// ImportJSON restores a backup written by ExportJSON into the bookmarks database.
+// In replace mode the database will match the backup exactly.
+// In merge mode bookmarks/folders in the backup overwrite ones with the same ID and everything else is kept.
+// Either the entire backup is restored or none of it is.
+func ImportJSON(reader io.Reader, options *importJSONOptions) error {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return fmt.Errorf("error getting config while importing backup: %w", err)
+	}
+
+	if err := validateRestoreMode(options.Mode); err != nil {
+		return fmt.Errorf("mode validation failed while importing backup: %w", err)
+	}
+
+	var backup Backup
+	if err := json.NewDecoder(reader).Decode(&backup); err != nil {
+		return fmt.Errorf("error parsing backup while importing backup: %w: %w", ErrInvalidBackup, err)
+	}
+
+	if err := validateBackup(backup); err != nil {
+		return fmt.Errorf("backup validation failed while importing backup: %w", err)
+	}
+
+	return db.ExecWithTransaction(options.DB, config.DB, func(tx db.Transaction) error {
+		ids := lo.Map(backup.Bookmarks, func(bookmark BackupBookmark, _ int) string {
+			return bookmark.ID
+		})
+
+		if options.Mode == RestoreModeReplace {
+			if err := db.RemoveAll(tx); err != nil {
+				return fmt.Errorf("error removing existing bookmarks while importing backup: %w", err)
+			}
+		} else if err := db.UnlinkAllTags(tx, ids); err != nil {
+			return fmt.Errorf("error removing existing tags while importing backup: %w", err)
+		}
+
+		existing, err := db.GetBookmarkRows(tx, db.GetBookmarkRowsArgs{IDsFilter: ids})
+		if err != nil {
+			return fmt.Errorf("error getting existing bookmarks while importing backup: %w", err)
+		}
+		rows := lo.KeyBy(existing, func(row db.BookmarkRowDTO) string { return row.ID })
+
+		// Parents are restored first so they exist before their children reference them.
+		for _, bookmark := range sortParentsFirst(backup.Bookmarks) {
+			if bookmark.ParentID != nil && !lo.Contains(ids, *bookmark.ParentID) {
+				if err := validateParentID(tx, null.NullStringFromPtr(bookmark.ParentID)); err != nil {
+					return fmt.Errorf("parent ID validation failed while importing backup: %w", err)
+				}
+			}
+
+			if row, ok := rows[bookmark.ID]; ok {
+				if err := validateOverwrite(tx, row, bookmark); err != nil {
+					return fmt.Errorf("bookmark validation failed while importing backup: %w", err)
+				}
+			}
+
+			if err := db.UpsertBookmarkRow(tx, fromBackupBookmark(bookmark)); err != nil {
+				return fmt.Errorf("error restoring bookmark while importing backup: %w", err)
+			}
+		}
+
+		for _, tag := range backup.Tags {
+			row := db.TagRowDTO{Tag: tag.Tag, Modified: tag.Modified}
+			if err := db.UpsertTagRow(tx, row); err != nil {
+				return fmt.Errorf("error restoring tag while importing backup: %w", err)
+			}
+		}
+
+		for _, bookmarkTag := range backup.BookmarksTags {
+			row := db.BookmarkTagRowDTO{BookmarkID: bookmarkTag.BookmarkID, Tag: bookmarkTag.Tag, Modified: bookmarkTag.Modified}
+			if err := db.UpsertBookmarkTagRow(tx, row); err != nil {
+				return fmt.Errorf("error restoring bookmark tag while importing backup: %w", err)
+			}
+		}
+
+		if options.Mode == RestoreModeMerge {
+			if err := db.CleanAllOrphanedTags(tx); err != nil {
+				return fmt.Errorf("error cleaning orphaned tags while importing backup: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// validateOverwrite validates overwriting a bookmark/folder in the database with one from a backup.
+// The folder structure in the database has to stay a tree:
+// a folder can't be moved into one of its descendants and a folder with children can't become a bookmark.
+func validateOverwrite(tx db.Transaction, row db.BookmarkRowDTO, bookmark BackupBookmark) error {
+	if !row.IsFolder {
+		return nil
+	}
+
+	if !bookmark.IsFolder {
+		children, err := db.GetParentAndChildren(tx, row.ID)
+		if err != nil {
+			return fmt.Errorf("error getting folder and children while validating overwrite: %w", err)
+		}
+
+		if len(children) > 1 {
+			return ErrFolderHasChildren
+		}
+	}
+
+	parentID := null.NullStringFromPtr(bookmark.ParentID)
+	if parentID.Valid != row.ParentID.Valid || parentID.String != row.ParentID.String {
+		if err := validateMove(tx, row.ID, parentID); err != nil {
+			return fmt.Errorf("move validation failed while validating overwrite: %w", err)
+		}
+	}
+
+	return nil
+}
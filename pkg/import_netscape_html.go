@@ -0,0 +1,126 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/netscape"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// importNetscapeHTMLOptions are the optional arguments for ImportNetscapeHTML.
+type importNetscapeHTMLOptions struct {
+	DB       null.NullString
+	ParentID null.NullString
+}
+
+// DefaultImportNetscapeHTMLOptions are the default options for ImportNetscapeHTML.
+func DefaultImportNetscapeHTMLOptions() *importNetscapeHTMLOptions {
+	return &importNetscapeHTMLOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *importNetscapeHTMLOptions) WithDB(db string) *importNetscapeHTMLOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID sets the folder to import the bookmarks into.
+func (o *importNetscapeHTMLOptions) WithParentID(parentID string) *importNetscapeHTMLOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// ImportResult is the outcome of importing bookmarks from another bookmarks manager.
+type ImportResult struct {
+	Books       []Book   // the bookmarks/folders that were imported
+	SkippedTags []string // tags that weren't imported because they aren't valid Armaria tags
+}
+
+// ImportNetscapeHTML imports a Netscape bookmark file into the bookmarks database.
+// The folder structure and order of the file are preserved.
+// Either every bookmark/folder in the file is imported or none of them are.
+func ImportNetscapeHTML(reader io.Reader, options *importNetscapeHTMLOptions) (ImportResult, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return ImportResult{}, fmt.Errorf("error getting config while importing bookmarks: %w", err)
+	}
+
+	nodes, err := netscape.Parse(reader)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("error parsing bookmark file while importing bookmarks: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (ImportResult, error) {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return ImportResult{}, fmt.Errorf("parent ID validation failed while importing bookmarks: %w", err)
+		}
+
+		return importNetscapeNodes(tx, nodes, options.ParentID)
+	})
+}
+
+// defaultFolderName is the name given to imported folders that don't have one.
+const defaultFolderName = "Untitled folder"
+
+// importNetscapeNodes adds a level of the Netscape bookmark tree (and all of its children) to the bookmarks database.
+func importNetscapeNodes(tx db.Transaction, nodes []*netscape.Node, parentID null.NullString) (ImportResult, error) {
+	result := ImportResult{
+		Books:       make([]Book, 0),
+		SkippedTags: make([]string, 0),
+	}
+
+	for _, node := range nodes {
+		if node.IsFolder {
+			options := DefaultAddFolderOptions()
+			if parentID.Valid {
+				options.WithParentID(parentID.String)
+			}
+
+			name := strings.TrimSpace(node.Name)
+			if name == "" {
+				name = defaultFolderName
+			}
+
+			folder, err := addFolder(tx, name, options)
+			if err != nil {
+				return ImportResult{}, fmt.Errorf("error adding folder while importing bookmarks: %w", err)
+			}
+			result.Books = append(result.Books, folder)
+
+			children, err := importNetscapeNodes(tx, node.Children, null.NullStringFrom(folder.ID))
+			if err != nil {
+				return ImportResult{}, err
+			}
+			result.Books = append(result.Books, children.Books...)
+			result.SkippedTags = lo.Uniq(append(result.SkippedTags, children.SkippedTags...))
+		} else {
+			options := DefaultAddBookOptions()
+			if parentID.Valid {
+				options.WithParentID(parentID.String)
+			}
+			if node.Name != "" {
+				options.WithName(node.Name)
+			}
+			if node.Description != "" {
+				options.WithDescription(node.Description)
+			}
+
+			tags, skipped := importTags(node.Tags)
+			options.WithTags(tags)
+			result.SkippedTags = lo.Uniq(append(result.SkippedTags, skipped...))
+
+			book, err := addBook(tx, node.URL, options)
+			if err != nil {
+				return ImportResult{}, fmt.Errorf("error adding bookmark while importing bookmarks: %w", err)
+			}
+			result.Books = append(result.Books, book)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,28 @@
+package armaria
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// importTags converts tags from another bookmarks manager into Armaria tags.
+// Tags that aren't valid Armaria tags (node.js, c++) are skipped rather than rewritten, since rewriting them could merge different tags together.
+// Tags past the max number of tags are skipped too.
+// The imported tags are returned along with the tags that were skipped.
+func importTags(tags []string) ([]string, []string) {
+	imported := make([]string, 0)
+	skipped := make([]string, 0)
+
+	for _, tag := range lo.Uniq(lo.Compact(lo.Map(tags, func(tag string, _ int) string {
+		return strings.TrimSpace(tag)
+	}))) {
+		if len(imported) == maxTags || validateTags([]string{tag}, nil) != nil {
+			skipped = append(skipped, tag)
+		} else {
+			imported = append(imported, tag)
+		}
+	}
+
+	return imported, skipped
+}
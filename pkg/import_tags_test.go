@@ -0,0 +1,56 @@
+package armaria
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportTags(t *testing.T) {
+	type test struct {
+		input   []string
+		want    []string
+		skipped []string
+	}
+
+	tests := []test{
+		{input: []string{}, want: []string{}, skipped: []string{}},
+		{input: []string{"blog", "programming"}, want: []string{"blog", "programming"}, skipped: []string{}},
+		{input: []string{" blog ", "blog", "  "}, want: []string{"blog"}, skipped: []string{}},
+		{input: []string{"web dev", "web-dev"}, want: []string{"web-dev"}, skipped: []string{"web dev"}},
+		{input: []string{"node.js", "c++", "c--", "web"}, want: []string{"c--", "web"}, skipped: []string{"node.js", "c++"}},
+		{input: []string{strings.Repeat("a", 200)}, want: []string{}, skipped: []string{strings.Repeat("a", 200)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(strings.Join(tc.input, ","), func(t *testing.T) {
+			got, skipped := importTags(tc.input)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %+v; want %+v", got, tc.want)
+			}
+			if !reflect.DeepEqual(skipped, tc.skipped) {
+				t.Errorf("got skipped %+v; want %+v", skipped, tc.skipped)
+			}
+		})
+	}
+}
+
+func TestImportTagsMax(t *testing.T) {
+	tags := make([]string, 0)
+	for i := 0; i < 30; i++ {
+		tags = append(tags, fmt.Sprintf("tag-%d", i))
+	}
+
+	got, skipped := importTags(tags)
+	if len(got) != 24 {
+		t.Errorf("got %d tags; want 24", len(got))
+	}
+	if len(skipped) != 6 {
+		t.Errorf("got %d skipped tags; want 6", len(skipped))
+	}
+
+	if err := validateTags(got, make([]string, 0)); err != nil {
+		t.Errorf("imported tags aren't valid: %s", err)
+	}
+}
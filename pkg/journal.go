@@ -0,0 +1,215 @@
+package armaria
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/samber/lo"
+)
+
+// maxJournalEntries is the max number of operations kept in the journal.
+const maxJournalEntries = 1000
+
+// Operation is a kind of change to the bookmarks database that can be undone.
+type Operation string
+
+const (
+	OperationAddBook               Operation = "add-book"                // a bookmark was added
+	OperationAddFolder             Operation = "add-folder"              // a folder was added
+	OperationAddSession            Operation = "add-session"             // a folder with a bookmark for each URL in a session was added
+	OperationUpdateBook            Operation = "update-book"             // a bookmark was updated or moved
+	OperationUpdateFolder          Operation = "update-folder"           // a folder was updated or moved
+	OperationRemoveBook            Operation = "remove-book"             // a bookmark was moved to the trash
+	OperationRemoveFolder          Operation = "remove-folder"           // a folder and everything in it was moved to the trash
+	OperationAddTags               Operation = "add-tags"                // tags were added to a bookmark
+	OperationRemoveTags            Operation = "remove-tags"             // tags were removed from a bookmark
+	OperationMergeBooks            Operation = "merge-books"             // duplicate bookmarks were merged into a surviving bookmark
+	OperationRestoreBook           Operation = "restore-book"            // a bookmark or folder was restored from the trash
+	OperationRenameTag             Operation = "rename-tag"              // a tag was renamed on every bookmark
+	OperationMergeTags             Operation = "merge-tags"              // tags were merged into a single tag on every bookmark
+	OperationDeleteTag             Operation = "delete-tag"              // a tag was removed from every bookmark
+	OperationBulkAddTags           Operation = "bulk-add-tags"           // tags were added to several bookmarks
+	OperationBulkRemoveTags        Operation = "bulk-remove-tags"        // tags were removed from several bookmarks
+	OperationBulkMove              Operation = "bulk-move"               // several bookmarks/folders were moved to a folder
+	OperationBulkRemove            Operation = "bulk-remove"             // several bookmarks/folders were moved to the trash
+	OperationBulkUpdateDescription Operation = "bulk-update-description" // the description of several bookmarks was updated
+	OperationCopyBook              Operation = "copy-book"               // a bookmark was copied
+	OperationCopyFolder            Operation = "copy-folder"             // a folder and everything in it was copied
+)
+
+// JournalEntry is an operation recorded in the journal.
+type JournalEntry struct {
+	ID          int64     // identifies the entry; later operations have larger IDs
+	Operation   Operation // the kind of operation
+	Description string    // a short description of the operation
+	Undone      bool      // true if the operation has been undone
+	Created     string    // when the operation happened (UTC)
+}
+
+// journalState is the state of the bookmarks/folders affected by an operation.
+// It's stored in the journal using the same format as backups.
+// Archives, favicons, and link checks aren't part of the state.
+type journalState struct {
+	Bookmarks     []BackupBookmark    `json:"bookmarks"`     // the rows of the bookmarks table
+	BookmarksTags []BackupBookmarkTag `json:"bookmarksTags"` // the rows of the bookmarks_tags table
+}
+
+// toJournalEntry converts a JournalEntryDTO to a JournalEntry.
+func toJournalEntry(dto db.JournalEntryDTO) JournalEntry {
+	return JournalEntry{
+		ID:          dto.ID,
+		Operation:   Operation(dto.Operation),
+		Description: dto.Description,
+		Undone:      dto.Undone,
+		Created:     dto.Created,
+	}
+}
+
+// snapshot captures the current state of a set of bookmarks/folders.
+// Bookmarks/folders that don't exist are left out.
+func snapshot(tx db.Transaction, IDs []string) (journalState, error) {
+	state := journalState{
+		Bookmarks:     make([]BackupBookmark, 0),
+		BookmarksTags: make([]BackupBookmarkTag, 0),
+	}
+
+	if len(IDs) == 0 {
+		return state, nil
+	}
+
+	bookmarks, err := db.GetBookmarkRows(tx, db.GetBookmarkRowsArgs{IDsFilter: IDs})
+	if err != nil {
+		return state, fmt.Errorf("error getting bookmarks while taking snapshot: %w", err)
+	}
+
+	bookmarksTags, err := db.GetBookmarkTagRows(tx, db.GetBookmarkTagRowsArgs{BookmarkIDsFilter: IDs})
+	if err != nil {
+		return state, fmt.Errorf("error getting tags while taking snapshot: %w", err)
+	}
+
+	state.Bookmarks = append(state.Bookmarks, lo.Map(bookmarks, toBackupBookmark)...)
+	state.BookmarksTags = append(state.BookmarksTags, lo.Map(bookmarksTags, toBackupBookmarkTag)...)
+
+	return state, nil
+}
+
+// record adds an operation to the journal.
+// The state of the affected bookmarks/folders after the operation is captured so it can be redone.
+func record(tx db.Transaction, operation Operation, description string, IDs []string, before journalState) error {
+	after, err := snapshot(tx, IDs)
+	if err != nil {
+		return fmt.Errorf("error taking snapshot while recording operation: %w", err)
+	}
+
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return fmt.Errorf("error marshalling state while recording operation: %w", err)
+	}
+
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return fmt.Errorf("error marshalling state while recording operation: %w", err)
+	}
+
+	entry := db.JournalEntryDTO{
+		Operation:   string(operation),
+		Description: description,
+		Before:      string(beforeJSON),
+		After:       string(afterJSON),
+	}
+
+	if err := db.AddJournalEntry(tx, entry, maxJournalEntries); err != nil {
+		return fmt.Errorf("error while recording operation: %w", err)
+	}
+
+	return nil
+}
+
+// restore changes a set of bookmarks/folders from one state to another.
+// Bookmarks/folders that are only in the current state are removed.
+// Bookmarks/folders in the target state are restored exactly including their timestamps.
+func restore(tx db.Transaction, current journalState, target journalState) error {
+	ids := lo.Union(
+		lo.Map(current.Bookmarks, func(bookmark BackupBookmark, _ int) string { return bookmark.ID }),
+		lo.Map(target.Bookmarks, func(bookmark BackupBookmark, _ int) string { return bookmark.ID }),
+	)
+
+	if err := db.UnlinkAllTags(tx, ids); err != nil {
+		return fmt.Errorf("error removing existing tags while restoring: %w", err)
+	}
+
+	// Children are removed before their parents so no folder is removed while it still has children.
+	for _, bookmark := range lo.Reverse(sortParentsFirst(current.Bookmarks)) {
+		if lo.ContainsBy(target.Bookmarks, func(other BackupBookmark) bool { return other.ID == bookmark.ID }) {
+			continue
+		}
+
+		remove := db.RemoveBook
+		if bookmark.IsFolder {
+			remove = db.RemoveFolder
+		}
+
+		if err := remove(tx, bookmark.ID); err != nil {
+			return fmt.Errorf("error removing bookmark while restoring: %w", err)
+		}
+	}
+
+	// Parents are restored first so they exist before their children reference them.
+	for _, bookmark := range sortParentsFirst(target.Bookmarks) {
+		if err := db.UpsertBookmarkRow(tx, fromBackupBookmark(bookmark)); err != nil {
+			return fmt.Errorf("error restoring bookmark while restoring: %w", err)
+		}
+	}
+
+	tags := lo.Uniq(lo.Map(target.BookmarksTags, func(bookmarkTag BackupBookmarkTag, _ int) string {
+		return bookmarkTag.Tag
+	}))
+
+	existingTags, err := db.GetTags(tx, db.GetTagsArgs{
+		TagsFilter: tags,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting tags while restoring: %w", err)
+	}
+
+	tagsToAdd, _ := lo.Difference(tags, existingTags)
+	if err = db.AddTags(tx, tagsToAdd); err != nil {
+		return fmt.Errorf("error adding tags while restoring: %w", err)
+	}
+
+	for _, bookmarkTag := range target.BookmarksTags {
+		row := db.BookmarkTagRowDTO{BookmarkID: bookmarkTag.BookmarkID, Tag: bookmarkTag.Tag, Modified: bookmarkTag.Modified}
+		if err := db.UpsertBookmarkTagRow(tx, row); err != nil {
+			return fmt.Errorf("error restoring bookmark tag while restoring: %w", err)
+		}
+	}
+
+	if err := db.CleanAllOrphanedTags(tx); err != nil {
+		return fmt.Errorf("error cleaning orphaned tags while restoring: %w", err)
+	}
+
+	return nil
+}
+
+// parseJournalEntry parses the states of the bookmarks/folders before and after an operation.
+func parseJournalEntry(entry db.JournalEntryDTO) (journalState, journalState, error) {
+	var before journalState
+	if err := json.Unmarshal([]byte(entry.Before), &before); err != nil {
+		return journalState{}, journalState{}, err
+	}
+
+	var after journalState
+	if err := json.Unmarshal([]byte(entry.After), &after); err != nil {
+		return journalState{}, journalState{}, err
+	}
+
+	return before, after, nil
+}
+
+// bookIDs gets the IDs of a set of bookmarks/folders.
+func bookIDs(books []Book) []string {
+	return lo.Map(books, func(book Book, _ int) string {
+		return book.ID
+	})
+}
@@ -3,9 +3,11 @@ package armaria
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jonathanhope/armaria/internal/db"
 	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/nullism/bqb"
 )
 
 // listBookOptions are the optional arguments for ListBooks.
@@ -16,6 +18,12 @@ type listBookOptions struct {
 	ParentID         null.NullString
 	Query            null.NullString
 	Tags             []string
+	TagMode          TagMode
+	ExcludeTags      []string
+	Recursive        bool
+	MaxDepth         null.NullInt64
+	ModifiedAfter    null.NullString
+	CreatedBefore    null.NullString
 	After            null.NullString
 	Order            Order
 	Direction        Direction
@@ -27,6 +35,7 @@ func DefaultListBooksOptions() *listBookOptions {
 	return &listBookOptions{
 		IncludeBookmarks: true,
 		IncludeFolders:   true,
+		TagMode:          TagModeAny,
 		Order:            OrderManual,
 		Direction:        DirectionAsc,
 	}
@@ -57,6 +66,7 @@ func (o *listBookOptions) WithParentID(parentID string) *listBookOptions {
 }
 
 // WithQuery searches on name, URL, description.
+// Queries can also filter on tags, folders, and more (see search_query.go for the syntax).
 func (o *listBookOptions) WithQuery(query string) *listBookOptions {
 	o.Query = null.NullStringFrom(query)
 	return o
@@ -68,6 +78,44 @@ func (o *listBookOptions) WithTags(tags []string) *listBookOptions {
 	return o
 }
 
+// WithTagMode sets whether results need any or all of the tags.
+func (o *listBookOptions) WithTagMode(mode TagMode) *listBookOptions {
+	o.TagMode = mode
+	return o
+}
+
+// WithExcludeTags filters out results with any of these tags.
+func (o *listBookOptions) WithExcludeTags(tags []string) *listBookOptions {
+	o.ExcludeTags = tags
+	return o
+}
+
+// WithRecursive sets whether to include everything under the parent instead of just its direct children.
+func (o *listBookOptions) WithRecursive(recursive bool) *listBookOptions {
+	o.Recursive = recursive
+	return o
+}
+
+// WithDepth sets how many levels deep to list; direct children are at depth 1.
+// It implies WithRecursive.
+func (o *listBookOptions) WithDepth(depth int64) *listBookOptions {
+	o.Recursive = true
+	o.MaxDepth = null.NullInt64From(depth)
+	return o
+}
+
+// WithModifiedAfter only returns results modified after a time.
+func (o *listBookOptions) WithModifiedAfter(modified time.Time) *listBookOptions {
+	o.ModifiedAfter = null.NullStringFrom(modified.UTC().Format(time.DateTime))
+	return o
+}
+
+// WithCreatedBefore only returns results created before a time.
+func (o *listBookOptions) WithCreatedBefore(created time.Time) *listBookOptions {
+	o.CreatedBefore = null.NullStringFrom(created.UTC().Format(time.DateTime))
+	return o
+}
+
 // WithAfter returns results after an ID.
 func (o *listBookOptions) WithAfter(after string) *listBookOptions {
 	o.After = null.NullStringFrom(after)
@@ -106,41 +154,87 @@ func ListBooks(options *listBookOptions) ([]Book, error) {
 	}
 
 	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
-		if !options.IncludeBookmarks && !options.IncludeFolders {
-			return nil, nil
-		}
+		return listBooks(tx, options)
+	})
+}
 
-		if err := validateFirst(options.First); err != nil {
-			return nil, fmt.Errorf("first validation failed while listing bookmarks: %w", err)
-		}
+// listBooks lists bookmarks and folders in the bookmarks database using an existing transaction.
+func listBooks(tx db.Transaction, options *listBookOptions) ([]Book, error) {
+	if !options.IncludeBookmarks && !options.IncludeFolders {
+		return nil, nil
+	}
 
-		if err := validateDirection(options.Direction); err != nil {
-			return nil, fmt.Errorf("direction validation failed while listing bookmarks: %w", err)
-		}
+	if err := validateFirst(options.First); err != nil {
+		return nil, fmt.Errorf("first validation failed while listing bookmarks: %w", err)
+	}
 
-		if err := validateOrder(options.Order); err != nil {
-			return nil, fmt.Errorf("order validation failed while listing bookmarks: %w", err)
-		}
+	if err := validateDepth(options.MaxDepth); err != nil {
+		return nil, fmt.Errorf("depth validation failed while listing bookmarks: %w", err)
+	}
 
-		if err := validateQuery(options.Query); err != nil {
-			return nil, fmt.Errorf("query validation failed while listing bookmarks: %w", err)
-		}
+	if err := validateDirection(options.Direction); err != nil {
+		return nil, fmt.Errorf("direction validation failed while listing bookmarks: %w", err)
+	}
 
-		books, err := db.GetBooks(tx, db.GetBooksArgs{
-			IncludeBooks:   options.IncludeBookmarks,
-			IncludeFolders: options.IncludeFolders,
-			ParentID:       options.ParentID,
-			Query:          options.Query,
-			Tags:           options.Tags,
-			After:          options.After,
-			Order:          options.Order,
-			Direction:      options.Direction,
-			First:          options.First,
-		})
+	if err := validateOrder(options.Order); err != nil {
+		return nil, fmt.Errorf("order validation failed while listing bookmarks: %w", err)
+	}
+
+	if err := validateTagMode(options.TagMode); err != nil {
+		return nil, fmt.Errorf("tag mode validation failed while listing bookmarks: %w", err)
+	}
+
+	var search searchQuery
+	if options.Query.Valid {
+		var err error
+		search, err = compileQuery(options.Query.String)
 		if err != nil {
-			return nil, fmt.Errorf("error while listing bookmarks: %w", err)
+			return nil, fmt.Errorf("query validation failed while listing bookmarks: %w", err)
 		}
+	}
 
-		return toBooks(books), nil
+	filter := search.filter
+	if options.ModifiedAfter.Valid {
+		filter = andFilter(filter, db.ModifiedAfterFilter(options.ModifiedAfter.String))
+	}
+	if options.CreatedBefore.Valid {
+		filter = andFilter(filter, db.CreatedBeforeFilter(options.CreatedBefore.String))
+	}
+
+	// Snippets are only returned when ranking.
+	var match null.NullString
+	if options.Order == OrderRelevance {
+		match = search.match
+	}
+
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IncludeBooks:   options.IncludeBookmarks,
+		IncludeFolders: options.IncludeFolders,
+		ParentID:       options.ParentID,
+		Filter:         filter,
+		Match:          match,
+		Tags:           options.Tags,
+		TagMode:        options.TagMode,
+		ExcludeTags:    options.ExcludeTags,
+		Recursive:      options.Recursive,
+		MaxDepth:       options.MaxDepth,
+		After:          options.After,
+		Order:          options.Order,
+		Direction:      options.Direction,
+		First:          options.First,
 	})
+	if err != nil {
+		return nil, fmt.Errorf("error while listing bookmarks: %w", err)
+	}
+
+	return toBooks(books), nil
+}
+
+// andFilter combines two filters where the left one may be missing.
+func andFilter(left *bqb.Query, right *bqb.Query) *bqb.Query {
+	if left == nil {
+		return right
+	}
+
+	return db.AndFilter(left, right)
 }
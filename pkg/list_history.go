@@ -0,0 +1,60 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// listHistoryOptions are the optional arguments for ListHistory.
+type listHistoryOptions struct {
+	DB    null.NullString
+	First null.NullInt64
+}
+
+// DefaultListHistoryOptions are the default options for ListHistory.
+func DefaultListHistoryOptions() *listHistoryOptions {
+	return &listHistoryOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *listHistoryOptions) WithDB(db string) *listHistoryOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithFirst sets the max number of results to return.
+func (o *listHistoryOptions) WithFirst(first int64) *listHistoryOptions {
+	o.First = null.NullInt64From(first)
+	return o
+}
+
+// ListHistory lists the operations in the journal from newest to oldest.
+func ListHistory(options *listHistoryOptions) ([]JournalEntry, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while listing history: %w", err)
+	}
+
+	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]JournalEntry, error) {
+		entries := make([]JournalEntry, 0)
+
+		if err := validateFirst(options.First); err != nil {
+			return entries, fmt.Errorf("first validation failed while listing history: %w", err)
+		}
+
+		dtos, err := db.GetJournalEntries(tx, db.GetJournalEntriesArgs{
+			First: options.First,
+		})
+		if err != nil {
+			return entries, fmt.Errorf("error while listing history: %w", err)
+		}
+
+		return append(entries, lo.Map(dtos, func(dto db.JournalEntryDTO, _ int) JournalEntry {
+			return toJournalEntry(dto)
+		})...), nil
+	})
+}
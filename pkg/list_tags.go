@@ -6,9 +6,16 @@ import (
 
 	"github.com/jonathanhope/armaria/internal/db"
 	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
 )
 
-// listTagsOptions are the optional arguments for ListTags.
+// TagCount is a tag and how many bookmarks it's applied to.
+type TagCount struct {
+	Tag   string // the tag
+	Count int64  // the number of bookmarks the tag is applied to; bookmarks in the trash aren't counted
+}
+
+// listTagsOptions are the optional arguments for ListTags and ListTagCounts.
 type listTagsOptions struct {
 	DB        null.NullString
 	Query     null.NullString
@@ -62,30 +69,84 @@ func ListTags(options *listTagsOptions) ([]string, error) {
 	}
 
 	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]string, error) {
-		tags := make([]string, 0)
-
-		if err := validateFirst(options.First); err != nil {
-			return tags, fmt.Errorf("first validation failed while listing tags: %w", err)
-		}
-
-		if err := validateDirection(options.Direction); err != nil {
-			return tags, fmt.Errorf("direction validation failed while listing tags: %w", err)
-		}
-
-		if err := validateQuery(options.Query); err != nil {
-			return tags, fmt.Errorf("query validation failed while listing tags: %w", err)
-		}
-
-		tags, err := db.GetTags(tx, db.GetTagsArgs{
-			Query:     options.Query,
-			After:     options.After,
-			Direction: options.Direction,
-			First:     options.First,
-		})
-		if err != nil {
-			return tags, fmt.Errorf("error while listing tags: %w", err)
-		}
-
-		return tags, nil
+		return listTags(tx, options)
+	})
+}
+
+// listTags lists tags using an existing transaction.
+func listTags(tx db.Transaction, options *listTagsOptions) ([]string, error) {
+	tags := make([]string, 0)
+
+	if err := validateFirst(options.First); err != nil {
+		return tags, fmt.Errorf("first validation failed while listing tags: %w", err)
+	}
+
+	if err := validateDirection(options.Direction); err != nil {
+		return tags, fmt.Errorf("direction validation failed while listing tags: %w", err)
+	}
+
+	if err := validateQuery(options.Query); err != nil {
+		return tags, fmt.Errorf("query validation failed while listing tags: %w", err)
+	}
+
+	tags, err := db.GetTags(tx, db.GetTagsArgs{
+		Query:      options.Query,
+		After:      options.After,
+		Direction:  options.Direction,
+		First:      options.First,
+		ActiveOnly: true,
 	})
+	if err != nil {
+		return tags, fmt.Errorf("error while listing tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// ListTagCounts lists tags in the bookmarks database along with how many bookmarks they are applied to.
+func ListTagCounts(options *listTagsOptions) ([]TagCount, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while listing tag counts: %w", err)
+	}
+
+	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]TagCount, error) {
+		return listTagCounts(tx, options)
+	})
+}
+
+// listTagCounts lists tags along with their counts using an existing transaction.
+func listTagCounts(tx db.Transaction, options *listTagsOptions) ([]TagCount, error) {
+	if err := validateFirst(options.First); err != nil {
+		return nil, fmt.Errorf("first validation failed while listing tag counts: %w", err)
+	}
+
+	if err := validateDirection(options.Direction); err != nil {
+		return nil, fmt.Errorf("direction validation failed while listing tag counts: %w", err)
+	}
+
+	if err := validateQuery(options.Query); err != nil {
+		return nil, fmt.Errorf("query validation failed while listing tag counts: %w", err)
+	}
+
+	tags, err := db.GetTagCounts(tx, db.GetTagsArgs{
+		Query:      options.Query,
+		After:      options.After,
+		Direction:  options.Direction,
+		First:      options.First,
+		ActiveOnly: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while listing tag counts: %w", err)
+	}
+
+	return lo.Map(tags, toTagCount), nil
+}
+
+// toTagCount converts a TagCountDTO to a TagCount.
+func toTagCount(dto db.TagCountDTO, _ int) TagCount {
+	return TagCount{
+		Tag:   dto.Tag,
+		Count: dto.Count,
+	}
 }
@@ -0,0 +1,55 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// listTrashOptions are the optional arguments for ListTrash.
+type listTrashOptions struct {
+	DB null.NullString
+}
+
+// DefaultListTrashOptions are the default options for ListTrash.
+func DefaultListTrashOptions() *listTrashOptions {
+	return &listTrashOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *listTrashOptions) WithDB(db string) *listTrashOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// ListTrash lists the bookmarks/folders in the trash.
+// The most recently removed come first.
+func ListTrash(options *listTrashOptions) ([]Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while listing trash: %w", err)
+	}
+
+	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		books, err := db.GetBooks(tx, db.GetBooksArgs{
+			IncludeBooks:   true,
+			IncludeFolders: true,
+			DeletedOnly:    true,
+			Order:          OrderName,
+			Direction:      DirectionAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error while listing trash: %w", err)
+		}
+
+		trash := append(make([]Book, 0), toBooks(books)...)
+		sort.SliceStable(trash, func(i, j int) bool {
+			return *trash[i].DeletedAt > *trash[j].DeletedAt
+		})
+
+		return trash, nil
+	})
+}
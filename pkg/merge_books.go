@@ -0,0 +1,128 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// mergeBooksOptions are the optional arguments for MergeBooks.
+type mergeBooksOptions struct {
+	DB null.NullString
+}
+
+// DefaultMergeBooksOptions are the default options for MergeBooks.
+func DefaultMergeBooksOptions() *mergeBooksOptions {
+	return &mergeBooksOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *mergeBooksOptions) WithDB(db string) *mergeBooksOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// MergeBooks merges duplicate bookmarks into a surviving bookmark.
+// The duplicates must have the same URL as the survivor once they're normalized.
+// The survivor gets the tags of all of the duplicates.
+// Descriptions that are different are joined together with a blank line between them.
+// The duplicates are moved to the trash once they have been merged.
+func MergeBooks(id string, duplicateIDs []string, options *mergeBooksOptions) (Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return Book{}, fmt.Errorf("error getting config while merging bookmarks: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
+		if err := validateBookID(tx, id); err != nil {
+			return Book{}, fmt.Errorf("bookmark ID validation failed while merging bookmarks: %w", err)
+		}
+
+		survivor, err := getBook(tx, id)
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting bookmark while merging bookmarks: %w", err)
+		}
+
+		tags := survivor.Tags
+		descriptions := make([]string, 0)
+		if survivor.Description != nil {
+			descriptions = append(descriptions, *survivor.Description)
+		}
+
+		duplicates := make([]Book, 0)
+		for _, duplicateID := range lo.Uniq(duplicateIDs) {
+			if duplicateID == id {
+				return Book{}, fmt.Errorf("duplicate ID validation failed while merging bookmarks: %w", ErrMergeIntoSelf)
+			}
+
+			if err := validateBookID(tx, duplicateID); err != nil {
+				return Book{}, fmt.Errorf("duplicate ID validation failed while merging bookmarks: %w", err)
+			}
+
+			duplicate, err := getBook(tx, duplicateID)
+			if err != nil {
+				return Book{}, fmt.Errorf("error getting duplicate while merging bookmarks: %w", err)
+			}
+
+			if NormalizeURL(*duplicate.URL) != NormalizeURL(*survivor.URL) {
+				return Book{}, fmt.Errorf("duplicate ID validation failed while merging bookmarks: %w", ErrNotDuplicate)
+			}
+
+			tags = append(tags, duplicate.Tags...)
+			if duplicate.Description != nil {
+				descriptions = append(descriptions, *duplicate.Description)
+			}
+			duplicates = append(duplicates, duplicate)
+		}
+
+		ids := append([]string{id}, bookIDs(duplicates)...)
+		before, err := snapshot(tx, ids)
+		if err != nil {
+			return Book{}, fmt.Errorf("error taking snapshot while merging bookmarks: %w", err)
+		}
+
+		tags = lo.Uniq(tags)
+		if err := validateTags(tags, make([]string, 0)); err != nil {
+			return Book{}, fmt.Errorf("tags validation failed while merging bookmarks: %w", err)
+		}
+
+		merged := strings.Join(lo.Uniq(lo.Compact(descriptions)), "\n\n")
+		if merged != "" && (survivor.Description == nil || merged != *survivor.Description) {
+			if err := validateDescription(null.NullStringFrom(merged)); err != nil {
+				return Book{}, fmt.Errorf("description validation failed while merging bookmarks: %w", err)
+			}
+
+			if err := db.UpdateBook(tx, id, db.UpdateBookArgs{
+				Description: null.NullStringFrom(merged),
+			}); err != nil {
+				return Book{}, fmt.Errorf("error updating description while merging bookmarks: %w", err)
+			}
+		}
+
+		tagsToLink, _ := lo.Difference(tags, survivor.Tags)
+		if err := db.LinkTags(tx, id, tagsToLink); err != nil {
+			return Book{}, fmt.Errorf("error linking tags while merging bookmarks: %w", err)
+		}
+
+		// The duplicates keep their tags in the trash so they're intact if they're restored.
+		if err := db.TrashBooks(tx, bookIDs(duplicates)); err != nil {
+			return Book{}, fmt.Errorf("error removing duplicates while merging bookmarks: %w", err)
+		}
+
+		book, err := getBook(tx, id)
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting bookmark while merging bookmarks: %w", err)
+		}
+
+		description := fmt.Sprintf("Merged %d duplicates into bookmark %q", len(duplicates), book.Name)
+		if err := record(tx, OperationMergeBooks, description, ids, before); err != nil {
+			return Book{}, fmt.Errorf("error recording operation while merging bookmarks: %w", err)
+		}
+
+		return book, nil
+	})
+}
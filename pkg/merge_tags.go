@@ -0,0 +1,158 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// mergeTagsOptions are the optional arguments for MergeTags.
+type mergeTagsOptions struct {
+	DB null.NullString
+}
+
+// DefaultMergeTagsOptions are the default options for MergeTags.
+func DefaultMergeTagsOptions() *mergeTagsOptions {
+	return &mergeTagsOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *mergeTagsOptions) WithDB(db string) *mergeTagsOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// MergeTags replaces a set of tags with a single tag on every bookmark.
+// The tag being merged into doesn't need to exist yet.
+// The merged tags are removed from the bookmarks database.
+func MergeTags(tags []string, into string, options *mergeTagsOptions) (TagCount, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return TagCount{}, fmt.Errorf("error getting config while merging tags: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (TagCount, error) {
+		return mergeTags(tx, tags, into)
+	})
+}
+
+// mergeTags merges tags into another tag using an existing transaction.
+func mergeTags(tx db.Transaction, tags []string, into string) (TagCount, error) {
+	if err := validateTags([]string{into}, nil); err != nil {
+		return TagCount{}, fmt.Errorf("tags validation failed while merging tags: %w", err)
+	}
+
+	// The tags being merged are matched with a GLOB so they can't have any wildcards in them.
+	tags = lo.Uniq(tags)
+	if err := validateTags(tags, nil); err != nil {
+		return TagCount{}, fmt.Errorf("tags validation failed while merging tags: %w", err)
+	}
+
+	tags = lo.Without(tags, into)
+	if len(tags) == 0 {
+		return TagCount{}, ErrNothingToMerge
+	}
+
+	existingTags, err := db.GetTags(tx, db.GetTagsArgs{
+		TagsFilter: tags,
+	})
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error getting tags while merging tags: %w", err)
+	}
+
+	if len(existingTags) != len(tags) {
+		return TagCount{}, ErrTagNotFound
+	}
+
+	ids, err := db.GetTaggedBookIDs(tx, tags)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error getting bookmarks while merging tags: %w", err)
+	}
+
+	before, err := snapshot(tx, ids)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error taking snapshot while merging tags: %w", err)
+	}
+
+	if err := retag(tx, ids, tags, into); err != nil {
+		return TagCount{}, fmt.Errorf("error while merging tags: %w", err)
+	}
+
+	description := fmt.Sprintf("Merged tags %s into %q", strings.Join(tags, ", "), into)
+	if err := record(tx, OperationMergeTags, description, ids, before); err != nil {
+		return TagCount{}, fmt.Errorf("error recording operation while merging tags: %w", err)
+	}
+
+	count, err := getTagCount(tx, into)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error getting tag count while merging tags: %w", err)
+	}
+
+	return count, nil
+}
+
+// retag replaces a set of tags with a target tag on a set of bookmarks.
+// If the target is empty the tags are removed without being replaced.
+// Tags that are no longer applied to any bookmark are removed.
+func retag(tx db.Transaction, IDs []string, tags []string, target string) error {
+	tagged := make([]string, 0)
+
+	if target != "" {
+		existingTags, err := db.GetTags(tx, db.GetTagsArgs{
+			TagsFilter: []string{target},
+		})
+		if err != nil {
+			return fmt.Errorf("error getting tags while retagging: %w", err)
+		}
+
+		if len(existingTags) == 0 {
+			if err := db.AddTags(tx, []string{target}); err != nil {
+				return fmt.Errorf("error adding tag while retagging: %w", err)
+			}
+		}
+
+		tagged, err = db.GetTaggedBookIDs(tx, []string{target})
+		if err != nil {
+			return fmt.Errorf("error getting bookmarks while retagging: %w", err)
+		}
+	}
+
+	for _, ID := range IDs {
+		if err := db.UnlinkTags(tx, ID, tags); err != nil {
+			return fmt.Errorf("error unlinking tags while retagging: %w", err)
+		}
+
+		if target != "" && !lo.Contains(tagged, ID) {
+			if err := db.LinkTags(tx, ID, []string{target}); err != nil {
+				return fmt.Errorf("error linking tag while retagging: %w", err)
+			}
+		}
+	}
+
+	if err := db.CleanOrphanedTags(tx, tags); err != nil {
+		return fmt.Errorf("error cleaning orphaned tags while retagging: %w", err)
+	}
+
+	return nil
+}
+
+// getTagCount gets how many bookmarks a tag is applied to.
+func getTagCount(tx db.Transaction, tag string) (TagCount, error) {
+	counts, err := db.GetTagCounts(tx, db.GetTagsArgs{
+		TagsFilter: []string{tag},
+		Direction:  DirectionAsc,
+	})
+	if err != nil {
+		return TagCount{}, err
+	}
+
+	if len(counts) != 1 {
+		return TagCount{}, ErrTagNotFound
+	}
+
+	return toTagCount(counts[0], 0), nil
+}
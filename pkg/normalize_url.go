@@ -0,0 +1,11 @@
+package armaria
+
+import "github.com/jonathanhope/armaria/internal/normalize"
+
+// NormalizeURL normalizes a URL so that different ways of writing the same page compare equal.
+// The scheme and host are lower cased, default ports and trailing slashes are dropped,
+// tracking params (such as utm_*) are removed, and the remaining params are sorted.
+// URLs that can't be parsed are returned trimmed but otherwise unchanged.
+func NormalizeURL(rawURL string) string {
+	return normalize.URL(rawURL)
+}
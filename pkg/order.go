@@ -7,3 +7,4 @@ type Order = db.Order
 const OrderModified = db.OrderModified
 const OrderName = db.OrderName
 const OrderManual = db.OrderManual
+const OrderRelevance = db.OrderRelevance
@@ -0,0 +1,63 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// redoOptions are the optional arguments for Redo.
+type redoOptions struct {
+	DB null.NullString
+}
+
+// DefaultRedoOptions are the default options for Redo.
+func DefaultRedoOptions() *redoOptions {
+	return &redoOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *redoOptions) WithDB(db string) *redoOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// Redo applies the most recently undone operation in the journal again.
+// Operations can only be redone until a new operation is made.
+func Redo(options *redoOptions) (JournalEntry, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return JournalEntry{}, fmt.Errorf("error getting config while redoing: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (JournalEntry, error) {
+		entries, err := db.GetRedoEntry(tx)
+		if err != nil {
+			return JournalEntry{}, fmt.Errorf("error getting journal entry while redoing: %w", err)
+		}
+
+		if len(entries) == 0 {
+			return JournalEntry{}, ErrNothingToRedo
+		}
+
+		before, after, err := parseJournalEntry(entries[0])
+		if err != nil {
+			return JournalEntry{}, fmt.Errorf("error parsing journal entry while redoing: %w", err)
+		}
+
+		if err := restore(tx, before, after); err != nil {
+			return JournalEntry{}, fmt.Errorf("error restoring bookmarks while redoing: %w", err)
+		}
+
+		if err := db.SetJournalEntryUndone(tx, entries[0].ID, false); err != nil {
+			return JournalEntry{}, fmt.Errorf("error updating journal entry while redoing: %w", err)
+		}
+
+		entry := toJournalEntry(entries[0])
+		entry.Undone = false
+
+		return entry, nil
+	})
+}
@@ -0,0 +1,131 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// refreshFaviconsOptions are the optional arguments for RefreshFavicons.
+type refreshFaviconsOptions struct {
+	DB         null.NullString
+	ParentID   null.NullString
+	Overwrite  bool
+	HTTPClient *http.Client
+}
+
+// DefaultRefreshFaviconsOptions are the default options for RefreshFavicons.
+func DefaultRefreshFaviconsOptions() *refreshFaviconsOptions {
+	return &refreshFaviconsOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *refreshFaviconsOptions) WithDB(db string) *refreshFaviconsOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID only refreshes the icons of bookmarks anywhere inside of a folder.
+func (o *refreshFaviconsOptions) WithParentID(parentID string) *refreshFaviconsOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// WithOverwrite downloads icons again for bookmarks that already have one.
+// Otherwise only bookmarks without an icon are refreshed.
+func (o *refreshFaviconsOptions) WithOverwrite(overwrite bool) *refreshFaviconsOptions {
+	o.Overwrite = overwrite
+	return o
+}
+
+// WithHTTPClient sets the HTTP client used to download the icons.
+func (o *refreshFaviconsOptions) WithHTTPClient(client *http.Client) *refreshFaviconsOptions {
+	o.HTTPClient = client
+	return o
+}
+
+// RefreshFavicons downloads the icon of each bookmark's site and stores it.
+// Bookmarks whose icons can't be found are skipped.
+// The icons that were stored are returned without their content.
+func RefreshFavicons(options *refreshFaviconsOptions) ([]Favicon, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while refreshing favicons: %w", err)
+	}
+
+	books, err := db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return nil, fmt.Errorf("parent ID validation failed while refreshing favicons: %w", err)
+		}
+
+		books, err := getBookmarksIn(tx, options.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting bookmarks while refreshing favicons: %w", err)
+		}
+
+		if options.Overwrite {
+			return books, nil
+		}
+
+		favicons, err := db.GetFavicons(tx, db.GetFaviconsArgs{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting favicons while refreshing favicons: %w", err)
+		}
+
+		fetched := lo.KeyBy(favicons, func(favicon db.FaviconDTO) string {
+			return favicon.BookmarkID
+		})
+
+		return lo.Filter(books, func(book Book, _ int) bool {
+			_, ok := fetched[book.ID]
+			return !ok
+		}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The icons are downloaded before the transaction starts so the database isn't held open on the network.
+	dtos := make([]db.FaviconDTO, 0)
+	for _, book := range books {
+		dto, err := fetchFavicon(options.HTTPClient, book.ID, *book.URL)
+		if err != nil {
+			continue
+		}
+		dtos = append(dtos, dto)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Favicon, error) {
+		favicons := make([]Favicon, 0)
+
+		for _, dto := range dtos {
+			// The bookmark may have been removed while its icon was being downloaded.
+			exists, err := db.BookFolderExists(tx, dto.BookmarkID, false)
+			if err != nil {
+				return nil, fmt.Errorf("error checking if bookmark exists while refreshing favicons: %w", err)
+			}
+			if !exists {
+				continue
+			}
+
+			if err := db.UpsertFavicon(tx, dto); err != nil {
+				return nil, fmt.Errorf("error storing favicon while refreshing favicons: %w", err)
+			}
+
+			favicon := toFavicon(dto)
+			favicon.Content = nil
+			favicons = append(favicons, favicon)
+		}
+
+		// Icons that were replaced or whose bookmarks were removed aren't needed anymore.
+		if err := db.CleanOrphanedFavicons(tx); err != nil {
+			return nil, fmt.Errorf("error cleaning orphaned favicons while refreshing favicons: %w", err)
+		}
+
+		return favicons, nil
+	})
+}
@@ -0,0 +1,141 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// refreshMetadataOptions are the optional arguments for RefreshMetadata.
+type refreshMetadataOptions struct {
+	DB           null.NullString
+	ParentID     null.NullString
+	Overwrite    bool
+	UseCanonical bool
+	HTTPClient   *http.Client
+}
+
+// DefaultRefreshMetadataOptions are the default options for RefreshMetadata.
+func DefaultRefreshMetadataOptions() *refreshMetadataOptions {
+	return &refreshMetadataOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *refreshMetadataOptions) WithDB(db string) *refreshMetadataOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// WithParentID only refreshes the bookmarks anywhere inside of a folder.
+func (o *refreshMetadataOptions) WithParentID(parentID string) *refreshMetadataOptions {
+	o.ParentID = null.NullStringFrom(parentID)
+	return o
+}
+
+// WithOverwrite replaces names and descriptions that have already been set.
+// Otherwise only names that are just the URL and missing descriptions are filled in.
+func (o *refreshMetadataOptions) WithOverwrite(overwrite bool) *refreshMetadataOptions {
+	o.Overwrite = overwrite
+	return o
+}
+
+// WithUseCanonical replaces URLs with the canonical URL of their page if it has one.
+func (o *refreshMetadataOptions) WithUseCanonical(useCanonical bool) *refreshMetadataOptions {
+	o.UseCanonical = useCanonical
+	return o
+}
+
+// WithHTTPClient sets the HTTP client used to fetch metadata.
+func (o *refreshMetadataOptions) WithHTTPClient(client *http.Client) *refreshMetadataOptions {
+	o.HTTPClient = client
+	return o
+}
+
+// RefreshMetadata downloads the page of each bookmark and fills in its name and description.
+// Bookmarks whose pages can't be fetched are left as they are.
+// The bookmarks that were changed are returned.
+func RefreshMetadata(options *refreshMetadataOptions) ([]Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while refreshing metadata: %w", err)
+	}
+
+	books, err := db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return nil, fmt.Errorf("parent ID validation failed while refreshing metadata: %w", err)
+		}
+
+		return getBookmarksIn(tx, options.ParentID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The pages are fetched before the transaction starts so the database isn't held open on the network.
+	updates := make(map[string]db.UpdateBookArgs)
+	for _, book := range books {
+		meta, err := fetchMetadata(options.HTTPClient, *book.URL)
+		if err != nil {
+			continue
+		}
+
+		args := metadataUpdate(book, meta, options.Overwrite, options.UseCanonical)
+		if args.Name.Dirty || args.Description.Dirty || args.URL.Dirty {
+			updates[book.ID] = args
+		}
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) ([]Book, error) {
+		refreshed := make([]Book, 0)
+
+		for _, book := range books {
+			args, ok := updates[book.ID]
+			if !ok {
+				continue
+			}
+
+			if err := db.UpdateBook(tx, book.ID, args); err != nil {
+				return nil, fmt.Errorf("error updating bookmark while refreshing metadata: %w", err)
+			}
+
+			dtos, err := db.GetBooks(tx, db.GetBooksArgs{
+				IDFilter:     book.ID,
+				IncludeBooks: true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error getting bookmarks while refreshing metadata: %w", err)
+			}
+
+			// The bookmark may have been removed while its page was being fetched.
+			refreshed = append(refreshed, toBooks(dtos)...)
+		}
+
+		return refreshed, nil
+	})
+}
+
+// metadataUpdate works out which fields of a bookmark should be updated from the metadata of its page.
+func metadataUpdate(book Book, meta Metadata, overwrite bool, useCanonical bool) db.UpdateBookArgs {
+	var args db.UpdateBookArgs
+
+	url := lo.FromPtr(book.URL)
+	description := lo.FromPtr(book.Description)
+
+	if meta.Title != "" && meta.Title != book.Name && (overwrite || book.Name == url) {
+		args.Name = null.NullStringFrom(meta.Title)
+	}
+
+	if meta.Description != "" && meta.Description != description && (overwrite || book.Description == nil) {
+		args.Description = null.NullStringFrom(meta.Description)
+	}
+
+	if useCanonical && meta.Canonical != "" && meta.Canonical != url && validateURL(null.NullStringFrom(meta.Canonical)) == nil {
+		args.URL = null.NullStringFrom(meta.Canonical)
+	}
+
+	return args
+}
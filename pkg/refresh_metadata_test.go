@@ -0,0 +1,83 @@
+package armaria
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+func TestMetadataUpdate(t *testing.T) {
+	type test struct {
+		name         string
+		book         Book
+		meta         Metadata
+		overwrite    bool
+		useCanonical bool
+		want         db.UpdateBookArgs
+	}
+
+	url := "https://jho.pe"
+	description := "A blog"
+
+	tests := []test{
+		{
+			name: "fills in default name and missing description",
+			book: Book{Name: url, URL: &url},
+			meta: Metadata{Title: "Flat Field", Description: "About code"},
+			want: db.UpdateBookArgs{
+				Name:        null.NullStringFrom("Flat Field"),
+				Description: null.NullStringFrom("About code"),
+			},
+		},
+		{
+			name: "keeps names and descriptions that were set",
+			book: Book{Name: "Blog", URL: &url, Description: &description},
+			meta: Metadata{Title: "Flat Field", Description: "About code"},
+			want: db.UpdateBookArgs{},
+		},
+		{
+			name:      "overwrites names and descriptions that were set",
+			book:      Book{Name: "Blog", URL: &url, Description: &description},
+			meta:      Metadata{Title: "Flat Field", Description: "About code"},
+			overwrite: true,
+			want: db.UpdateBookArgs{
+				Name:        null.NullStringFrom("Flat Field"),
+				Description: null.NullStringFrom("About code"),
+			},
+		},
+		{
+			name: "ignores missing metadata",
+			book: Book{Name: url, URL: &url},
+			meta: Metadata{},
+			want: db.UpdateBookArgs{},
+		},
+		{
+			name: "ignores canonical URL unless asked",
+			book: Book{Name: "Blog", URL: &url},
+			meta: Metadata{Canonical: "https://jho.pe/"},
+			want: db.UpdateBookArgs{},
+		},
+		{
+			name:         "uses canonical URL",
+			book:         Book{Name: "Blog", URL: &url},
+			meta:         Metadata{Canonical: "https://jho.pe/"},
+			useCanonical: true,
+			want: db.UpdateBookArgs{
+				URL: null.NullStringFrom("https://jho.pe/"),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := metadataUpdate(tc.book, tc.meta, tc.overwrite, tc.useCanonical)
+
+			diff := cmp.Diff(got, tc.want)
+			if diff != "" {
+				t.Errorf("Expected and actual update different:\n%s", diff)
+			}
+		})
+	}
+}
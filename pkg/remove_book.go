@@ -24,7 +24,8 @@ func (o *removeBookOptions) WithDB(db string) *removeBookOptions {
 	return o
 }
 
-// RemoveBook removes a bookmark from the bookmarks database.
+// RemoveBook moves a bookmark to the trash.
+// The bookmark keeps its tags so it can be restored with RestoreBook until the trash is emptied.
 func RemoveBook(id string, options *removeBookOptions) (err error) {
 	config, err := GetConfig()
 	if err != nil && !errors.Is(err, ErrConfigMissing) {
@@ -32,31 +33,38 @@ func RemoveBook(id string, options *removeBookOptions) (err error) {
 	}
 
 	return db.ExecWithTransaction(options.DB, config.DB, func(tx db.Transaction) error {
-		if err := validateBookID(tx, id); err != nil {
-			return fmt.Errorf("bookmark ID validation failed while removing bookmark: %w", err)
-		}
-
-		books, err := db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:     id,
-			IncludeBooks: true,
-		})
-		if err != nil {
-			return fmt.Errorf("error getting bookmarks while removing bookmark: %w", err)
-		}
-		book := toBook(books[0])
-
-		if err = db.UnlinkTags(tx, book.ID, book.Tags); err != nil {
-			return fmt.Errorf("error unlinking tags while removing bookmark: %w", err)
-		}
-
-		if err = db.RemoveBook(tx, book.ID); err != nil {
-			return fmt.Errorf("error while removing bookmark: %w", err)
-		}
-
-		if err = db.CleanOrphanedTags(tx, book.Tags); err != nil {
-			return fmt.Errorf("error cleaning orphaned tags while removing bookmark: %w", err)
-		}
-
-		return nil
+		return removeBook(tx, id)
 	})
 }
+
+// removeBook moves a bookmark to the trash using an existing transaction.
+func removeBook(tx db.Transaction, id string) (err error) {
+	if err := validateBookID(tx, id); err != nil {
+		return fmt.Errorf("bookmark ID validation failed while removing bookmark: %w", err)
+	}
+
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:     id,
+		IncludeBooks: true,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting bookmarks while removing bookmark: %w", err)
+	}
+	book := toBook(books[0])
+
+	before, err := snapshot(tx, []string{book.ID})
+	if err != nil {
+		return fmt.Errorf("error taking snapshot while removing bookmark: %w", err)
+	}
+
+	if err = db.TrashBooks(tx, []string{book.ID}); err != nil {
+		return fmt.Errorf("error while removing bookmark: %w", err)
+	}
+
+	description := fmt.Sprintf("Removed bookmark %q", book.Name)
+	if err = record(tx, OperationRemoveBook, description, []string{book.ID}, before); err != nil {
+		return fmt.Errorf("error recording operation while removing bookmark: %w", err)
+	}
+
+	return nil
+}
@@ -25,7 +25,8 @@ func (o *removeFolderOptions) WithDB(db string) *removeFolderOptions {
 	return o
 }
 
-// RemoveFolder removes a folder from the bookmarks database.
+// RemoveFolder moves a folder and everything in it to the trash.
+// It can be restored with RestoreBook until the trash is emptied.
 func RemoveFolder(id string, options *removeFolderOptions) error {
 	config, err := GetConfig()
 	if err != nil && !errors.Is(err, ErrConfigMissing) {
@@ -33,35 +34,43 @@ func RemoveFolder(id string, options *removeFolderOptions) error {
 	}
 
 	return db.ExecWithTransaction(options.DB, config.DB, func(tx db.Transaction) error {
-		if err := validateParentID(tx, null.NullStringFrom(id)); err != nil {
-			return fmt.Errorf("parent ID validation failed while removing folder: %w", err)
-		}
-
-		bookOrFolders, err := db.GetParentAndChildren(tx, id)
-		if err != nil {
-			return fmt.Errorf("error getting folder and children while removing folder: %w", err)
-		}
+		return removeFolder(tx, id)
+	})
+}
 
-		for _, bookOrFolder := range lo.Reverse(toBooks(bookOrFolders)) {
-			if !bookOrFolder.IsFolder {
-				if err = db.UnlinkTags(tx, bookOrFolder.ID, bookOrFolder.Tags); err != nil {
-					return fmt.Errorf("error unlinking tags while removing folder: %w", err)
-				}
+// removeFolder moves a folder and everything in it to the trash using an existing transaction.
+func removeFolder(tx db.Transaction, id string) error {
+	if err := validateParentID(tx, null.NullStringFrom(id)); err != nil {
+		return fmt.Errorf("parent ID validation failed while removing folder: %w", err)
+	}
 
-				if err = db.RemoveBook(tx, bookOrFolder.ID); err != nil {
-					return fmt.Errorf("error remmoving bookmark while removing folder: %w", err)
-				}
+	bookOrFolders, err := db.GetParentAndChildren(tx, id)
+	if err != nil {
+		return fmt.Errorf("error getting folder and children while removing folder: %w", err)
+	}
 
-				if err = db.CleanOrphanedTags(tx, bookOrFolder.Tags); err != nil {
-					return fmt.Errorf("error cleaning orphaned tags while removing folder: %w", err)
-				}
-			} else {
-				if err = db.RemoveFolder(tx, bookOrFolder.ID); err != nil {
-					return fmt.Errorf("error while removing folder: %w", err)
-				}
-			}
-		}
+	// Anything that was already in the trash stays there on its own.
+	bookOrFolders = lo.Filter(bookOrFolders, func(bookOrFolder db.BookDTO, _ int) bool {
+		return !bookOrFolder.DeletedAt.Valid
+	})
 
-		return nil
+	ids := lo.Map(bookOrFolders, func(bookOrFolder db.BookDTO, _ int) string {
+		return bookOrFolder.ID
 	})
+
+	before, err := snapshot(tx, ids)
+	if err != nil {
+		return fmt.Errorf("error taking snapshot while removing folder: %w", err)
+	}
+
+	if err = db.TrashBooks(tx, ids); err != nil {
+		return fmt.Errorf("error while removing folder: %w", err)
+	}
+
+	description := fmt.Sprintf("Removed folder %q", bookOrFolders[0].Name)
+	if err = record(tx, OperationRemoveFolder, description, ids, before); err != nil {
+		return fmt.Errorf("error recording operation while removing folder: %w", err)
+	}
+
+	return nil
 }
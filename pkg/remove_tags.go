@@ -3,6 +3,7 @@ package armaria
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jonathanhope/armaria/internal/db"
 	"github.com/jonathanhope/armaria/internal/null"
@@ -33,42 +34,59 @@ func RemoveTags(id string, tags []string, options *removeTagsOptions) (Book, err
 	}
 
 	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
-		books, err := db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:     id,
-			IncludeBooks: true,
-		})
-		if err != nil {
-			return Book{}, fmt.Errorf("error getting bookmarks while removing tags: %w", err)
-		}
+		return removeTags(tx, id, tags)
+	})
+}
 
-		if len(books) != 1 || books[0].IsFolder {
-			return Book{}, ErrBookNotFound
-		}
+// removeTags removes tags from a bookmark using an existing transaction.
+func removeTags(tx db.Transaction, id string, tags []string) (Book, error) {
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:     id,
+		IncludeBooks: true,
+	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting bookmarks while removing tags: %w", err)
+	}
 
-		book := toBook(books[0])
+	if len(books) != 1 || books[0].IsFolder {
+		return Book{}, ErrBookNotFound
+	}
 
-		for _, tag := range tags {
-			if !lo.Contains(book.Tags, tag) {
-				return Book{}, ErrTagNotFound
-			}
-		}
+	book := toBook(books[0])
 
-		if err = db.UnlinkTags(tx, book.ID, tags); err != nil {
-			return Book{}, fmt.Errorf("error unlinking tags while removing tags: %w", err)
-		}
+	before, err := snapshot(tx, []string{id})
+	if err != nil {
+		return Book{}, fmt.Errorf("error taking snapshot while removing tags: %w", err)
+	}
 
-		if err = db.CleanOrphanedTags(tx, tags); err != nil {
-			return Book{}, fmt.Errorf("error cleaning orphaned tags while removing tags: %w", err)
+	for _, tag := range tags {
+		if !lo.Contains(book.Tags, tag) {
+			return Book{}, ErrTagNotFound
 		}
+	}
 
-		books, err = db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:     id,
-			IncludeBooks: true,
-		})
-		if err != nil {
-			return Book{}, fmt.Errorf("error getting bookmarks while removing tags: %w", err)
-		}
+	if err = db.UnlinkTags(tx, book.ID, tags); err != nil {
+		return Book{}, fmt.Errorf("error unlinking tags while removing tags: %w", err)
+	}
+
+	if err = db.CleanOrphanedTags(tx, tags); err != nil {
+		return Book{}, fmt.Errorf("error cleaning orphaned tags while removing tags: %w", err)
+	}
 
-		return toBook(books[0]), nil
+	books, err = db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:     id,
+		IncludeBooks: true,
 	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting bookmarks while removing tags: %w", err)
+	}
+
+	book = toBook(books[0])
+
+	description := fmt.Sprintf("Removed tags %s from %q", strings.Join(tags, ", "), book.Name)
+	if err := record(tx, OperationRemoveTags, description, []string{id}, before); err != nil {
+		return Book{}, fmt.Errorf("error recording operation while removing tags: %w", err)
+	}
+
+	return book, nil
 }
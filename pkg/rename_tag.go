@@ -0,0 +1,121 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
+)
+
+// renameTagOptions are the optional arguments for RenameTag.
+type renameTagOptions struct {
+	DB null.NullString
+}
+
+// DefaultRenameTagOptions are the default options for RenameTag.
+func DefaultRenameTagOptions() *renameTagOptions {
+	return &renameTagOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *renameTagOptions) WithDB(db string) *renameTagOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// RenameTag renames a tag on every bookmark it's applied to.
+// Renaming a namespace such as lang renames every tag underneath it too, so lang/go becomes language/go.
+// The new names can't already be in use; use MergeTags to combine two existing tags.
+func RenameTag(from string, to string, options *renameTagOptions) (TagCount, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return TagCount{}, fmt.Errorf("error getting config while renaming tag: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (TagCount, error) {
+		return renameTag(tx, from, to)
+	})
+}
+
+// renameTag renames a tag and its descendants using an existing transaction.
+func renameTag(tx db.Transaction, from string, to string) (TagCount, error) {
+	// The tag being renamed is matched with a GLOB so it can't have any wildcards in it.
+	if err := validateTags([]string{from}, nil); err != nil {
+		return TagCount{}, fmt.Errorf("tags validation failed while renaming tag: %w", err)
+	}
+
+	if err := validateTags([]string{to}, nil); err != nil {
+		return TagCount{}, fmt.Errorf("tags validation failed while renaming tag: %w", err)
+	}
+
+	fromTags, err := db.GetTags(tx, db.GetTagsArgs{
+		TagsFilter: []string{from, from + TagDescendants},
+	})
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error getting tags while renaming tag: %w", err)
+	}
+
+	if len(fromTags) == 0 {
+		return TagCount{}, ErrTagNotFound
+	}
+
+	toTags := lo.Map(fromTags, func(tag string, _ int) string {
+		return to + strings.TrimPrefix(tag, from)
+	})
+
+	if err := validateTags(toTags, nil); err != nil {
+		return TagCount{}, fmt.Errorf("tags validation failed while renaming tag: %w", err)
+	}
+
+	existingTags, err := db.GetTags(tx, db.GetTagsArgs{
+		TagsFilter: toTags,
+	})
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error getting tags while renaming tag: %w", err)
+	}
+
+	if len(existingTags) > 0 {
+		return TagCount{}, ErrTagExists
+	}
+
+	ids, err := db.GetTaggedBookIDs(tx, fromTags)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error getting bookmarks while renaming tag: %w", err)
+	}
+
+	before, err := snapshot(tx, ids)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error taking snapshot while renaming tag: %w", err)
+	}
+
+	for i, tag := range fromTags {
+		tagged, err := db.GetTaggedBookIDs(tx, []string{tag})
+		if err != nil {
+			return TagCount{}, fmt.Errorf("error getting bookmarks while renaming tag: %w", err)
+		}
+
+		if err := retag(tx, tagged, []string{tag}, toTags[i]); err != nil {
+			return TagCount{}, fmt.Errorf("error while renaming tag: %w", err)
+		}
+	}
+
+	description := fmt.Sprintf("Renamed tag %q to %q", from, to)
+	if err := record(tx, OperationRenameTag, description, ids, before); err != nil {
+		return TagCount{}, fmt.Errorf("error recording operation while renaming tag: %w", err)
+	}
+
+	// Renaming a namespace that isn't a tag itself leaves only the tags underneath it.
+	if !lo.Contains(fromTags, from) {
+		return TagCount{Tag: to}, nil
+	}
+
+	count, err := getTagCount(tx, to)
+	if err != nil {
+		return TagCount{}, fmt.Errorf("error getting tag count while renaming tag: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,145 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/jonathanhope/armaria/internal/order"
+	"github.com/samber/lo"
+)
+
+// restoreBookOptions are the optional arguments for RestoreBook.
+type restoreBookOptions struct {
+	DB null.NullString
+}
+
+// DefaultRestoreBookOptions are the default options for RestoreBook.
+func DefaultRestoreBookOptions() *restoreBookOptions {
+	return &restoreBookOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *restoreBookOptions) WithDB(db string) *restoreBookOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// RestoreBook takes a bookmark or folder out of the trash.
+// It goes back to its old parent and order.
+// If its old parent is gone or still in the trash it's placed at the end of the top level instead.
+// Restoring a folder also restores everything that was moved to the trash along with it.
+func RestoreBook(id string, options *restoreBookOptions) (Book, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return Book{}, fmt.Errorf("error getting config while restoring bookmark: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
+		books, err := db.GetBooks(tx, db.GetBooksArgs{
+			IDFilter:       id,
+			IncludeBooks:   true,
+			IncludeFolders: true,
+			DeletedOnly:    true,
+		})
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting bookmarks while restoring bookmark: %w", err)
+		}
+
+		if len(books) == 0 {
+			return Book{}, ErrNotInTrash
+		}
+
+		book := books[0]
+		ids := []string{book.ID}
+
+		if book.IsFolder {
+			children, err := db.GetParentAndChildren(tx, book.ID)
+			if err != nil {
+				return Book{}, fmt.Errorf("error getting folder and children while restoring bookmark: %w", err)
+			}
+
+			// Children that were moved to the trash before the folder stay there.
+			children = lo.Filter(children, func(child db.BookDTO, _ int) bool {
+				return child.DeletedBatch == book.DeletedBatch
+			})
+
+			ids = lo.Uniq(append(ids, lo.Map(children, func(child db.BookDTO, _ int) string {
+				return child.ID
+			})...))
+		}
+
+		before, err := snapshot(tx, ids)
+		if err != nil {
+			return Book{}, fmt.Errorf("error taking snapshot while restoring bookmark: %w", err)
+		}
+
+		if err := db.RestoreBooks(tx, ids); err != nil {
+			return Book{}, fmt.Errorf("error while restoring bookmark: %w", err)
+		}
+
+		if book.ParentID.Valid {
+			exists, err := db.BookFolderExists(tx, book.ParentID.String, true)
+			if err != nil {
+				return Book{}, fmt.Errorf("error checking parent while restoring bookmark: %w", err)
+			}
+
+			if !exists {
+				if err := moveToTopLevel(tx, book); err != nil {
+					return Book{}, fmt.Errorf("error moving to top level while restoring bookmark: %w", err)
+				}
+			}
+		}
+
+		books, err = db.GetBooks(tx, db.GetBooksArgs{
+			IDFilter:       id,
+			IncludeBooks:   true,
+			IncludeFolders: true,
+		})
+		if err != nil {
+			return Book{}, fmt.Errorf("error getting bookmarks while restoring bookmark: %w", err)
+		}
+
+		restored := toBook(books[0])
+
+		description := fmt.Sprintf("Restored %q", restored.Name)
+		if err := record(tx, OperationRestoreBook, description, ids, before); err != nil {
+			return Book{}, fmt.Errorf("error recording operation while restoring bookmark: %w", err)
+		}
+
+		return restored, nil
+	})
+}
+
+// moveToTopLevel moves a bookmark or folder to the end of the top level.
+func moveToTopLevel(tx db.Transaction, book db.BookDTO) error {
+	topLevel := null.NullStringFromPtr(nil)
+
+	previous, err := db.MaxOrder(tx, topLevel)
+	if err != nil {
+		return fmt.Errorf("error getting max order while moving to top level: %w", err)
+	}
+
+	var current string
+	if previous == "" {
+		current, err = order.Initial()
+	} else {
+		current, err = order.End(previous)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting current order while moving to top level: %w", err)
+	}
+
+	if book.IsFolder {
+		return db.UpdateFolder(tx, book.ID, db.UpdateFolderArgs{
+			ParentID: topLevel,
+			Order:    current,
+		})
+	}
+
+	return db.UpdateBook(tx, book.ID, db.UpdateBookArgs{
+		ParentID: topLevel,
+		Order:    current,
+	})
+}
@@ -0,0 +1,600 @@
+package armaria
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/nullism/bqb"
+)
+
+// Search queries support the following syntax:
+// - words are matched anywhere in the name, URL, or description
+// - "quoted phrases" are matched as a whole
+// - word* and "phrase"* are matched as prefixes
+// - tag:go matches a tag, tag:lang/* matches every tag underneath a namespace, and tag:-go excludes it
+// - folder:"Work/Docs" matches anything inside of a folder
+// - url:, name:, and description: match text in a single field
+// - modified:>2024-01-01 matches the day a bookmark was modified; >, >=, <, <=, and = are supported
+// - created:<2024-01-01 matches the day a bookmark was created the same way
+// - AND, OR, NOT, -, and parentheses combine terms; terms next to each other are ANDed
+
+// QueryError is returned when a search query can't be parsed.
+// It wraps ErrInvalidQuery.
+type QueryError struct {
+	Position int    // 1-based position in the query where the problem is
+	Message  string // description of the problem
+}
+
+// Error describes the problem and where it is.
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// Unwrap allows errors.Is to match ErrInvalidQuery.
+func (e *QueryError) Unwrap() error {
+	return ErrInvalidQuery
+}
+
+// queryFields are the fields that can be searched on.
+var queryFields = []string{"tag", "folder", "url", "name", "description", "modified", "created"}
+
+// tokenKind is the kind of a token in a search query.
+type tokenKind int
+
+const (
+	tokenText  tokenKind = iota // word or phrase
+	tokenField                  // field:value filter
+	tokenAnd                    // AND
+	tokenOr                     // OR
+	tokenNot                    // NOT or -
+	tokenOpen                   // (
+	tokenClose                  // )
+)
+
+// queryToken is a token in a search query.
+type queryToken struct {
+	kind          tokenKind
+	position      int    // 1-based position of the token in the query
+	field         string // name of the field for field tokens
+	value         string // text of a word, phrase, or field value
+	valuePosition int    // 1-based position of the value of a field token
+	prefix        bool   // true if a word or phrase should match as a prefix
+}
+
+// describe describes a token for an error message.
+func (t queryToken) describe() string {
+	switch t.kind {
+	case tokenAnd:
+		return "AND"
+	case tokenOr:
+		return "OR"
+	case tokenNot:
+		return "NOT"
+	case tokenOpen:
+		return `"("`
+	case tokenClose:
+		return `")"`
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+// queryNode is a node in a parsed search query.
+type queryNode interface{}
+
+// queryAnd matches when both sides match.
+type queryAnd struct {
+	Left  queryNode
+	Right queryNode
+}
+
+// queryOr matches when either side matches.
+type queryOr struct {
+	Left  queryNode
+	Right queryNode
+}
+
+// queryNot matches when its operand doesn't.
+type queryNot struct {
+	Operand queryNode
+}
+
+// queryText is a word or phrase matched against the full-text index.
+type queryText struct {
+	Value  string
+	Prefix bool
+}
+
+// queryTag matches bookmarks with a tag.
+type queryTag struct {
+	Tag string
+}
+
+// queryFolder matches anything inside of a folder.
+type queryFolder struct {
+	Path []string
+}
+
+// queryContains matches text in a single field.
+type queryContains struct {
+	Field string
+	Value string
+}
+
+// queryModified matches the day a bookmark/folder was modified.
+type queryModified struct {
+	Comparison db.Comparison
+	Date       string
+}
+
+// queryCreated matches the day a bookmark/folder was created.
+type queryCreated struct {
+	Comparison db.Comparison
+	Date       string
+}
+
+// searchQuery is a search query compiled into arguments for db.GetBooks.
+type searchQuery struct {
+	filter *bqb.Query      // predicate results must satisfy
+	match  null.NullString // full-text expression results are ranked on
+}
+
+// compileQuery parses a search query and compiles it into arguments for db.GetBooks.
+func compileQuery(query string) (searchQuery, error) {
+	node, err := parseQuery(query)
+	if err != nil {
+		return searchQuery{}, err
+	}
+
+	filter, err := compileNode(node)
+	if err != nil {
+		return searchQuery{}, err
+	}
+
+	compiled := searchQuery{filter: filter}
+
+	// Only terms that are being searched for contribute to the ranking.
+	terms := rankedTerms(node, false)
+	if len(terms) > 0 {
+		compiled.match = null.NullStringFrom(strings.Join(terms, " OR "))
+	}
+
+	return compiled, nil
+}
+
+// parseQuery parses a search query.
+func parseQuery(query string) (queryNode, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := queryParser{tokens: tokens, end: len([]rune(query)) + 1}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token, ok := p.peek(); ok {
+		return nil, &QueryError{Position: token.position, Message: fmt.Sprintf("unexpected %s", token.describe())}
+	}
+
+	return node, nil
+}
+
+// lexQuery breaks a search query into tokens.
+func lexQuery(query string) ([]queryToken, error) {
+	runes := []rune(query)
+	tokens := make([]queryToken, 0)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		position := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenOpen, position: position})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenClose, position: position})
+			i++
+
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && runes[i+1] != ')':
+			tokens = append(tokens, queryToken{kind: tokenNot, position: position})
+			i++
+
+		case r == '"':
+			value, prefix, end, err := lexPhrase(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+
+			if strings.TrimSpace(value) != "" {
+				tokens = append(tokens, queryToken{kind: tokenText, position: position, value: value, prefix: prefix})
+			}
+
+		default:
+			end := i
+			for end < len(runes) && !isQueryDelimiter(runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			i = end
+
+			if word == "AND" {
+				tokens = append(tokens, queryToken{kind: tokenAnd, position: position})
+				continue
+			}
+			if word == "OR" {
+				tokens = append(tokens, queryToken{kind: tokenOr, position: position})
+				continue
+			}
+			if word == "NOT" {
+				tokens = append(tokens, queryToken{kind: tokenNot, position: position})
+				continue
+			}
+
+			field, value, found := strings.Cut(word, ":")
+			if found && isQueryField(field) {
+				token := queryToken{
+					kind:          tokenField,
+					position:      position,
+					field:         field,
+					value:         value,
+					valuePosition: position + len([]rune(field)) + 1,
+				}
+
+				if value == "" && i < len(runes) && runes[i] == '"' {
+					phrase, _, end, err := lexPhrase(runes, i)
+					if err != nil {
+						return nil, err
+					}
+					i = end
+					token.value = phrase
+				}
+
+				if strings.TrimSpace(token.value) == "" {
+					return nil, &QueryError{Position: position, Message: fmt.Sprintf("missing value for %s", field)}
+				}
+
+				tokens = append(tokens, token)
+				continue
+			}
+
+			token := queryToken{kind: tokenText, position: position, value: word}
+			if len(word) > 1 && strings.HasSuffix(word, "*") {
+				token.value = strings.TrimSuffix(word, "*")
+				token.prefix = true
+			}
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens, nil
+}
+
+// lexPhrase reads a quoted phrase starting at the opening quote.
+// The phrase, whether it's a prefix, and the index after it are returned.
+func lexPhrase(runes []rune, start int) (string, bool, int, error) {
+	end := start + 1
+	for end < len(runes) && runes[end] != '"' {
+		end++
+	}
+	if end == len(runes) {
+		return "", false, 0, &QueryError{Position: start + 1, Message: "unterminated phrase"}
+	}
+
+	phrase := string(runes[start+1 : end])
+	end++
+
+	if end < len(runes) && runes[end] == '*' {
+		return phrase, true, end + 1, nil
+	}
+
+	return phrase, false, end, nil
+}
+
+// isQueryDelimiter returns true if a character ends a word.
+func isQueryDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"'
+}
+
+// isQueryField returns true if a name is a field that can be searched on.
+func isQueryField(name string) bool {
+	for _, field := range queryFields {
+		if name == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// queryParser is a recursive descent parser for search queries.
+// OR has the lowest precedence, then AND, then NOT.
+type queryParser struct {
+	tokens []queryToken
+	next   int // index of the next token
+	end    int // position just past the end of the query
+}
+
+// peek returns the next token without consuming it.
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.next >= len(p.tokens) {
+		return queryToken{}, false
+	}
+
+	return p.tokens[p.next], true
+}
+
+// parseOr parses terms separated by OR.
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind != tokenOr {
+			return left, nil
+		}
+		p.next++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = queryOr{Left: left, Right: right}
+	}
+}
+
+// parseAnd parses terms separated by AND or next to each other.
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		token, ok := p.peek()
+		if !ok || token.kind == tokenOr || token.kind == tokenClose {
+			return left, nil
+		}
+		if token.kind == tokenAnd {
+			p.next++
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = queryAnd{Left: left, Right: right}
+	}
+}
+
+// parseUnary parses a term that may be negated.
+func (p *queryParser) parseUnary() (queryNode, error) {
+	token, ok := p.peek()
+	if ok && token.kind == tokenNot {
+		p.next++
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return queryNot{Operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a single term or a parenthesized group.
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	token, ok := p.peek()
+	if !ok {
+		return nil, &QueryError{Position: p.end, Message: "unexpected end of query"}
+	}
+
+	switch token.kind {
+	case tokenOpen:
+		p.next++
+
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		closing, ok := p.peek()
+		if !ok || closing.kind != tokenClose {
+			return nil, &QueryError{Position: token.position, Message: `missing ")"`}
+		}
+		p.next++
+
+		return node, nil
+
+	case tokenText:
+		p.next++
+		return queryText{Value: token.value, Prefix: token.prefix}, nil
+
+	case tokenField:
+		p.next++
+		return parseField(token)
+
+	default:
+		return nil, &QueryError{Position: token.position, Message: fmt.Sprintf("unexpected %s", token.describe())}
+	}
+}
+
+// parseField parses the value of a field:value filter.
+func parseField(token queryToken) (queryNode, error) {
+	switch token.field {
+	case "tag":
+		if tag, negated := strings.CutPrefix(token.value, "-"); negated {
+			if tag == "" {
+				return nil, &QueryError{Position: token.position, Message: "missing value for tag"}
+			}
+			return queryNot{Operand: queryTag{Tag: tag}}, nil
+		}
+
+		return queryTag{Tag: token.value}, nil
+
+	case "folder":
+		path := make([]string, 0)
+		for _, name := range strings.Split(token.value, "/") {
+			if name = strings.TrimSpace(name); name != "" {
+				path = append(path, name)
+			}
+		}
+		if len(path) == 0 {
+			return nil, &QueryError{Position: token.valuePosition, Message: "missing folder name"}
+		}
+
+		return queryFolder{Path: path}, nil
+
+	case "modified":
+		comparison, date, err := parseDateComparison(token)
+		if err != nil {
+			return nil, err
+		}
+
+		return queryModified{Comparison: comparison, Date: date}, nil
+
+	case "created":
+		comparison, date, err := parseDateComparison(token)
+		if err != nil {
+			return nil, err
+		}
+
+		return queryCreated{Comparison: comparison, Date: date}, nil
+
+	default:
+		return queryContains{Field: token.field, Value: token.value}, nil
+	}
+}
+
+// parseDateComparison parses the value of a date field such as modified:>2024-01-01.
+func parseDateComparison(token queryToken) (db.Comparison, string, error) {
+	comparison := db.ComparisonEqual
+	date := token.value
+	for _, candidate := range []db.Comparison{db.ComparisonGreaterOrEqual, db.ComparisonLessOrEqual, db.ComparisonGreater, db.ComparisonLess, db.ComparisonEqual} {
+		if rest, found := strings.CutPrefix(date, string(candidate)); found {
+			comparison = candidate
+			date = rest
+			break
+		}
+	}
+
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return "", "", &QueryError{Position: token.valuePosition, Message: fmt.Sprintf("invalid date %q; expected YYYY-MM-DD", date)}
+	}
+
+	return comparison, date, nil
+}
+
+// compileNode compiles a parsed search query into a filter.
+func compileNode(node queryNode) (*bqb.Query, error) {
+	switch n := node.(type) {
+	case queryAnd:
+		left, right, err := compileOperands(n.Left, n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return db.AndFilter(left, right), nil
+	case queryOr:
+		left, right, err := compileOperands(n.Left, n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return db.OrFilter(left, right), nil
+	case queryNot:
+		operand, err := compileNode(n.Operand)
+		if err != nil {
+			return nil, err
+		}
+		return db.NotFilter(operand), nil
+	case queryText:
+		// The trigram full-text index can't match short terms so they are matched with a contains filter instead.
+		if len([]rune(n.Value)) < minQueryLength {
+			return db.TextFilter(n.Value), nil
+		}
+		return db.MatchFilter(matchTerm(n)), nil
+	case queryTag:
+		return db.TagFilter(n.Tag), nil
+	case queryFolder:
+		return db.FolderFilter(n.Path), nil
+	case queryModified:
+		return db.ModifiedFilter(n.Comparison, n.Date), nil
+	case queryCreated:
+		return db.CreatedFilter(n.Comparison, n.Date), nil
+	case queryContains:
+		switch n.Field {
+		case "url":
+			return db.URLFilter(n.Value), nil
+		case "name":
+			return db.NameFilter(n.Value), nil
+		default:
+			return db.DescriptionFilter(n.Value), nil
+		}
+	default:
+		return nil, &QueryError{Position: 1, Message: fmt.Sprintf("unsupported query node %T", node)}
+	}
+}
+
+// compileOperands compiles both sides of a binary query node.
+func compileOperands(left queryNode, right queryNode) (*bqb.Query, *bqb.Query, error) {
+	compiledLeft, err := compileNode(left)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	compiledRight, err := compileNode(right)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return compiledLeft, compiledRight, nil
+}
+
+// rankedTerms collects the full-text terms that aren't negated.
+func rankedTerms(node queryNode, negated bool) []string {
+	switch n := node.(type) {
+	case queryAnd:
+		return append(rankedTerms(n.Left, negated), rankedTerms(n.Right, negated)...)
+	case queryOr:
+		return append(rankedTerms(n.Left, negated), rankedTerms(n.Right, negated)...)
+	case queryNot:
+		return rankedTerms(n.Operand, !negated)
+	case queryText:
+		if negated || len([]rune(n.Value)) < minQueryLength {
+			return nil
+		}
+		return []string{matchTerm(n)}
+	default:
+		return nil
+	}
+}
+
+// matchTerm converts a word or phrase into an FTS5 MATCH expression.
+// Terms are quoted so punctuation (which is common in URLs) is matched literally.
+func matchTerm(text queryText) string {
+	term := fmt.Sprintf(`"%s"`, strings.ReplaceAll(text.Value, `"`, `""`))
+	if text.Prefix {
+		term += "*"
+	}
+
+	return term
+}
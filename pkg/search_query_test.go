@@ -0,0 +1,166 @@
+package armaria
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+func TestParseQuery(t *testing.T) {
+	type test struct {
+		input string
+		want  queryNode
+	}
+
+	tests := []test{
+		{input: "blog", want: queryText{Value: "blog"}},
+		{input: "jho.pe", want: queryText{Value: "jho.pe"}},
+		{input: "https://jho.pe", want: queryText{Value: "https://jho.pe"}},
+		{input: `"flat field"`, want: queryText{Value: "flat field"}},
+		{input: "blo*", want: queryText{Value: "blo", Prefix: true}},
+		{input: `"flat fi"*`, want: queryText{Value: "flat fi", Prefix: true}},
+		{
+			input: "flat  field",
+			want:  queryAnd{Left: queryText{Value: "flat"}, Right: queryText{Value: "field"}},
+		},
+		{
+			input: "flat AND field",
+			want:  queryAnd{Left: queryText{Value: "flat"}, Right: queryText{Value: "field"}},
+		},
+		{
+			input: "flat OR field",
+			want:  queryOr{Left: queryText{Value: "flat"}, Right: queryText{Value: "field"}},
+		},
+		{
+			input: "flat field OR blog",
+			want: queryOr{
+				Left:  queryAnd{Left: queryText{Value: "flat"}, Right: queryText{Value: "field"}},
+				Right: queryText{Value: "blog"},
+			},
+		},
+		{
+			input: "flat (field OR blog)",
+			want: queryAnd{
+				Left:  queryText{Value: "flat"},
+				Right: queryOr{Left: queryText{Value: "field"}, Right: queryText{Value: "blog"}},
+			},
+		},
+		{input: "NOT blog", want: queryNot{Operand: queryText{Value: "blog"}}},
+		{input: "-blog", want: queryNot{Operand: queryText{Value: "blog"}}},
+		{input: `-"flat field"`, want: queryNot{Operand: queryText{Value: "flat field"}}},
+		{input: "e-mail", want: queryText{Value: "e-mail"}},
+		{input: "blog or not", want: queryAnd{
+			Left:  queryAnd{Left: queryText{Value: "blog"}, Right: queryText{Value: "or"}},
+			Right: queryText{Value: "not"},
+		}},
+		{input: "tag:go", want: queryTag{Tag: "go"}},
+		{input: "tag:lang/*", want: queryTag{Tag: "lang/*"}},
+		{input: "tag:-archived", want: queryNot{Operand: queryTag{Tag: "archived"}}},
+		{input: "-tag:archived", want: queryNot{Operand: queryTag{Tag: "archived"}}},
+		{
+			input: "tag:go tag:web",
+			want:  queryAnd{Left: queryTag{Tag: "go"}, Right: queryTag{Tag: "web"}},
+		},
+		{input: `folder:"Work/Docs"`, want: queryFolder{Path: []string{"Work", "Docs"}}},
+		{input: "folder:Work", want: queryFolder{Path: []string{"Work"}}},
+		{input: "url:github.com", want: queryContains{Field: "url", Value: "github.com"}},
+		{input: `name:"flat field"`, want: queryContains{Field: "name", Value: "flat field"}},
+		{input: "description:blog", want: queryContains{Field: "description", Value: "blog"}},
+		{input: "modified:2024-01-01", want: queryModified{Comparison: db.ComparisonEqual, Date: "2024-01-01"}},
+		{input: "modified:>2024-01-01", want: queryModified{Comparison: db.ComparisonGreater, Date: "2024-01-01"}},
+		{input: "modified:>=2024-01-01", want: queryModified{Comparison: db.ComparisonGreaterOrEqual, Date: "2024-01-01"}},
+		{input: "modified:<2024-01-01", want: queryModified{Comparison: db.ComparisonLess, Date: "2024-01-01"}},
+		{input: "modified:<=2024-01-01", want: queryModified{Comparison: db.ComparisonLessOrEqual, Date: "2024-01-01"}},
+		{input: "created:2024-01-01", want: queryCreated{Comparison: db.ComparisonEqual, Date: "2024-01-01"}},
+		{input: "created:<2024-01-01", want: queryCreated{Comparison: db.ComparisonLess, Date: "2024-01-01"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := parseQuery(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			diff := cmp.Diff(got, tc.want)
+			if diff != "" {
+				t.Errorf("Expected and actual query different:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	type test struct {
+		input string
+		want  string
+	}
+
+	tests := []test{
+		{input: "", want: "unexpected end of query at position 1"},
+		{input: "blog OR", want: "unexpected end of query at position 8"},
+		{input: "OR blog", want: "unexpected OR at position 1"},
+		{input: "blog AND OR field", want: "unexpected OR at position 10"},
+		{input: "blog)", want: `unexpected ")" at position 5`},
+		{input: "(blog", want: `missing ")" at position 1`},
+		{input: `flat "field`, want: "unterminated phrase at position 6"},
+		{input: "tag:", want: "missing value for tag at position 1"},
+		{input: "blog tag:-", want: "missing value for tag at position 6"},
+		{input: "folder:/", want: "missing folder name at position 8"},
+		{input: "modified:>2024-13-01", want: `invalid date "2024-13-01"; expected YYYY-MM-DD at position 10`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := parseQuery(tc.input)
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Fatalf("got %+v; want %+v", err, ErrInvalidQuery)
+			}
+
+			if err.Error() != tc.want {
+				t.Errorf("got %s; want %s", err.Error(), tc.want)
+			}
+		})
+	}
+}
+
+func TestCompileQueryMatch(t *testing.T) {
+	type test struct {
+		input string
+		want  null.NullString
+	}
+
+	tests := []test{
+		{input: "blog", want: null.NullStringFrom(`"blog"`)},
+		{input: `"flat field" blo*`, want: null.NullStringFrom(`"flat field" OR "blo"*`)},
+		{input: "blog -armaria", want: null.NullStringFrom(`"blog"`)},
+		{input: "NOT (blog OR -armaria)", want: null.NullStringFrom(`"armaria"`)},
+		{input: "tag:go", want: null.NullString{}},
+		{input: "go tools", want: null.NullStringFrom(`"tools"`)},
+		{input: "go", want: null.NullString{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := compileQuery(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			diff := cmp.Diff(got.match, tc.want)
+			if diff != "" {
+				t.Errorf("Expected and actual match different:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompileNodeUnsupported(t *testing.T) {
+	_, err := compileNode(queryAnd{Left: queryText{Value: "blog"}, Right: struct{}{}})
+	if !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("got %+v; want %+v", err, ErrInvalidQuery)
+	}
+}
@@ -0,0 +1,15 @@
+package armaria
+
+import "github.com/jonathanhope/armaria/internal/db"
+
+// StartSession keeps connections to the bookmarks database open between calls.
+// Long lived processes should start a session so they only connect once.
+// Call EndSession to close the connections.
+func StartSession() {
+	db.StartSession()
+}
+
+// EndSession closes any connections that were kept open by StartSession.
+func EndSession() error {
+	return db.EndSession()
+}
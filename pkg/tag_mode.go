@@ -0,0 +1,8 @@
+package armaria
+
+import "github.com/jonathanhope/armaria/internal/db"
+
+type TagMode = db.TagMode
+
+const TagModeAny = db.TagModeAny
+const TagModeAll = db.TagModeAll
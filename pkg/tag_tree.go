@@ -0,0 +1,84 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/samber/lo"
+)
+
+// TagSeparator separates the levels of a hierarchical tag such as lang/go.
+const TagSeparator = db.TagSeparator
+
+// TagDescendants ends a tag pattern such as lang/* that matches every tag underneath a namespace.
+const TagDescendants = db.TagDescendants
+
+// TagNode is a level in the tree of hierarchical tags.
+type TagNode struct {
+	Name     string    // the last level of the tag such as go for lang/go
+	Tag      string    // the full tag such as lang/go
+	Count    int64     // the number of bookmarks the tag is applied to; 0 if it's only a namespace
+	Children []TagNode // the tags underneath this one
+}
+
+// ListTagTree lists the tags in the bookmarks database as a tree of namespaces.
+// A tag such as lang/go is listed as go underneath lang.
+func ListTagTree(options *listTagsOptions) ([]TagNode, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return nil, fmt.Errorf("error getting config while listing tag tree: %w", err)
+	}
+
+	return db.QueryWithDB(options.DB, config.DB, func(tx db.Transaction) ([]TagNode, error) {
+		return listTagTree(tx, options)
+	})
+}
+
+// listTagTree lists tags as a tree using an existing transaction.
+func listTagTree(tx db.Transaction, options *listTagsOptions) ([]TagNode, error) {
+	if err := validateQuery(options.Query); err != nil {
+		return nil, fmt.Errorf("query validation failed while listing tag tree: %w", err)
+	}
+
+	tags, err := db.GetTagCounts(tx, db.GetTagsArgs{
+		Query:      options.Query,
+		Direction:  DirectionAsc,
+		ActiveOnly: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while listing tag tree: %w", err)
+	}
+
+	return tagTree(lo.Map(tags, toTagCount), ""), nil
+}
+
+// tagTree builds the level of the tag tree underneath a prefix such as lang/.
+func tagTree(counts []TagCount, prefix string) []TagNode {
+	names := make([]string, 0)
+	for _, count := range counts {
+		if !strings.HasPrefix(count.Tag, prefix) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(strings.TrimPrefix(count.Tag, prefix), TagSeparator)
+		names = append(names, name)
+	}
+
+	names = lo.Uniq(names)
+	sort.Strings(names)
+
+	return lo.Map(names, func(name string, _ int) TagNode {
+		tag := prefix + name
+		count, _ := lo.Find(counts, func(count TagCount) bool { return count.Tag == tag })
+
+		return TagNode{
+			Name:     name,
+			Tag:      tag,
+			Count:    count.Count,
+			Children: tagTree(counts, tag+TagSeparator),
+		}
+	})
+}
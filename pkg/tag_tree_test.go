@@ -0,0 +1,38 @@
+package armaria
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTagTree(t *testing.T) {
+	counts := []TagCount{
+		{Tag: "blog", Count: 1},
+		{Tag: "lang-x", Count: 1},
+		{Tag: "lang/go", Count: 3},
+		{Tag: "lang/rust", Count: 2},
+		{Tag: "proj/armaria/cli", Count: 1},
+	}
+
+	want := []TagNode{
+		{Name: "blog", Tag: "blog", Count: 1, Children: []TagNode{}},
+		{Name: "lang", Tag: "lang", Children: []TagNode{
+			{Name: "go", Tag: "lang/go", Count: 3, Children: []TagNode{}},
+			{Name: "rust", Tag: "lang/rust", Count: 2, Children: []TagNode{}},
+		}},
+		{Name: "lang-x", Tag: "lang-x", Count: 1, Children: []TagNode{}},
+		{Name: "proj", Tag: "proj", Children: []TagNode{
+			{Name: "armaria", Tag: "proj/armaria", Children: []TagNode{
+				{Name: "cli", Tag: "proj/armaria/cli", Count: 1, Children: []TagNode{}},
+			}},
+		}},
+	}
+
+	got := tagTree(counts, "")
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Errorf("Expected and actual trees different:\n%s", diff)
+	}
+}
@@ -1,6 +1,7 @@
 package armaria
 
 import (
+	"encoding/json"
 	"strings"
 
 	"github.com/jonathanhope/armaria/internal/db"
@@ -25,11 +26,31 @@ func toBook(book db.BookDTO) Book {
 		ParentID:    null.PtrFromNullString(book.ParentID),
 		IsFolder:    book.IsFolder,
 		Order:       book.Order,
+		Created:     book.Created,
+		Modified:    book.Modified,
+		DeletedAt:   null.PtrFromNullString(book.DeletedAt),
 		ParentName:  null.PtrFromNullString(book.ParentName),
 		Tags:        parseTags(book.Tags),
+		Snippet:     null.PtrFromNullString(book.Snippet),
+		Depth:       null.PtrFromNullInt64(book.Depth),
+		Path:        parsePath(book.Path),
 	}
 }
 
+// parsePath parses the JSON array of names coming back from the database.
+func parsePath(path null.NullString) []string {
+	if !path.Valid {
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal([]byte(path.String), &names); err != nil {
+		return nil
+	}
+
+	return names
+}
+
 // parseTags parses the tags coming back from the database.
 func parseTags(tags string) []string {
 	if tags == "" {
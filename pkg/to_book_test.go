@@ -4,8 +4,33 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+
+	"github.com/jonathanhope/armaria/internal/null"
 )
 
+func TestParsePath(t *testing.T) {
+	type test struct {
+		input null.NullString
+		want  []string
+	}
+
+	tests := []test{
+		{input: null.NullString{}, want: nil},
+		{input: null.NullStringFrom(`["Work"]`), want: []string{"Work"}},
+		{input: null.NullStringFrom(`["Work","Docs/Guides"]`), want: []string{"Work", "Docs/Guides"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%+v", tc.input.String), func(t *testing.T) {
+			got := parsePath(tc.input)
+			equal := reflect.DeepEqual(got, tc.want)
+			if !equal {
+				t.Errorf("got %+v; want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestParseTags(t *testing.T) {
 	type test struct {
 		input string
@@ -0,0 +1,63 @@
+package armaria
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jonathanhope/armaria/internal/db"
+	"github.com/jonathanhope/armaria/internal/null"
+)
+
+// undoOptions are the optional arguments for Undo.
+type undoOptions struct {
+	DB null.NullString
+}
+
+// DefaultUndoOptions are the default options for Undo.
+func DefaultUndoOptions() *undoOptions {
+	return &undoOptions{}
+}
+
+// WithDB sets the location of the bookmarks database.
+func (o *undoOptions) WithDB(db string) *undoOptions {
+	o.DB = null.NullStringFrom(db)
+	return o
+}
+
+// Undo reverts the most recent operation in the journal that hasn't been undone.
+// The affected bookmarks/folders are put back exactly as they were before the operation.
+func Undo(options *undoOptions) (JournalEntry, error) {
+	config, err := GetConfig()
+	if err != nil && !errors.Is(err, ErrConfigMissing) {
+		return JournalEntry{}, fmt.Errorf("error getting config while undoing: %w", err)
+	}
+
+	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (JournalEntry, error) {
+		entries, err := db.GetUndoEntry(tx)
+		if err != nil {
+			return JournalEntry{}, fmt.Errorf("error getting journal entry while undoing: %w", err)
+		}
+
+		if len(entries) == 0 {
+			return JournalEntry{}, ErrNothingToUndo
+		}
+
+		before, after, err := parseJournalEntry(entries[0])
+		if err != nil {
+			return JournalEntry{}, fmt.Errorf("error parsing journal entry while undoing: %w", err)
+		}
+
+		if err := restore(tx, after, before); err != nil {
+			return JournalEntry{}, fmt.Errorf("error restoring bookmarks while undoing: %w", err)
+		}
+
+		if err := db.SetJournalEntryUndone(tx, entries[0].ID, true); err != nil {
+			return JournalEntry{}, fmt.Errorf("error updating journal entry while undoing: %w", err)
+		}
+
+		entry := toJournalEntry(entries[0])
+		entry.Undone = true
+
+		return entry, nil
+	})
+}
@@ -87,80 +87,97 @@ func UpdateBook(id string, options *updateBookOptions) (Book, error) {
 	}
 
 	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
-		if err := validateBookID(tx, id); err != nil {
-			return Book{}, fmt.Errorf("bookmark ID validation failed while updating bookmark: %w", err)
-		}
+		return updateBook(tx, id, options)
+	})
+}
 
-		if !options.Name.Dirty && !options.URL.Dirty && !options.Description.Dirty && !options.ParentID.Dirty && !options.PreviousBook.Dirty && !options.NextBook.Dirty {
-			return Book{}, ErrNoUpdate
-		}
+// updateBook updates a bookmark using an existing transaction.
+func updateBook(tx db.Transaction, id string, options *updateBookOptions) (Book, error) {
+	if err := validateBookID(tx, id); err != nil {
+		return Book{}, fmt.Errorf("bookmark ID validation failed while updating bookmark: %w", err)
+	}
 
-		if options.Name.Dirty {
-			if err := validateName(options.Name); err != nil {
-				return Book{}, fmt.Errorf("name validation failed while updating bookmark: %w", err)
-			}
+	if !options.Name.Dirty && !options.URL.Dirty && !options.Description.Dirty && !options.ParentID.Dirty && !options.PreviousBook.Dirty && !options.NextBook.Dirty {
+		return Book{}, ErrNoUpdate
+	}
+
+	before, err := snapshot(tx, []string{id})
+	if err != nil {
+		return Book{}, fmt.Errorf("error taking snapshot while updating bookmark: %w", err)
+	}
+
+	if options.Name.Dirty {
+		if err := validateName(options.Name); err != nil {
+			return Book{}, fmt.Errorf("name validation failed while updating bookmark: %w", err)
 		}
+	}
 
-		if options.URL.Dirty {
-			if err := validateURL(options.URL); err != nil {
-				return Book{}, fmt.Errorf("URL validation failed while updating bookmark: %w", err)
-			}
+	if options.URL.Dirty {
+		if err := validateURL(options.URL); err != nil {
+			return Book{}, fmt.Errorf("URL validation failed while updating bookmark: %w", err)
 		}
+	}
 
-		if options.Description.Dirty {
-			if err := validateDescription(options.Description); err != nil {
-				return Book{}, fmt.Errorf("description validation failed while updating bookmark: %w", err)
-			}
+	if options.Description.Dirty {
+		if err := validateDescription(options.Description); err != nil {
+			return Book{}, fmt.Errorf("description validation failed while updating bookmark: %w", err)
 		}
+	}
 
-		if options.ParentID.Dirty {
-			if err := validateParentID(tx, options.ParentID); err != nil {
-				return Book{}, fmt.Errorf("parent ID validation failed while updating bookmark: %w", err)
-			}
+	if options.ParentID.Dirty {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return Book{}, fmt.Errorf("parent ID validation failed while updating bookmark: %w", err)
 		}
+	}
+
+	current, err := validateOrdering(tx, options.PreviousBook, options.NextBook)
+	if err != nil {
+		return Book{}, fmt.Errorf("ordering validation failed while updating bookmark: %w", err)
+	}
 
-		current, err := validateOrdering(tx, options.PreviousBook, options.NextBook)
+	if current == "" && options.ParentID.Dirty {
+		previous, err := db.MaxOrder(tx, options.ParentID)
 		if err != nil {
-			return Book{}, fmt.Errorf("ordering validation failed while updating bookmark: %w", err)
+			return Book{}, fmt.Errorf("error getting max order while adding bookmark: %w", err)
 		}
 
-		if current == "" && options.ParentID.Dirty {
-			previous, err := db.MaxOrder(tx, options.ParentID)
+		if previous == "" {
+			current, err = order.Initial()
 			if err != nil {
-				return Book{}, fmt.Errorf("error getting max order while adding bookmark: %w", err)
+				return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
 			}
-
-			if previous == "" {
-				current, err = order.Initial()
-				if err != nil {
-					return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
-				}
-			} else {
-				current, err = order.End(previous)
-				if err != nil {
-					return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
-				}
+		} else {
+			current, err = order.End(previous)
+			if err != nil {
+				return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
 			}
 		}
+	}
 
-		if err := db.UpdateBook(tx, id, db.UpdateBookArgs{
-			Name:        options.Name,
-			URL:         options.URL,
-			Description: options.Description,
-			ParentID:    options.ParentID,
-			Order:       current,
-		}); err != nil {
-			return Book{}, fmt.Errorf("error while updating bookmark: %w", err)
-		}
-
-		books, err := db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:     id,
-			IncludeBooks: true,
-		})
-		if err != nil {
-			return Book{}, fmt.Errorf("error getting bookmarks while updating bookmark: %w", err)
-		}
+	if err := db.UpdateBook(tx, id, db.UpdateBookArgs{
+		Name:        options.Name,
+		URL:         options.URL,
+		Description: options.Description,
+		ParentID:    options.ParentID,
+		Order:       current,
+	}); err != nil {
+		return Book{}, fmt.Errorf("error while updating bookmark: %w", err)
+	}
 
-		return toBook(books[0]), nil
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:     id,
+		IncludeBooks: true,
 	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error getting bookmarks while updating bookmark: %w", err)
+	}
+
+	book := toBook(books[0])
+
+	description := fmt.Sprintf("Updated bookmark %q", book.Name)
+	if err := record(tx, OperationUpdateBook, description, []string{id}, before); err != nil {
+		return Book{}, fmt.Errorf("error recording operation while updating bookmark: %w", err)
+	}
+
+	return book, nil
 }
@@ -67,66 +67,87 @@ func UpdateFolder(id string, options *updateFolderOptions) (Book, error) {
 	}
 
 	return db.QueryWithTransaction(options.DB, config.DB, func(tx db.Transaction) (Book, error) {
-		if err := validateParentID(tx, null.NullStringFrom(id)); err != nil {
-			return Book{}, fmt.Errorf("bookmark ID validation failed while updating folder: %w", err)
-		}
+		return updateFolder(tx, id, options)
+	})
+}
+
+// updateFolder updates a folder using an existing transaction.
+func updateFolder(tx db.Transaction, id string, options *updateFolderOptions) (Book, error) {
+	if err := validateParentID(tx, null.NullStringFrom(id)); err != nil {
+		return Book{}, fmt.Errorf("bookmark ID validation failed while updating folder: %w", err)
+	}
+
+	if !options.Name.Dirty && !options.ParentID.Dirty && !options.PreviousBook.Dirty && !options.NextBook.Dirty {
+		return Book{}, ErrNoUpdate
+	}
 
-		if !options.Name.Dirty && !options.ParentID.Dirty && !options.PreviousBook.Dirty && !options.NextBook.Dirty {
-			return Book{}, ErrNoUpdate
+	before, err := snapshot(tx, []string{id})
+	if err != nil {
+		return Book{}, fmt.Errorf("error taking snapshot while updating folder: %w", err)
+	}
+
+	if options.Name.Dirty {
+		if err := validateName(options.Name); err != nil {
+			return Book{}, fmt.Errorf("name validation failed while updating folder: %w", err)
 		}
+	}
 
-		if options.Name.Dirty {
-			if err := validateName(options.Name); err != nil {
-				return Book{}, fmt.Errorf("name validation failed while updating folder: %w", err)
-			}
+	if options.ParentID.Dirty {
+		if err := validateParentID(tx, options.ParentID); err != nil {
+			return Book{}, fmt.Errorf("parent ID validation failed while updating folder: %w", err)
 		}
 
-		if options.ParentID.Dirty {
-			if err := validateParentID(tx, options.ParentID); err != nil {
-				return Book{}, fmt.Errorf("parent ID validation failed while updating folder: %w", err)
-			}
+		if err := validateMove(tx, id, options.ParentID); err != nil {
+			return Book{}, fmt.Errorf("move validation failed while updating folder: %w", err)
 		}
+	}
+
+	current, err := validateOrdering(tx, options.PreviousBook, options.NextBook)
+	if err != nil {
+		return Book{}, fmt.Errorf("ordering validation failed while updating bookmark: %w", err)
+	}
 
-		current, err := validateOrdering(tx, options.PreviousBook, options.NextBook)
+	if current == "" && options.ParentID.Dirty {
+		previous, err := db.MaxOrder(tx, options.ParentID)
 		if err != nil {
-			return Book{}, fmt.Errorf("ordering validation failed while updating bookmark: %w", err)
+			return Book{}, fmt.Errorf("error getting max order while adding bookmark: %w", err)
 		}
 
-		if current == "" && options.ParentID.Dirty {
-			previous, err := db.MaxOrder(tx, options.ParentID)
+		if previous == "" {
+			current, err = order.Initial()
 			if err != nil {
-				return Book{}, fmt.Errorf("error getting max order while adding bookmark: %w", err)
+				return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
 			}
-
-			if previous == "" {
-				current, err = order.Initial()
-				if err != nil {
-					return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
-				}
-			} else {
-				current, err = order.End(previous)
-				if err != nil {
-					return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
-				}
+		} else {
+			current, err = order.End(previous)
+			if err != nil {
+				return Book{}, fmt.Errorf("error getting current order while adding bookmark: %w", err)
 			}
 		}
+	}
 
-		if err := db.UpdateFolder(tx, id, db.UpdateFolderArgs{
-			Name:     options.Name,
-			ParentID: options.ParentID,
-			Order:    current,
-		}); err != nil {
-			return Book{}, fmt.Errorf("error while updating folder: %w", err)
-		}
-
-		books, err := db.GetBooks(tx, db.GetBooksArgs{
-			IDFilter:       id,
-			IncludeFolders: true,
-		})
-		if err != nil {
-			return Book{}, fmt.Errorf("error geting bookmarks while updating folder: %w", err)
-		}
+	if err := db.UpdateFolder(tx, id, db.UpdateFolderArgs{
+		Name:     options.Name,
+		ParentID: options.ParentID,
+		Order:    current,
+	}); err != nil {
+		return Book{}, fmt.Errorf("error while updating folder: %w", err)
+	}
 
-		return toBook(books[0]), nil
+	books, err := db.GetBooks(tx, db.GetBooksArgs{
+		IDFilter:       id,
+		IncludeFolders: true,
 	})
+	if err != nil {
+		return Book{}, fmt.Errorf("error geting bookmarks while updating folder: %w", err)
+	}
+
+	book := toBook(books[0])
+
+	description := fmt.Sprintf("Updated folder %q", book.Name)
+	if err := record(tx, OperationUpdateFolder, description, []string{id}, before); err != nil {
+		return Book{}, fmt.Errorf("error recording operation while updating folder: %w", err)
+	}
+
+	return book, nil
 }
@@ -3,6 +3,8 @@ package armaria
 import (
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/jonathanhope/armaria/internal/db"
 	"github.com/jonathanhope/armaria/internal/null"
@@ -66,7 +68,8 @@ func validateDescription(description null.NullString) error {
 // validateTags validates a tags value.
 // They must be unique.
 // Each must have a length >= 1 and <= 128.
-// Each must be comprised of the chars A-Z a-z 0-9 - _
+// Each must be comprised of the chars A-Z a-z 0-9 - _ /
+// Each namespace separated by / must be non-empty.
 func validateTags(tags []string, existingTags []string) error {
 	if len(tags)+len(existingTags) > 24 {
 		return ErrTooManyTags
@@ -76,7 +79,7 @@ func validateTags(tags []string, existingTags []string) error {
 		return ErrDuplicateTag
 	}
 
-	r, err := regexp.Compile(`^[a-zA-Z0-9\-_]*$`)
+	r, err := regexp.Compile(`^[a-zA-Z0-9\-_/]*$`)
 	if err != nil {
 		return fmt.Errorf("error compiling regex while validating tags: %w", err)
 	}
@@ -95,6 +98,10 @@ func validateTags(tags []string, existingTags []string) error {
 			return ErrTagInvalidChar
 		}
 
+		if lo.Contains(strings.Split(tag, TagSeparator), "") {
+			return ErrTagEmptyNamespace
+		}
+
 		if lo.Contains(existingTags, tag) {
 			return ErrDuplicateTag
 		}
@@ -117,16 +124,80 @@ func validateFirst(limit null.NullInt64) error {
 	return nil
 }
 
+// validateDepth validates a depth value.
+// It's optional, but if it is provided it must be > 0.
+func validateDepth(depth null.NullInt64) error {
+	if !depth.Valid {
+		return nil
+	}
+
+	if depth.Int64 <= 0 {
+		return ErrDepthTooSmall
+	}
+
+	return nil
+}
+
+// validateConcurrency validates how many URLs are checked at once.
+// It must be > 0.
+func validateConcurrency(concurrency int) error {
+	if concurrency <= 0 {
+		return ErrConcurrencyTooSmall
+	}
+
+	return nil
+}
+
+// validateTimeout validates how long to wait for a URL.
+// It must be > 0.
+func validateTimeout(timeout time.Duration) error {
+	if timeout <= 0 {
+		return ErrTimeoutTooSmall
+	}
+
+	return nil
+}
+
+// validateOlderThan validates how long bookmarks/folders must be in the trash before they are purged.
+// It must be >= 0.
+func validateOlderThan(olderThan time.Duration) error {
+	if olderThan < 0 {
+		return ErrOlderThanTooSmall
+	}
+
+	return nil
+}
+
+// validateHostInterval validates how long to wait between requests to the same host.
+// It must be >= 0.
+func validateHostInterval(interval time.Duration) error {
+	if interval < 0 {
+		return ErrHostIntervalTooSmall
+	}
+
+	return nil
+}
+
 // validateOrder validates an order value.
 // It must be modified or name.
 func validateOrder(order Order) error {
-	if order != OrderModified && order != OrderName && order != OrderManual {
+	if order != OrderModified && order != OrderName && order != OrderManual && order != OrderRelevance {
 		return ErrInvalidOrder
 	}
 
 	return nil
 }
 
+// validateTagMode validates a tag mode value.
+// It must be any or all.
+func validateTagMode(mode TagMode) error {
+	if mode != TagModeAny && mode != TagModeAll {
+		return ErrInvalidTagMode
+	}
+
+	return nil
+}
+
 // validateDirection validates a direction value.
 // It must be asc or desc.
 func validateDirection(direction Direction) error {
@@ -138,6 +209,16 @@ func validateDirection(direction Direction) error {
 
 }
 
+// validateRestoreMode validates a restore mode value.
+// It must be replace or merge.
+func validateRestoreMode(mode RestoreMode) error {
+	if mode != RestoreModeReplace && mode != RestoreModeMerge {
+		return ErrInvalidRestoreMode
+	}
+
+	return nil
+}
+
 // validateQuery validates a query value.
 // It's optional must be at least 3 chars long.
 func validateQuery(query null.NullString) error {
@@ -145,7 +226,7 @@ func validateQuery(query null.NullString) error {
 		return nil
 	}
 
-	if len(query.String) < minQueryLength {
+	if len([]rune(query.String)) < minQueryLength {
 		return ErrQueryTooShort
 	}
 
@@ -186,6 +267,25 @@ func validateParentID(tx db.Transaction, parentID null.NullString) error {
 	return nil
 }
 
+// validateMove validates moving a folder to a new parent.
+// The new parent can't be the folder itself or one of its descendants since that would create a cycle.
+func validateMove(tx db.Transaction, ID string, parentID null.NullString) error {
+	if !parentID.Valid {
+		return nil
+	}
+
+	children, err := db.GetParentAndChildren(tx, ID)
+	if err != nil {
+		return fmt.Errorf("error getting folder and children while validating move: %w", err)
+	}
+
+	if lo.ContainsBy(children, func(child db.BookDTO) bool { return child.ID == parentID.String }) {
+		return ErrMoveIntoSelf
+	}
+
+	return nil
+}
+
 // validateOrdering validates the values used for manual ordering.
 // The previousBook value must be < the nextBook value.
 func validateOrdering(tx db.Transaction, previousID null.NullString, nextID null.NullString) (string, error) {
@@ -236,3 +336,79 @@ func validateOrdering(tx db.Transaction, previousID null.NullString, nextID null
 
 	return "", nil
 }
+
+// validateBackup validates a backup.
+// It must be the current version.
+// Its rows must be valid and only reference rows that are also in the backup.
+// The only exception is parent folders which may already be in the database.
+func validateBackup(backup Backup) error {
+	if backup.Version != BackupVersion {
+		return ErrUnsupportedBackupVersion
+	}
+
+	isFolder := make(map[string]bool)
+	for _, bookmark := range backup.Bookmarks {
+		if _, ok := isFolder[bookmark.ID]; ok || bookmark.ID == "" {
+			return ErrInvalidBackup
+		}
+		isFolder[bookmark.ID] = bookmark.IsFolder
+	}
+
+	for _, bookmark := range backup.Bookmarks {
+		if err := validateName(null.NullStringFrom(bookmark.Name)); err != nil {
+			return err
+		}
+
+		if bookmark.IsFolder && bookmark.URL != nil {
+			return ErrInvalidBackup
+		}
+
+		if !bookmark.IsFolder {
+			if err := validateURL(null.NullStringFromPtr(bookmark.URL)); err != nil {
+				return err
+			}
+		}
+
+		if err := validateDescription(null.NullStringFromPtr(bookmark.Description)); err != nil {
+			return err
+		}
+
+		if bookmark.ParentID != nil {
+			folder, ok := isFolder[*bookmark.ParentID]
+			if (ok && !folder) || *bookmark.ParentID == bookmark.ID {
+				return ErrInvalidBackup
+			}
+		}
+	}
+
+	if len(sortParentsFirst(backup.Bookmarks)) != len(backup.Bookmarks) {
+		return ErrInvalidBackup
+	}
+
+	tags := make([]string, 0)
+	for _, tag := range backup.Tags {
+		if lo.Contains(tags, tag.Tag) {
+			return ErrInvalidBackup
+		}
+
+		if err := validateTags([]string{tag.Tag}, nil); err != nil {
+			return err
+		}
+		tags = append(tags, tag.Tag)
+	}
+
+	bookmarkTags := make(map[string][]string)
+	for _, bookmarkTag := range backup.BookmarksTags {
+		folder, ok := isFolder[bookmarkTag.BookmarkID]
+		if !ok || folder || !lo.Contains(tags, bookmarkTag.Tag) {
+			return ErrInvalidBackup
+		}
+
+		if err := validateTags([]string{bookmarkTag.Tag}, bookmarkTags[bookmarkTag.BookmarkID]); err != nil {
+			return err
+		}
+		bookmarkTags[bookmarkTag.BookmarkID] = append(bookmarkTags[bookmarkTag.BookmarkID], bookmarkTag.Tag)
+	}
+
+	return nil
+}
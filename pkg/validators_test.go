@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jonathanhope/armaria/internal/null"
+	"github.com/samber/lo"
 )
 
 func TestURL(t *testing.T) {
@@ -101,6 +103,10 @@ func TestTags(t *testing.T) {
 		{input: []string{stringOfLength("x", 129)}, existing: []string{}, want: ErrTagTooLong},
 		{input: []string{stringOfLength("x", 128)}, existing: []string{}, want: nil},
 		{input: []string{"?"}, existing: []string{}, want: ErrTagInvalidChar},
+		{input: []string{"lang/go"}, existing: []string{}, want: nil},
+		{input: []string{"lang//go"}, existing: []string{}, want: ErrTagEmptyNamespace},
+		{input: []string{"/go"}, existing: []string{}, want: ErrTagEmptyNamespace},
+		{input: []string{"lang/"}, existing: []string{}, want: ErrTagEmptyNamespace},
 		{input: twentyFiveTags, existing: []string{}, want: ErrTooManyTags},
 		{input: twentyFourTags, existing: []string{}, want: nil},
 	}
@@ -133,6 +139,106 @@ func TestFirst(t *testing.T) {
 	}
 }
 
+func TestDepth(t *testing.T) {
+	type test struct {
+		input null.NullInt64
+		want  error
+	}
+
+	tests := []test{
+		{input: nullInt64(1, false), want: nil},
+		{input: nullInt64(0, true), want: nil},
+		{input: nullInt64(0, false), want: ErrDepthTooSmall},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%d", tc.input.Int64), func(t *testing.T) {
+			got := validateDepth(tc.input)
+			validateValidator(t, tc.want, got)
+		})
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	type test struct {
+		input int
+		want  error
+	}
+
+	tests := []test{
+		{input: 1, want: nil},
+		{input: 0, want: ErrConcurrencyTooSmall},
+		{input: -1, want: ErrConcurrencyTooSmall},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%d", tc.input), func(t *testing.T) {
+			got := validateConcurrency(tc.input)
+			validateValidator(t, tc.want, got)
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	type test struct {
+		input time.Duration
+		want  error
+	}
+
+	tests := []test{
+		{input: time.Second, want: nil},
+		{input: 0, want: ErrTimeoutTooSmall},
+		{input: -time.Second, want: ErrTimeoutTooSmall},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input.String(), func(t *testing.T) {
+			got := validateTimeout(tc.input)
+			validateValidator(t, tc.want, got)
+		})
+	}
+}
+
+func TestHostInterval(t *testing.T) {
+	type test struct {
+		input time.Duration
+		want  error
+	}
+
+	tests := []test{
+		{input: time.Second, want: nil},
+		{input: 0, want: nil},
+		{input: -time.Second, want: ErrHostIntervalTooSmall},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input.String(), func(t *testing.T) {
+			got := validateHostInterval(tc.input)
+			validateValidator(t, tc.want, got)
+		})
+	}
+}
+
+func TestOlderThan(t *testing.T) {
+	type test struct {
+		input time.Duration
+		want  error
+	}
+
+	tests := []test{
+		{input: time.Hour, want: nil},
+		{input: 0, want: nil},
+		{input: -time.Hour, want: ErrOlderThanTooSmall},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input.String(), func(t *testing.T) {
+			got := validateOlderThan(tc.input)
+			validateValidator(t, tc.want, got)
+		})
+	}
+}
+
 func TestOrder(t *testing.T) {
 	type test struct {
 		input Order
@@ -143,6 +249,7 @@ func TestOrder(t *testing.T) {
 		{input: OrderName, want: nil},
 		{input: OrderModified, want: nil},
 		{input: OrderManual, want: nil},
+		{input: OrderRelevance, want: nil},
 		{input: "", want: ErrInvalidOrder},
 		{input: "Description", want: ErrInvalidOrder},
 	}
@@ -155,6 +262,27 @@ func TestOrder(t *testing.T) {
 	}
 }
 
+func TestTagMode(t *testing.T) {
+	type test struct {
+		input TagMode
+		want  error
+	}
+
+	tests := []test{
+		{input: TagModeAny, want: nil},
+		{input: TagModeAll, want: nil},
+		{input: "", want: ErrInvalidTagMode},
+		{input: "none", want: ErrInvalidTagMode},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.input), func(t *testing.T) {
+			got := validateTagMode(tc.input)
+			validateValidator(t, tc.want, got)
+		})
+	}
+}
+
 func TestDirection(t *testing.T) {
 	type test struct {
 		input Direction
@@ -196,6 +324,129 @@ func TestQuery(t *testing.T) {
 	}
 }
 
+func TestRestoreMode(t *testing.T) {
+	type test struct {
+		input RestoreMode
+		want  error
+	}
+
+	tests := []test{
+		{input: RestoreModeReplace, want: nil},
+		{input: RestoreModeMerge, want: nil},
+		{input: "", want: ErrInvalidRestoreMode},
+		{input: "append", want: ErrInvalidRestoreMode},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.input), func(t *testing.T) {
+			got := validateRestoreMode(tc.input)
+			validateValidator(t, tc.want, got)
+		})
+	}
+}
+
+func TestBackup(t *testing.T) {
+	type test struct {
+		name  string
+		input Backup
+		want  error
+	}
+
+	url := "https://jho.pe"
+	folderID := "1"
+	bookID := "2"
+
+	valid := func() Backup {
+		return Backup{
+			Version: BackupVersion,
+			Bookmarks: []BackupBookmark{
+				{ID: folderID, IsFolder: true, Name: "blogs"},
+				{ID: bookID, ParentID: &folderID, Name: "jho.pe", URL: &url},
+			},
+			Tags: []BackupTag{
+				{Tag: "blog"},
+			},
+			BookmarksTags: []BackupBookmarkTag{
+				{BookmarkID: bookID, Tag: "blog"},
+			},
+		}
+	}
+
+	tests := []test{
+		{name: "valid", input: valid(), want: nil},
+		{name: "unsupported version", input: func() Backup {
+			backup := valid()
+			backup.Version = BackupVersion + 1
+			return backup
+		}(), want: ErrUnsupportedBackupVersion},
+		{name: "duplicate ID", input: func() Backup {
+			backup := valid()
+			backup.Bookmarks[1].ID = folderID
+			return backup
+		}(), want: ErrInvalidBackup},
+		{name: "invalid name", input: func() Backup {
+			backup := valid()
+			backup.Bookmarks[0].Name = ""
+			return backup
+		}(), want: ErrNameTooShort},
+		{name: "folder with URL", input: func() Backup {
+			backup := valid()
+			backup.Bookmarks[0].URL = &url
+			return backup
+		}(), want: ErrInvalidBackup},
+		{name: "bookmark without URL", input: func() Backup {
+			backup := valid()
+			backup.Bookmarks[1].URL = nil
+			return backup
+		}(), want: ErrURLTooShort},
+		{name: "parent is a bookmark", input: func() Backup {
+			backup := valid()
+			backup.Bookmarks[0].ParentID = &bookID
+			return backup
+		}(), want: ErrInvalidBackup},
+		{name: "parent cycle", input: func() Backup {
+			backup := valid()
+			backup.Bookmarks = append(backup.Bookmarks,
+				BackupBookmark{ID: "3", ParentID: lo.ToPtr("4"), IsFolder: true, Name: "a"},
+				BackupBookmark{ID: "4", ParentID: lo.ToPtr("3"), IsFolder: true, Name: "b"},
+			)
+			return backup
+		}(), want: ErrInvalidBackup},
+		{name: "duplicate tag", input: func() Backup {
+			backup := valid()
+			backup.Tags = append(backup.Tags, BackupTag{Tag: "blog"})
+			return backup
+		}(), want: ErrInvalidBackup},
+		{name: "invalid tag", input: func() Backup {
+			backup := valid()
+			backup.Tags[0].Tag = "b!og"
+			return backup
+		}(), want: ErrTagInvalidChar},
+		{name: "missing tag", input: func() Backup {
+			backup := valid()
+			backup.BookmarksTags[0].Tag = "programming"
+			return backup
+		}(), want: ErrInvalidBackup},
+		{name: "tag applied to folder", input: func() Backup {
+			backup := valid()
+			backup.BookmarksTags[0].BookmarkID = folderID
+			return backup
+		}(), want: ErrInvalidBackup},
+		{name: "tag applied twice", input: func() Backup {
+			backup := valid()
+			backup.BookmarksTags = append(backup.BookmarksTags, backup.BookmarksTags[0])
+			return backup
+		}(), want: ErrDuplicateTag},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := validateBackup(tc.input)
+			validateValidator(t, tc.want, got)
+		})
+	}
+}
+
 // nullStringOfLength generates a string of a desired length
 func stringOfLength(substr string, length int) string {
 	var str = ""